@@ -2,55 +2,328 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
+	"time"
 
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	metricsclient "k8s.io/metrics/pkg/client/clientset/versioned"
 
 	"github.com/zvelocity/k8s-cli/internal/resources"
 )
 
 // K8sClient wraps kubernetes clientset with helper methods
 type K8sClient struct {
-	Clientset *kubernetes.Clientset
+	Clientset kubernetes.Interface
+	Metrics   *metricsclient.Clientset
+	Dynamic   dynamic.Interface
+
+	// Discovery caches discovery responses in memory so features looking up
+	// API groups and resources share a single round of requests. It never
+	// spans clusters: switching context builds a new client, cache included.
+	Discovery discovery.CachedDiscoveryInterface
+
+	// kubeconfig is the resolved path the client was built from, empty
+	// when connected with a token
+	kubeconfig string
+
+	// context is the context chosen instead of the current one, if any
+	context string
+
+	// server is the API server URL when connected with a token
+	server string
+
+	// impersonate is the user requests are made as, if any
+	impersonate string
+
+	// config is the resolved rest config, kept to reconnect
+	config *rest.Config
+}
+
+// Impersonation is the user and groups requests are made as instead of the
+// authenticated user, like kubectl's --as and --as-group. The zero value
+// doesn't impersonate.
+type Impersonation struct {
+	User   string
+	Groups []string
 }
 
-// New creates a new K8sClient
+// ErrNoCurrentContext is returned when the kubeconfig has contexts but none
+// of them is selected, so one has to be chosen
+var ErrNoCurrentContext = errors.New("kubeconfig has no current context")
+
+// New creates a new K8sClient using the KUBECONFIG env var or the default location
 func New() (*K8sClient, error) {
-	// Find kubeconfig
-	kubeconfig := os.Getenv("KUBECONFIG")
-	if kubeconfig == "" {
-		// Try default location if not specified
-		homeDir, err := os.UserHomeDir()
-		if err == nil {
-			kubeconfig = filepath.Join(homeDir, ".kube", "config")
+	return NewWithConfig("")
+}
+
+// NewWithConfig creates a new K8sClient from the given kubeconfig path. An empty
+// path falls back to the KUBECONFIG env var and then to ~/.kube/config.
+func NewWithConfig(path string) (*K8sClient, error) {
+	return NewWithContext(path, "", "", Impersonation{})
+}
+
+// NewWithContext creates a new K8sClient for a context of the given
+// kubeconfig, or for its current context when context is empty.
+// ErrNoCurrentContext is returned when no context is selected at all.
+//
+// apiServer, when set, replaces the server URL of the context while keeping
+// its credentials, e.g. to go through kubectl proxy. An error is returned when
+// it doesn't answer.
+//
+// Requests are made as the user of as when set. An error is returned when
+// the authenticated user isn't allowed to impersonate it.
+func NewWithContext(path, context, apiServer string, as Impersonation) (*K8sClient, error) {
+	kubeconfig := ResolveKubeconfig(path)
+	if apiServer != "" {
+		if err := ValidateServerURL(apiServer); err != nil {
+			return nil, err
+		}
+	}
+
+	// Building a config without a context fails with a cryptic error, let
+	// the caller offer the contexts instead
+	if context == "" {
+		if raw, err := clientcmd.LoadFromFile(kubeconfig); err == nil && raw.CurrentContext == "" && len(raw.Contexts) > 0 {
+			return nil, ErrNoCurrentContext
 		}
 	}
 
 	// Build config from kubeconfig
-	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfig},
+		&clientcmd.ConfigOverrides{
+			CurrentContext: context,
+			ClusterInfo:    clientcmdapi.Cluster{Server: apiServer},
+		},
+	).ClientConfig()
 	if err != nil {
 		return nil, fmt.Errorf("error building kubeconfig: %v", err)
 	}
+	if apiServer != "" {
+		if err := checkReachable(config); err != nil {
+			return nil, err
+		}
+	}
+
+	c, err := newImpersonating(config, as)
+	if err != nil {
+		return nil, err
+	}
+	c.kubeconfig = kubeconfig
+	c.context = context
 
+	return c, nil
+}
+
+// NewFromToken creates a new K8sClient connecting to server with a bearer
+// token, bypassing kubeconfig entirely. caCert is the path of a CA bundle used
+// to verify the server certificate. Requests are made as the user of as when
+// set.
+func NewFromToken(server, token, caCert string, insecure bool, as Impersonation) (*K8sClient, error) {
+	if insecure && caCert != "" {
+		return nil, errors.New("a CA certificate cannot be used together with insecure-skip-tls-verify")
+	}
+	if err := ValidateServerURL(server); err != nil {
+		return nil, err
+	}
+
+	config := &rest.Config{
+		Host:        server,
+		BearerToken: token,
+		TLSClientConfig: rest.TLSClientConfig{
+			CAFile:   caCert,
+			Insecure: insecure,
+		},
+	}
+
+	c, err := newImpersonating(config, as)
+	if err != nil {
+		return nil, err
+	}
+	c.server = server
+
+	return c, nil
+}
+
+// ValidateServerURL checks that server is an http or https URL with a host
+func ValidateServerURL(server string) error {
+	u, err := url.Parse(server)
+	if err != nil {
+		return fmt.Errorf("invalid server URL %q: %v", server, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid server URL %q: expected http:// or https:// followed by a host", server)
+	}
+	return nil
+}
+
+// reachableTimeout bounds the check that an overridden API server answers
+const reachableTimeout = 5 * time.Second
+
+// checkReachable fails when the API server of config doesn't answer. Any
+// answer counts, even a refused request: the server is there.
+func checkReachable(config *rest.Config) error {
+	checkConfig := rest.CopyConfig(config)
+	checkConfig.Timeout = reachableTimeout
+	dc, err := discovery.NewDiscoveryClientForConfig(checkConfig)
+	if err != nil {
+		return fmt.Errorf("error creating discovery client: %v", err)
+	}
+
+	if _, err := dc.ServerVersion(); err != nil {
+		var status apierrors.APIStatus
+		if !errors.As(err, &status) {
+			return fmt.Errorf("API server %s is unreachable: %v", config.Host, err)
+		}
+	}
+	return nil
+}
+
+// newImpersonating creates the clientsets for a resolved rest config, making
+// requests as the user of as when set
+func newImpersonating(config *rest.Config, as Impersonation) (*K8sClient, error) {
+	if as.User != "" {
+		config.Impersonate = rest.ImpersonationConfig{UserName: as.User, Groups: as.Groups}
+		if err := checkImpersonation(config); err != nil {
+			return nil, err
+		}
+	}
+
+	c, err := newFromRESTConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	c.impersonate = as.User
+	return c, nil
+}
+
+// checkImpersonation fails when the API server refuses the impersonation
+// headers of config. The server checks them before anything else, so a
+// refused request means the user may not impersonate; other errors are left
+// to the first real request.
+func checkImpersonation(config *rest.Config) error {
+	checkConfig := rest.CopyConfig(config)
+	checkConfig.Timeout = reachableTimeout
+	dc, err := discovery.NewDiscoveryClientForConfig(checkConfig)
+	if err != nil {
+		return fmt.Errorf("error creating discovery client: %v", err)
+	}
+
+	if _, err := dc.ServerVersion(); apierrors.IsForbidden(err) {
+		return fmt.Errorf("not allowed to impersonate %s: %v", config.Impersonate.UserName, err)
+	}
+	return nil
+}
+
+// newFromRESTConfig creates the clientsets for a resolved rest config
+func newFromRESTConfig(config *rest.Config) (*K8sClient, error) {
 	// Create clientset
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, fmt.Errorf("error creating Kubernetes client: %v", err)
 	}
 
+	// Create metrics clientset, only usable when metrics-server is installed
+	metrics, err := metricsclient.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating metrics client: %v", err)
+	}
+
+	// Create dynamic client for custom resources
+	dyn, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating dynamic client: %v", err)
+	}
+
 	return &K8sClient{
 		Clientset: clientset,
+		Metrics:   metrics,
+		Dynamic:   dyn,
+		Discovery: memory.NewMemCacheClient(clientset.Discovery()),
+		config:    config,
 	}, nil
 }
 
+// ResolveKubeconfig returns the kubeconfig path to use. An explicit path takes
+// precedence over the KUBECONFIG env var, which takes precedence over the
+// default ~/.kube/config.
+func ResolveKubeconfig(path string) string {
+	if path != "" {
+		return path
+	}
+
+	kubeconfig := os.Getenv("KUBECONFIG")
+	if kubeconfig == "" {
+		// Try default location if not specified
+		homeDir, err := os.UserHomeDir()
+		if err == nil {
+			kubeconfig = filepath.Join(homeDir, ".kube", "config")
+		}
+	}
+
+	return kubeconfig
+}
+
+// Impersonating returns the user requests are made as, empty when not
+// impersonating
+func (c *K8sClient) Impersonating() string {
+	return c.impersonate
+}
+
+// ServerResourcesForGroupVersion returns the resources served for a group
+// version from the discovery cache. A group missing from the cache may have
+// been installed since it was filled, so the lookup is retried once against
+// fresh discovery data.
+func (c *K8sClient) ServerResourcesForGroupVersion(groupVersion string) (*metav1.APIResourceList, error) {
+	list, err := c.Discovery.ServerResourcesForGroupVersion(groupVersion)
+	if err != nil && (apierrors.IsNotFound(err) || errors.Is(err, memory.ErrCacheNotFound)) {
+		c.Discovery.Invalidate()
+		list, err = c.Discovery.ServerResourcesForGroupVersion(groupVersion)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error discovering %s resources: %v", groupVersion, err)
+	}
+	return list, nil
+}
+
+// Serves reports whether the server serves a resource, e.g. the routes of
+// OpenShift. A failed discovery counts as not served.
+func (c *K8sClient) Serves(gvr schema.GroupVersionResource) bool {
+	list, err := c.ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+	if err != nil {
+		return false
+	}
+	for _, r := range list.APIResources {
+		if r.Name == gvr.Resource {
+			return true
+		}
+	}
+	return false
+}
+
 // GetNamespaces returns all namespaces in the cluster
-func (c *K8sClient) GetNamespaces() ([]string, error) {
+func (c *K8sClient) GetNamespaces(ctx context.Context) ([]string, error) {
 	// Get namespace list from K8s API
-	nsList, err := c.Clientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{})
+	nsList, err := c.Clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("error fetching namespaces: %v", err)
 	}
@@ -64,43 +337,329 @@ func (c *K8sClient) GetNamespaces() ([]string, error) {
 	return namespaces, nil
 }
 
-// GetPods returns pods in the given namespace
-func (c *K8sClient) GetPods(namespace string) ([]resources.PodInfo, error) {
-	return resources.GetPods(c.Clientset, namespace)
+// GetNamespaceInfos returns all namespaces with their phase and age
+func (c *K8sClient) GetNamespaceInfos(ctx context.Context) ([]resources.NamespaceInfo, error) {
+	return resources.GetNamespaces(ctx, c.Clientset)
+}
+
+// GetProjects returns the OpenShift projects the user can access
+func (c *K8sClient) GetProjects(ctx context.Context) ([]resources.NamespaceInfo, error) {
+	return resources.GetProjects(ctx, c.Dynamic)
+}
+
+// GetRoutes returns the OpenShift routes in the given namespace
+func (c *K8sClient) GetRoutes(ctx context.Context, namespace string) ([]resources.RouteInfo, error) {
+	return resources.GetRoutes(ctx, c.Dynamic, namespace)
+}
+
+// GetRouteDetail returns detailed information about an OpenShift route
+func (c *K8sClient) GetRouteDetail(ctx context.Context, namespace, name string) (string, error) {
+	return resources.GetRouteDetail(ctx, c.Dynamic, namespace, name)
+}
+
+// GetResourceQuotas returns the resource quotas of a namespace with their usage
+func (c *K8sClient) GetResourceQuotas(ctx context.Context, namespace string) ([]resources.ResourceQuotaInfo, error) {
+	return resources.GetResourceQuotas(ctx, c.Clientset, namespace)
+}
+
+// ResourceExists reports whether the named pod or service exists
+func (c *K8sClient) ResourceExists(ctx context.Context, kind resources.ResourceKind, namespace, name string) (bool, error) {
+	return resources.ResourceExists(ctx, c.Clientset, kind, namespace, name)
+}
+
+// CountNamespacePods counts the pods of each namespace by phase
+func (c *K8sClient) CountNamespacePods(ctx context.Context, namespaces []string) map[string]resources.PodCounts {
+	return resources.CountNamespacePods(ctx, c.Clientset, namespaces)
+}
+
+// GetPodWorkload returns the workload that ultimately controls a pod
+func (c *K8sClient) GetPodWorkload(ctx context.Context, namespace, name string) (resources.Workload, bool, error) {
+	return resources.GetPodWorkload(ctx, c.Clientset, namespace, name)
+}
+
+// GetRolloutHistory returns the revisions of a Deployment, newest first
+func (c *K8sClient) GetRolloutHistory(ctx context.Context, namespace, name string) ([]resources.RevisionInfo, error) {
+	return resources.GetRolloutHistory(ctx, c.Clientset, namespace, name)
+}
+
+// RollbackDeployment rolls a Deployment back to a revision
+func (c *K8sClient) RollbackDeployment(ctx context.Context, namespace, name string, revision int64) error {
+	return resources.RollbackDeployment(ctx, c.Clientset, namespace, name, revision)
+}
+
+// GetWorkloadPods returns the pods in the given namespace controlled by the
+// workload and matching the field selector
+func (c *K8sClient) GetWorkloadPods(ctx context.Context, namespace, fieldSelector string, workload resources.Workload) ([]resources.PodInfo, error) {
+	return resources.GetWorkloadPods(ctx, c.Clientset, namespace, fieldSelector, workload)
+}
+
+// GetPods returns pods in the given namespace matching the field selector
+func (c *K8sClient) GetPods(ctx context.Context, namespace, fieldSelector string) ([]resources.PodInfo, error) {
+	return resources.GetPods(ctx, c.Clientset, namespace, fieldSelector)
 }
 
 // GetServices returns services in the given namespace
-func (c *K8sClient) GetServices(namespace string) ([]resources.ServiceInfo, error) {
-	return resources.GetServices(c.Clientset, namespace)
+func (c *K8sClient) GetServices(ctx context.Context, namespace string) ([]resources.ServiceInfo, error) {
+	return resources.GetServices(ctx, c.Clientset, namespace)
+}
+
+// GetSecrets returns secrets in the given namespace
+func (c *K8sClient) GetSecrets(ctx context.Context, namespace string) ([]resources.SecretInfo, error) {
+	return resources.GetSecrets(ctx, c.Clientset, namespace)
+}
+
+// GetCronJob returns the schedule and state of a CronJob
+func (c *K8sClient) GetCronJob(ctx context.Context, namespace, name string) (resources.CronJobInfo, error) {
+	return resources.GetCronJob(ctx, c.Clientset, namespace, name)
+}
+
+// TriggerCronJob creates a Job from a CronJob right away and returns its name
+func (c *K8sClient) TriggerCronJob(ctx context.Context, namespace, name string) (string, error) {
+	return resources.TriggerCronJob(ctx, c.Clientset, namespace, name)
+}
+
+// ScanTLSSecrets returns the certificates of the TLS secrets of a namespace
+// by expiry
+func (c *K8sClient) ScanTLSSecrets(ctx context.Context, namespace string) ([]resources.CertInfo, error) {
+	return resources.ScanTLSSecrets(ctx, c.Clientset, namespace)
+}
+
+// GetContainerEnv returns the environment variables of a container of a pod
+func (c *K8sClient) GetContainerEnv(ctx context.Context, namespace, pod, container string, reveal bool) ([]resources.EnvVar, error) {
+	return resources.GetContainerEnv(ctx, c.Clientset, namespace, pod, container, reveal)
 }
 
 // GetPodDetail returns detailed info for a pod
-func (c *K8sClient) GetPodDetail(namespace, name string) (string, error) {
-	return resources.GetPodDetail(c.Clientset, namespace, name)
+func (c *K8sClient) GetPodDetail(ctx context.Context, namespace, name string, env resources.EnvMode) (resources.Detail, error) {
+	return resources.GetPodDetail(ctx, c.Clientset, namespace, name, env)
 }
 
 // GetServiceDetail returns detailed info for a service
-func (c *K8sClient) GetServiceDetail(namespace, name string) (string, error) {
-	return resources.GetServiceDetail(c.Clientset, namespace, name)
+func (c *K8sClient) GetServiceDetail(ctx context.Context, namespace, name string) (resources.Detail, error) {
+	return resources.GetServiceDetail(ctx, c.Clientset, namespace, name)
+}
+
+// GetSecretDetail returns detailed info for a secret
+func (c *K8sClient) GetSecretDetail(ctx context.Context, namespace, name string, summarize bool) (resources.Detail, error) {
+	return resources.GetSecretDetail(ctx, c.Clientset, namespace, name, summarize)
+}
+
+// FollowPodLogs streams the logs a container writes after since until the
+// stream ends
+func (c *K8sClient) FollowPodLogs(ctx context.Context, namespace, name, container string, since time.Time, line func(resources.LogLine)) error {
+	return resources.FollowPodLogs(ctx, c.Clientset, namespace, name, container, since, line)
+}
+
+// GetPodLogs returns the logs of a container in a pod
+func (c *K8sClient) GetPodLogs(ctx context.Context, namespace, name, container string, previous bool, r resources.LogRange) (string, error) {
+	return resources.GetPodLogs(ctx, c.Clientset, namespace, name, container, previous, r)
+}
+
+// GetPodLogsAllContainers returns the logs of every container in a pod,
+// merged by time
+func (c *K8sClient) GetPodLogsAllContainers(ctx context.Context, namespace, name string, r resources.LogRange) ([]resources.LogLine, error) {
+	return resources.GetPodLogsAllContainers(ctx, c.Clientset, namespace, name, r)
+}
+
+// StreamPodLogs writes the full logs of a container in a pod to w
+func (c *K8sClient) StreamPodLogs(ctx context.Context, namespace, name, container string, previous bool, w io.Writer) (int64, error) {
+	return resources.StreamPodLogs(ctx, c.Clientset, namespace, name, container, previous, w)
+}
+
+// GetPodMetrics returns the current resource usage of a pod
+func (c *K8sClient) GetPodMetrics(ctx context.Context, namespace, name string) (resources.PodMetricsInfo, error) {
+	return resources.GetPodMetrics(ctx, c.Metrics, namespace, name)
+}
+
+// GetServiceAccounts returns the service accounts in a namespace
+func (c *K8sClient) GetServiceAccounts(ctx context.Context, namespace string) ([]resources.ServiceAccountInfo, error) {
+	return resources.GetServiceAccounts(ctx, c.Clientset, namespace)
+}
+
+// GetServiceAccountDetail returns a service account with the permissions bound to it
+func (c *K8sClient) GetServiceAccountDetail(ctx context.Context, namespace, name string) (string, error) {
+	return resources.GetServiceAccountDetail(ctx, c.Clientset, namespace, name)
+}
+
+// GetRoles returns the roles in a namespace and the cluster roles
+func (c *K8sClient) GetRoles(ctx context.Context, namespace string) ([]resources.RoleInfo, error) {
+	return resources.GetRoles(ctx, c.Clientset, namespace)
+}
+
+// GetRoleDetail returns the rules of a role or cluster role
+func (c *K8sClient) GetRoleDetail(ctx context.Context, kind resources.ResourceKind, namespace, name string) (string, error) {
+	return resources.GetRoleDetail(ctx, c.Clientset, kind, namespace, name)
+}
+
+// GetRoleBindings returns the role bindings in a namespace and the cluster role bindings
+func (c *K8sClient) GetRoleBindings(ctx context.Context, namespace string) ([]resources.RoleBindingInfo, error) {
+	return resources.GetRoleBindings(ctx, c.Clientset, namespace)
+}
+
+// GetRoleBindingDetail returns the subjects of a binding and the rules granted to them
+func (c *K8sClient) GetRoleBindingDetail(ctx context.Context, kind resources.ResourceKind, namespace, name string) (string, error) {
+	return resources.GetRoleBindingDetail(ctx, c.Clientset, kind, namespace, name)
+}
+
+// WatchEvents streams new events of a namespace, or of all namespaces when
+// namespace is empty, until ctx is cancelled
+func (c *K8sClient) WatchEvents(ctx context.Context, namespace string, send func(resources.EventInfo)) error {
+	events := c.Clientset.CoreV1().Events(namespace)
+	lw := ListWatch{
+		List: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return events.List(ctx, opts)
+		},
+		Watch: events.Watch,
+	}
+
+	for e := range Watch[*corev1.Event](ctx, lw, false) {
+		switch e.Type {
+		case watch.Added, watch.Modified:
+			send(resources.NewEventInfo(*e.Object))
+		case watch.Error:
+			return fmt.Errorf("error watching events: %v", e.Err)
+		}
+	}
+	return ctx.Err()
+}
+
+// WhoCan returns the subjects allowed to perform verb on resource in a namespace
+func (c *K8sClient) WhoCan(ctx context.Context, verb, resource, namespace string) ([]resources.Subject, error) {
+	return resources.WhoCan(ctx, c.Clientset, verb, resource, namespace)
+}
+
+// GetPodMetricsList returns the current resource usage of every pod in a namespace
+func (c *K8sClient) GetPodMetricsList(ctx context.Context, namespace string) ([]resources.PodMetricsInfo, error) {
+	return resources.GetPodMetricsList(ctx, c.Metrics, namespace)
+}
+
+// GetDashboard fetches the overview of a namespace that is not part of its
+// pod and service lists
+func (c *K8sClient) GetDashboard(ctx context.Context, namespace string) resources.DashboardInfo {
+	return resources.GetDashboard(ctx, c.Clientset, c.Metrics, namespace)
+}
+
+// GetEvents returns the events of a resource
+func (c *K8sClient) GetEvents(ctx context.Context, kind resources.ResourceKind, namespace, name string) ([]resources.EventInfo, error) {
+	return resources.GetEvents(ctx, c.Clientset, kind, namespace, name)
+}
+
+// GetResourceYAML returns the YAML manifest of a resource
+func (c *K8sClient) GetResourceYAML(ctx context.Context, kind resources.ResourceKind, namespace, name string) (string, error) {
+	return resources.GetResourceYAML(ctx, c.Clientset, kind, namespace, name)
+}
+
+// UpdateFromYAML patches a resource with the changes made to its YAML. It
+// reports whether anything changed.
+func (c *K8sClient) UpdateFromYAML(ctx context.Context, kind resources.ResourceKind, namespace, name, original, edited string) (bool, error) {
+	return resources.UpdateFromYAML(ctx, c.Clientset, kind, namespace, name, original, edited)
+}
+
+// GetRaw fetches any resource the API server serves, resolving the type of
+// the query through discovery
+func (c *K8sClient) GetRaw(ctx context.Context, namespace string, q resources.RawQuery, hideSecrets bool) (resources.RawResult, error) {
+	return resources.GetRaw(ctx, c.Dynamic, c.Discovery, namespace, q, hideSecrets)
+}
+
+// GetCustomResourceTypes returns the custom resource types served by the
+// cluster. The discovery cache is dropped first so that the types installed
+// since it was filled are listed.
+func (c *K8sClient) GetCustomResourceTypes(ctx context.Context) ([]resources.CustomResourceType, error) {
+	c.Discovery.Invalidate()
+	return resources.GetCustomResourceTypes(ctx, c.Dynamic, c.Discovery)
+}
+
+// GetCustomResources returns the instances of a custom resource type
+func (c *K8sClient) GetCustomResources(ctx context.Context, crType resources.CustomResourceType, namespace string) ([]resources.CustomResourceInfo, error) {
+	return resources.GetCustomResources(ctx, c.Dynamic, crType, namespace)
+}
+
+// GetCustomResourceYAML returns the YAML manifest of a custom resource
+func (c *K8sClient) GetCustomResourceYAML(ctx context.Context, crType resources.CustomResourceType, namespace, name string) (string, error) {
+	return resources.GetCustomResourceYAML(ctx, c.Dynamic, crType, namespace, name)
+}
+
+// DiagnosePod returns the likely causes of a pod being stuck in Pending
+func (c *K8sClient) DiagnosePod(ctx context.Context, namespace, name string) ([]resources.Diagnosis, error) {
+	return resources.DiagnosePod(ctx, c.Clientset, namespace, name)
+}
+
+// DeletePod deletes a pod
+func (c *K8sClient) DeletePod(ctx context.Context, namespace, name string) error {
+	return resources.DeletePod(ctx, c.Clientset, namespace, name)
+}
+
+// DeletePods deletes pods concurrently, returning the errors keyed by pod name
+func (c *K8sClient) DeletePods(ctx context.Context, namespace string, names []string) map[string]error {
+	return resources.DeletePods(ctx, c.Clientset, namespace, names)
+}
+
+// CanI reports whether the current user may perform verb on resource in the
+// given namespace, using a SelfSubjectAccessReview
+func (c *K8sClient) CanI(ctx context.Context, verb, resource, namespace string) (bool, error) {
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      verb,
+				Resource:  resource,
+			},
+		},
+	}
+
+	result, err := c.Clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("error checking permissions: %v", err)
+	}
+
+	return result.Status.Allowed, nil
 }
 
 // GetCurrentContext returns the current Kubernetes context name
 func (c *K8sClient) GetCurrentContext() (string, error) {
-	// Load kubeconfig
-	kubeconfig := os.Getenv("KUBECONFIG")
-	if kubeconfig == "" {
-		// Try default location if not specified
-		homeDir, err := os.UserHomeDir()
-		if err == nil {
-			kubeconfig = filepath.Join(homeDir, ".kube", "config")
+	// Without a kubeconfig there are no contexts, identify the server instead
+	if c.server != "" {
+		u, err := url.Parse(c.server)
+		if err != nil {
+			return "", fmt.Errorf("error parsing server URL: %v", err)
 		}
+		return u.Host, nil
+	}
+
+	if c.context != "" {
+		return c.context, nil
 	}
 
-	// Load client config
-	config, err := clientcmd.LoadFromFile(kubeconfig)
+	// Load client config from the same file the clientset was built from
+	config, err := clientcmd.LoadFromFile(c.kubeconfig)
 	if err != nil {
 		return "", fmt.Errorf("error loading kubeconfig: %v", err)
 	}
 
 	return config.CurrentContext, nil
 }
+
+// GetContexts returns the names of all contexts in the kubeconfig
+func (c *K8sClient) GetContexts() ([]string, error) {
+	if c.server != "" {
+		return nil, errors.New("contexts are not available when connected with a token")
+	}
+	return ListContexts(c.kubeconfig)
+}
+
+// ListContexts returns the names of all contexts in a kubeconfig, resolved
+// like NewWithConfig does, sorted
+func ListContexts(path string) ([]string, error) {
+	config, err := clientcmd.LoadFromFile(ResolveKubeconfig(path))
+	if err != nil {
+		return nil, fmt.Errorf("error loading kubeconfig: %v", err)
+	}
+
+	contexts := make([]string, 0, len(config.Contexts))
+	for name := range config.Contexts {
+		contexts = append(contexts, name)
+	}
+	sort.Strings(contexts)
+
+	return contexts, nil
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// kubeconfigWithoutCurrent has two contexts and selects neither
+const kubeconfigWithoutCurrent = `apiVersion: v1
+kind: Config
+clusters:
+- name: dev
+  cluster:
+    server: https://127.0.0.1:6443
+- name: prod
+  cluster:
+    server: https://127.0.0.1:7443
+users:
+- name: admin
+  user:
+    token: secret
+contexts:
+- name: prod
+  context:
+    cluster: prod
+    user: admin
+- name: dev
+  context:
+    cluster: dev
+    user: admin
+`
+
+// writeKubeconfig writes a kubeconfig to a temporary file and returns its path
+func writeKubeconfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestNewWithContextNoCurrentContext(t *testing.T) {
+	path := writeKubeconfig(t, kubeconfigWithoutCurrent)
+
+	if _, err := NewWithContext(path, "", "", Impersonation{}); !errors.Is(err, ErrNoCurrentContext) {
+		t.Fatalf("NewWithContext without a context: err = %v, want ErrNoCurrentContext", err)
+	}
+
+	c, err := NewWithContext(path, "dev", "", Impersonation{})
+	if err != nil {
+		t.Fatalf("NewWithContext with the dev context: %v", err)
+	}
+	if c.context != "dev" || c.kubeconfig != path {
+		t.Errorf("client for context %q of %q, want dev of %q", c.context, c.kubeconfig, path)
+	}
+}
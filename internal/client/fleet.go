@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/zvelocity/k8s-cli/internal/resources"
+)
+
+const (
+	// fleetTimeout bounds how long each cluster of the fleet view may take
+	// to answer, so an unreachable one doesn't hold up the view
+	fleetTimeout = 10 * time.Second
+
+	// maxConcurrentClusters bounds the clusters GetFleet asks at once
+	maxConcurrentClusters = 8
+)
+
+// GetFleet summarizes the cluster of every context of the kubeconfig,
+// asking them concurrently. A cluster that can't be reached has the error in
+// its summary. The current context is asked through this client, the others
+// with the credentials of their kubeconfig entry.
+func (c *K8sClient) GetFleet(ctx context.Context) ([]resources.ClusterSummary, error) {
+	contexts, err := c.GetContexts()
+	if err != nil {
+		return nil, err
+	}
+	current, _ := c.GetCurrentContext()
+
+	summaries := make([]resources.ClusterSummary, len(contexts))
+	resources.ForEach(contexts, maxConcurrentClusters, func(i int, name string) error {
+		ctx, cancel := context.WithTimeout(ctx, fleetTimeout)
+		defer cancel()
+
+		cc := c
+		if name != current {
+			var err error
+			if cc, err = NewWithContext(c.kubeconfig, name, "", Impersonation{}); err != nil {
+				summaries[i] = resources.ClusterSummary{Context: name, Err: err}
+				return nil
+			}
+		}
+		summaries[i] = resources.GetClusterSummary(ctx, cc.Clientset, name)
+		return nil
+	})
+
+	return summaries, nil
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/url"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/rest"
+)
+
+// IsConnectionError reports whether err means the API server could not be
+// reached, e.g. a refused dial, a timeout or a broken TLS connection, as
+// opposed to an error it answered with like not found or forbidden
+func IsConnectionError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var status apierrors.APIStatus
+	if errors.As(err, &status) {
+		return false
+	}
+
+	// client-go reports every transport failure, TLS ones included, as a
+	// url.Error
+	var urlErr *url.Error
+	var netErr net.Error
+	return errors.As(err, &urlErr) || errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// Ping fails when the API server doesn't answer. Any answer counts, even a
+// refused request: the server is there.
+func (c *K8sClient) Ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, reachableTimeout)
+	defer cancel()
+
+	err := c.Discovery.RESTClient().Get().AbsPath("/version").Do(ctx).Error()
+	var status apierrors.APIStatus
+	if err != nil && !errors.As(err, &status) {
+		return err
+	}
+	return nil
+}
+
+// Reconnect creates a client like c over new connections to the API server,
+// failing while the server doesn't answer
+func (c *K8sClient) Reconnect(ctx context.Context) (*K8sClient, error) {
+	// A dialer of its own keeps client-go from reusing the cached transport
+	// and the connections that went dead with it
+	config := rest.CopyConfig(c.config)
+	config.Dial = (&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}).DialContext
+
+	nc, err := newFromRESTConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	if err := nc.Ping(ctx); err != nil {
+		return nil, err
+	}
+
+	nc.kubeconfig = c.kubeconfig
+	nc.context = c.context
+	nc.server = c.server
+	nc.impersonate = c.impersonate
+	return nc, nil
+}
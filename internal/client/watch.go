@@ -0,0 +1,205 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// ListWatch lists and watches one resource, usually with the List and Watch
+// methods of a typed client
+type ListWatch struct {
+	List  func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error)
+	Watch func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+}
+
+// WatchEvent is a change to a watched object. The last event of a failed
+// watch has the type watch.Error and Err set.
+type WatchEvent[T runtime.Object] struct {
+	Type   watch.EventType
+	Object T
+	Err    error
+}
+
+// Watch streams the changes to the objects of lw until ctx is cancelled or
+// the watch fails, then closes the channel. Bookmarks keep the resource
+// version current so a watch closed by the server resumes where it stopped.
+// When the resource version has expired (410 Gone) the objects are listed
+// again and the watch restarts from there.
+//
+// With sendInitial, the objects listed when the watch starts, and whenever
+// it restarts, are sent as Added events so a consumer keeping a set of
+// objects stays in sync; Added may then repeat an object already seen.
+// Without it only changes from now on are sent.
+func Watch[T runtime.Object](ctx context.Context, lw ListWatch, sendInitial bool) <-chan WatchEvent[T] {
+	ch := make(chan WatchEvent[T])
+	go func() {
+		defer close(ch)
+		err := runWatch(ctx, lw, sendInitial, ch)
+		if err == nil || ctx.Err() != nil {
+			return
+		}
+		select {
+		case ch <- WatchEvent[T]{Type: watch.Error, Err: err}:
+		case <-ctx.Done():
+		}
+	}()
+	return ch
+}
+
+const (
+	// watchFirstDelay is how long to wait before watching again after a
+	// watch ended without any change, doubled each time up to watchMaxDelay
+	watchFirstDelay = time.Second
+	watchMaxDelay   = 30 * time.Second
+)
+
+// runWatch lists and watches until ctx is cancelled or an error other than
+// an expired resource version occurs. A server that keeps closing the
+// stream before anything changed is watched again with a backoff.
+func runWatch[T runtime.Object](ctx context.Context, lw ListWatch, sendInitial bool, ch chan<- WatchEvent[T]) error {
+	send := func(e WatchEvent[T]) bool {
+		select {
+		case ch <- e:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	resourceVersion := ""
+	attempt := 0
+	for {
+		if resourceVersion == "" {
+			var err error
+			resourceVersion, err = relist(ctx, lw, sendInitial, send)
+			if err != nil {
+				return err
+			}
+		}
+
+		w, err := lw.Watch(ctx, metav1.ListOptions{
+			ResourceVersion:     resourceVersion,
+			AllowWatchBookmarks: true,
+		})
+		if isExpired(err) {
+			resourceVersion = ""
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		from := resourceVersion
+		resourceVersion, err = drainWatch(ctx, w, resourceVersion, send)
+		w.Stop()
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			return err
+		}
+
+		// Changes and bookmarks move the resource version, an expired one
+		// is listed again right away
+		if resourceVersion == "" || resourceVersion != from {
+			attempt = 0
+			continue
+		}
+		delay := watchMaxDelay
+		if attempt < 5 {
+			delay = min(watchFirstDelay<<attempt, watchMaxDelay)
+		}
+		attempt++
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// relist lists the objects of lw, sending them as Added events when
+// sendInitial is true, and returns the resource version to watch from
+func relist[T runtime.Object](ctx context.Context, lw ListWatch, sendInitial bool, send func(WatchEvent[T]) bool) (string, error) {
+	// A minimal list is enough for the resource version
+	opts := metav1.ListOptions{}
+	if !sendInitial {
+		opts.Limit = 1
+	}
+	list, err := lw.List(ctx, opts)
+	if err != nil {
+		return "", err
+	}
+	listMeta, err := meta.ListAccessor(list)
+	if err != nil {
+		return "", fmt.Errorf("unexpected list type %T: %v", list, err)
+	}
+
+	if sendInitial {
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return "", fmt.Errorf("unexpected list type %T: %v", list, err)
+		}
+		for _, item := range items {
+			if obj, ok := item.(T); ok && !send(WatchEvent[T]{Type: watch.Added, Object: obj}) {
+				return "", ctx.Err()
+			}
+		}
+	}
+
+	return listMeta.GetResourceVersion(), nil
+}
+
+// drainWatch sends the changes of a watch until it ends or ctx is cancelled,
+// returning the resource version to resume from, empty when it has expired
+func drainWatch[T runtime.Object](ctx context.Context, w watch.Interface, resourceVersion string, send func(WatchEvent[T]) bool) (string, error) {
+	for {
+		var result watch.Event
+		select {
+		case r, ok := <-w.ResultChan():
+			if !ok {
+				return resourceVersion, nil
+			}
+			result = r
+		case <-ctx.Done():
+			return resourceVersion, nil
+		}
+
+		switch result.Type {
+		case watch.Added, watch.Modified, watch.Deleted:
+			obj, ok := result.Object.(T)
+			if !ok {
+				continue
+			}
+			if accessor, err := meta.Accessor(obj); err == nil {
+				resourceVersion = accessor.GetResourceVersion()
+			}
+			if !send(WatchEvent[T]{Type: result.Type, Object: obj}) {
+				return resourceVersion, nil
+			}
+		case watch.Bookmark:
+			if accessor, err := meta.Accessor(result.Object); err == nil {
+				resourceVersion = accessor.GetResourceVersion()
+			}
+		case watch.Error:
+			err := apierrors.FromObject(result.Object)
+			if isExpired(err) {
+				return "", nil
+			}
+			return resourceVersion, err
+		}
+	}
+}
+
+// isExpired reports whether err means the resource version to watch from is
+// too old and the objects have to be listed again
+func isExpired(err error) bool {
+	return apierrors.IsResourceExpired(err) || apierrors.IsGone(err)
+}
@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// fakeListWatch lists the pods of lists in turn, the last one repeatedly,
+// and hands out the watchers of watchers in turn, recording the options
+type fakeListWatch struct {
+	mu       sync.Mutex
+	lists    []*corev1.PodList
+	watchers []*watch.FakeWatcher
+	listed   int
+	watched  []metav1.ListOptions
+}
+
+func (f *fakeListWatch) listWatch() ListWatch {
+	return ListWatch{
+		List: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			list := f.lists[min(f.listed, len(f.lists)-1)]
+			f.listed++
+			return list, nil
+		},
+		Watch: func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			w := f.watchers[min(len(f.watched), len(f.watchers)-1)]
+			f.watched = append(f.watched, opts)
+			return w, nil
+		},
+	}
+}
+
+func (f *fakeListWatch) counts() (listed, watched int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.listed, len(f.watched)
+}
+
+func testPod(name, resourceVersion string) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", ResourceVersion: resourceVersion}}
+}
+
+func podList(resourceVersion string, pods ...*corev1.Pod) *corev1.PodList {
+	list := &corev1.PodList{ListMeta: metav1.ListMeta{ResourceVersion: resourceVersion}}
+	for _, pod := range pods {
+		list.Items = append(list.Items, *pod)
+	}
+	return list
+}
+
+func receive(t *testing.T, ch <-chan WatchEvent[*corev1.Pod]) WatchEvent[*corev1.Pod] {
+	t.Helper()
+	select {
+	case e, ok := <-ch:
+		if !ok {
+			t.Fatal("watch closed")
+		}
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an event")
+	}
+	return WatchEvent[*corev1.Pod]{}
+}
+
+func TestWatchRelistsWhenExpired(t *testing.T) {
+	first, second := watch.NewFake(), watch.NewFake()
+	f := &fakeListWatch{
+		lists: []*corev1.PodList{
+			podList("10", testPod("a", "10")),
+			podList("20", testPod("a", "20")),
+		},
+		watchers: []*watch.FakeWatcher{first, second},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := Watch[*corev1.Pod](ctx, f.listWatch(), true)
+
+	if e := receive(t, ch); e.Type != watch.Added || e.Object.Name != "a" {
+		t.Fatalf("first event = %s %v, want the listed pod added", e.Type, e.Object)
+	}
+
+	first.Error(&metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    410,
+		Reason:  metav1.StatusReasonExpired,
+		Message: "too old resource version: 10 (15)",
+	})
+
+	// Listed again, the pods are sent again
+	if e := receive(t, ch); e.Type != watch.Added || e.Object.Name != "a" || e.Object.ResourceVersion != "20" {
+		t.Fatalf("event after expiry = %s %v, want the pod listed again", e.Type, e.Object)
+	}
+
+	second.Add(testPod("b", "21"))
+	if e := receive(t, ch); e.Type != watch.Added || e.Object.Name != "b" {
+		t.Fatalf("event = %s %v, want b added", e.Type, e.Object)
+	}
+
+	listed, watched := f.counts()
+	if listed != 2 {
+		t.Errorf("listed %d times, want 2", listed)
+	}
+	if watched != 2 {
+		t.Fatalf("watched %d times, want 2", watched)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if rv := f.watched[1].ResourceVersion; rv != "20" {
+		t.Errorf("watched again from %q, want the version listed again, 20", rv)
+	}
+}
+
+func TestWatchBacksOffWhenClosedRightAway(t *testing.T) {
+	// Every watch is closed before anything changed
+	closed := watch.NewFake()
+	closed.Stop()
+	f := &fakeListWatch{
+		lists:    []*corev1.PodList{podList("10")},
+		watchers: []*watch.FakeWatcher{closed},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := Watch[*corev1.Pod](ctx, f.listWatch(), false)
+
+	time.Sleep(watchFirstDelay / 2)
+	cancel()
+	for range ch {
+	}
+
+	if _, watched := f.counts(); watched != 1 {
+		t.Errorf("watched %d times within %s, want 1", watched, watchFirstDelay/2)
+	}
+}
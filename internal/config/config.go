@@ -0,0 +1,248 @@
+// Package config reads the optional user configuration file
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Config holds the settings read from the configuration file. Empty values
+// leave the built-in defaults in place.
+type Config struct {
+	// DefaultView is the view shown at startup: dashboard, pods, services,
+	// secrets, namespaces, top or fleet
+	DefaultView string `json:"defaultView"`
+
+	// DefaultNamespace is the namespace selected at startup
+	DefaultNamespace string `json:"defaultNamespace"`
+
+	// RefreshInterval is how often follow mode re-fetches a resource and
+	// the dashboard refreshes, as a duration like "5s"
+	RefreshInterval string `json:"refreshInterval"`
+
+	// LogTailLines is how many lines opening logs fetches
+	LogTailLines int64 `json:"logTailLines"`
+
+	// LogSince only fetches the log lines written within it when opening
+	// logs, as a duration like "15m"
+	LogSince string `json:"logSince"`
+
+	// Theme is the color theme, default or monochrome
+	Theme string `json:"theme"`
+
+	// ReadOnly disables every action that changes the cluster, like
+	// --read-only
+	ReadOnly bool `json:"readOnly"`
+
+	// HideSecrets never shows what Secrets hold, like --hide-secrets
+	HideSecrets bool `json:"hideSecrets"`
+
+	// Columns lists the columns of the pod, service and secret lists in
+	// the order they are shown, by resource, e.g.
+	// pods: [name, ready, status, restarts, age, node]
+	Columns map[string][]string `json:"columns"`
+
+	// RowStyles color the pod and service rows whose labels match a
+	// selector, the first matching rule winning
+	RowStyles []RowStyle `json:"rowStyles"`
+
+	// Keys binds actions to other keys than the built-in ones, by action
+	// name, e.g. delete: ctrl+x. A key already bound to another action is left
+	// out, move that action too to free it.
+	Keys map[string]string `json:"keys"`
+
+	// FavoriteNamespaces lists the namespaces pinned to the top of the
+	// namespace picker, by context name since each cluster has its own
+	// namespaces. It is written by the picker.
+	FavoriteNamespaces map[string][]string `json:"favoriteNamespaces"`
+}
+
+// RowStyle styles the list rows whose labels match Selector, e.g.
+// "tier=critical", with Style: error, warning, success, info, dim or marked
+type RowStyle struct {
+	Selector string `json:"selector"`
+	Style    string `json:"style"`
+}
+
+// Path returns the location of the configuration file, under
+// $XDG_CONFIG_HOME when set and ~/.config otherwise
+func Path() (string, error) {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "k8s-cli", "config.yaml"), nil
+}
+
+// Load reads the configuration file. A missing file is not an error and
+// yields an empty Config. Each setting is read on its own: an invalid one is
+// left out with a warning and the others still apply. An error means the
+// file couldn't be read or isn't YAML at all, nothing of it applies.
+func Load() (Config, []string, error) {
+	path, err := Path()
+	if err != nil {
+		// Without a home directory there is no file to read
+		return Config{}, []string{fmt.Sprintf("error locating config file: %v", err)}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Config{}, nil, nil
+	}
+	if err != nil {
+		return Config{}, nil, fmt.Errorf("error reading config file: %v", err)
+	}
+
+	cfg, warnings, err := parse(data)
+	if err != nil {
+		return Config{}, nil, fmt.Errorf("error parsing %s: %v", path, err)
+	}
+	return cfg, warnings, nil
+}
+
+// parse decodes the settings of a configuration file one by one, leaving
+// out the invalid ones with a warning. An invalid readOnly or hideSecrets
+// still turns its protection on, so a typo never lifts it.
+func parse(data []byte) (Config, []string, error) {
+	doc, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return Config{}, nil, err
+	}
+	var settings map[string]json.RawMessage
+	if err := json.Unmarshal(doc, &settings); err != nil {
+		return Config{}, nil, fmt.Errorf("expected a mapping of settings")
+	}
+
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var cfg Config
+	var warnings []string
+	fields := settingFields(&cfg)
+	for _, name := range names {
+		field, ok := fields[name]
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal(settings[name], field.Addr().Interface()); err != nil {
+			field.SetZero()
+			warnings = append(warnings, fmt.Sprintf("%s: %v", name, strings.TrimPrefix(err.Error(), "json: ")))
+			switch name {
+			case "readOnly":
+				cfg.ReadOnly = true
+			case "hideSecrets":
+				cfg.HideSecrets = true
+			}
+		}
+	}
+
+	if cfg.RefreshInterval != "" {
+		if d, err := time.ParseDuration(cfg.RefreshInterval); err != nil || d <= 0 {
+			warnings = append(warnings, fmt.Sprintf("refreshInterval: invalid duration %q", cfg.RefreshInterval))
+			cfg.RefreshInterval = ""
+		}
+	}
+	if cfg.LogTailLines < 0 {
+		warnings = append(warnings, fmt.Sprintf("logTailLines: invalid line count %d", cfg.LogTailLines))
+		cfg.LogTailLines = 0
+	}
+	if cfg.LogSince != "" {
+		if d, err := time.ParseDuration(cfg.LogSince); err != nil || d <= 0 {
+			warnings = append(warnings, fmt.Sprintf("logSince: invalid duration %q", cfg.LogSince))
+			cfg.LogSince = ""
+		}
+	}
+
+	return cfg, warnings, nil
+}
+
+// settingFields returns the fields of a Config by the name of their setting
+func settingFields(cfg *Config) map[string]reflect.Value {
+	v := reflect.ValueOf(cfg).Elem()
+	fields := make(map[string]reflect.Value, v.NumField())
+	for i := range v.NumField() {
+		name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("json"), ",")
+		fields[name] = v.Field(i)
+	}
+	return fields
+}
+
+// SaveFavoriteNamespaces stores the favorite namespaces of a context in the
+// configuration file, creating the file when needed. The other settings are
+// kept but comments are lost.
+func SaveFavoriteNamespaces(context string, names []string) error {
+	path, err := Path()
+	if err != nil {
+		return fmt.Errorf("error locating config file: %v", err)
+	}
+
+	// Rewrite the file as generic data so settings unknown to this version
+	// survive
+	var raw map[string]any
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("error reading config file: %v", err)
+	}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("error parsing %s: %v", path, err)
+	}
+	if raw == nil {
+		raw = make(map[string]any)
+	}
+
+	favorites, _ := raw["favoriteNamespaces"].(map[string]any)
+	if favorites == nil {
+		favorites = make(map[string]any)
+	}
+	if len(names) > 0 {
+		favorites[context] = names
+	} else {
+		delete(favorites, context)
+	}
+	if len(favorites) > 0 {
+		raw["favoriteNamespaces"] = favorites
+	} else {
+		delete(raw, "favoriteNamespaces")
+	}
+
+	data, err = yaml.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("error encoding config file: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("error creating config directory: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("error writing config file: %v", err)
+	}
+	return nil
+}
+
+// Interval returns the parsed refresh interval, zero when it is not set
+func (c Config) Interval() time.Duration {
+	d, _ := time.ParseDuration(c.RefreshInterval)
+	return d
+}
+
+// Since returns the parsed log window, zero when it is not set
+func (c Config) Since() time.Duration {
+	d, _ := time.ParseDuration(c.LogSince)
+	return d
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		want     Config
+		warnings []string
+	}{
+		{"valid", "theme: monochrome\nreadOnly: true\nlogTailLines: 50\n", Config{Theme: "monochrome", ReadOnly: true, LogTailLines: 50}, nil},
+		{"unknown setting", "colour: red\ntheme: monochrome\n", Config{Theme: "monochrome"}, nil},
+		{"invalid interval keeps read-only", "refreshInterval: soon\nreadOnly: true\n", Config{ReadOnly: true}, []string{"refreshInterval"}},
+		{"negative tail lines", "logTailLines: -1\nlogSince: 15m\n", Config{LogSince: "15m"}, []string{"logTailLines"}},
+		{"invalid since", "logSince: 0s\ntheme: monochrome\n", Config{Theme: "monochrome"}, []string{"logSince"}},
+		{"wrong type", "logTailLines: many\nreadOnly: true\n", Config{ReadOnly: true}, []string{"logTailLines"}},
+		{"invalid read-only is read-only", "readOnly: maybe\ntheme: monochrome\n", Config{ReadOnly: true, Theme: "monochrome"}, []string{"readOnly"}},
+		{"invalid hide-secrets hides them", "hideSecrets: yes please\nreadOnly: false\n", Config{HideSecrets: true}, []string{"hideSecrets"}},
+		{"valid hide-secrets", "hideSecrets: true\nlogSince: never\n", Config{HideSecrets: true}, []string{"logSince"}},
+		{"empty", "", Config{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, warnings, err := parse([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if !reflect.DeepEqual(cfg, tt.want) {
+				t.Errorf("config = %+v, want %+v", cfg, tt.want)
+			}
+			if len(warnings) != len(tt.warnings) {
+				t.Fatalf("warnings = %q, want %d", warnings, len(tt.warnings))
+			}
+			for i, w := range tt.warnings {
+				if !strings.HasPrefix(warnings[i], w+": ") {
+					t.Errorf("warning %q, want one for %s", warnings[i], w)
+				}
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, data := range []string{"readOnly: [true\n", "- readOnly\n", "just text\n"} {
+		if _, _, err := parse([]byte(data)); err == nil {
+			t.Errorf("parse(%q) succeeded, want an error", data)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zvelocity/k8s-cli/internal/client"
+	"github.com/zvelocity/k8s-cli/internal/resources"
+	"github.com/zvelocity/k8s-cli/internal/ui"
+)
+
+// showCertificates switches to the certificates of the TLS secrets of the
+// current namespace, the first to expire first
+func (m Model) showCertificates() (tea.Model, tea.Cmd) {
+	if m.options.HideSecrets {
+		return m.setStatus(ui.WarningStyle.Render("Certificates are read from Secrets, which --hide-secrets keeps closed"))
+	}
+	ctx := m.beginLoad("Scanning TLS secrets...")
+	m.navigate(resources.CertificateView)
+	m.selectedItem = 0
+	return m, m.loadCmd(getCertificates(ctx, m.client, m.currentNS))
+}
+
+type certificatesMsg struct {
+	certs []resources.CertInfo
+	err   error
+}
+
+func getCertificates(ctx context.Context, client *client.K8sClient, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		certs, err := client.ScanTLSSecrets(ctx, namespace)
+		return certificatesMsg{certs, err}
+	}
+}
@@ -0,0 +1,113 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zvelocity/k8s-cli/internal/resources"
+	"github.com/zvelocity/k8s-cli/internal/ui"
+)
+
+// changeHighlight is how long the rows changed by a refresh stay highlighted
+const changeHighlight = 2 * time.Second
+
+// trackChanges compares the pods and services of a refresh with the
+// previous ones of the same list, highlighting the differences until a tick
+// clears them. The first complete list of a namespace has nothing to compare
+// with. A type that failed to list is not compared, its previous list is
+// kept for the next refresh rather than every row passing for removed.
+func (m *Model) trackChanges(data resources.ResourceData, failed map[string]error) tea.Cmd {
+	scope := fmt.Sprintf("%s|%s|%v", m.currentNS, m.fieldSelector, m.workload)
+	previous := m.listSnapshot
+	m.podChanges, m.serviceChanges = ui.RowChanges{}, ui.RowChanges{}
+	if previous == nil || m.listScope != scope {
+		m.listScope = scope
+		m.listSnapshot = nil
+		if len(failed) == 0 {
+			m.listSnapshot = &data
+		}
+		return nil
+	}
+
+	snapshot := data
+	if failed["pods"] != nil {
+		snapshot.Pods = previous.Pods
+	}
+	if failed["services"] != nil {
+		snapshot.Services = previous.Services
+	}
+	m.listSnapshot = &snapshot
+
+	if failed["pods"] == nil {
+		m.podChanges = diffRows(previous.Pods, data.Pods, podState)
+	}
+	if failed["services"] == nil {
+		m.serviceChanges = diffRows(previous.Services, data.Services, serviceState)
+	}
+
+	m.changesGen++
+	gen := m.changesGen
+	return tea.Tick(changeHighlight, func(time.Time) tea.Msg {
+		return changesFadeMsg{gen}
+	})
+}
+
+// podState returns the namespace, name and state of a pod as diffRows
+// compares them
+func podState(p resources.PodInfo) (string, string, string) {
+	ready, restarts := 0, 0
+	for _, c := range p.Containers {
+		if c.Ready {
+			ready++
+		}
+		restarts += c.RestartCount
+	}
+	return p.Namespace, p.Name, fmt.Sprintf("%s %d %d", p.Status, ready, restarts)
+}
+
+// serviceState returns the namespace, name and state of a service as
+// diffRows compares them
+func serviceState(s resources.ServiceInfo) (string, string, string) {
+	return s.Namespace, s.Name, fmt.Sprintf("%s %s %s %s %s %d %d/%d", s.Type, s.ClusterIP, s.ExternalIP, s.Ports, s.Targets, s.Endpoints, s.PodsReady, s.PodsTotal)
+}
+
+// diffRows compares two lists by the namespace and name of their rows, a
+// row whose state differs having changed. row returns the namespace, name
+// and state of an item.
+func diffRows[T any](before, after []T, row func(T) (string, string, string)) ui.RowChanges {
+	changes := ui.RowChanges{Added: make(map[string]bool), Changed: make(map[string]bool)}
+
+	states := make(map[string]string, len(before))
+	for _, item := range before {
+		namespace, name, state := row(item)
+		states[ui.ChangeKey(namespace, name)] = state
+	}
+
+	seen := make(map[string]bool, len(after))
+	for _, item := range after {
+		namespace, name, state := row(item)
+		key := ui.ChangeKey(namespace, name)
+		seen[key] = true
+		if old, ok := states[key]; !ok {
+			changes.Added[key] = true
+		} else if old != state {
+			changes.Changed[key] = true
+		}
+	}
+
+	for _, item := range before {
+		namespace, name, _ := row(item)
+		if !seen[ui.ChangeKey(namespace, name)] {
+			changes.Removed = append(changes.Removed, name)
+		}
+	}
+	return changes
+}
+
+// changesFadeMsg clears the highlight of the changes of a refresh, unless a
+// later refresh replaced them
+type changesFadeMsg struct {
+	gen int
+}
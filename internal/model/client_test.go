@@ -0,0 +1,66 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/zvelocity/k8s-cli/internal/client"
+	"github.com/zvelocity/k8s-cli/internal/resources"
+)
+
+func TestNoCurrentContextOffersContexts(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	err := os.WriteFile(kubeconfig, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: dev
+  cluster:
+    server: https://127.0.0.1:6443
+users:
+- name: admin
+  user:
+    token: secret
+contexts:
+- name: staging
+  context:
+    cluster: dev
+    user: admin
+- name: dev
+  context:
+    cluster: dev
+    user: admin
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := Options{Kubeconfig: kubeconfig}
+	msg := initK8sClient(opts)()
+	clientMsg, ok := msg.(k8sClientMsg)
+	if !ok {
+		t.Fatalf("got %T, want k8sClientMsg", msg)
+	}
+	if !errors.Is(clientMsg.err, client.ErrNoCurrentContext) {
+		t.Fatalf("err = %v, want ErrNoCurrentContext", clientMsg.err)
+	}
+
+	next, _ := New(context.Background(), opts).Update(clientMsg)
+	m := next.(Model)
+
+	if m.currentView != resources.ContextView {
+		t.Errorf("view = %s, want %s", m.currentView, resources.ContextView)
+	}
+	if m.error != "" {
+		t.Errorf("error = %q, want the contexts offered instead", m.error)
+	}
+	if m.loading {
+		t.Error("still loading")
+	}
+	if want := []string{"dev", "staging"}; !slices.Equal(m.contexts, want) {
+		t.Errorf("contexts = %v, want %v", m.contexts, want)
+	}
+}
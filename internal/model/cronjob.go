@@ -0,0 +1,114 @@
+package model
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zvelocity/k8s-cli/internal/client"
+	"github.com/zvelocity/k8s-cli/internal/resources"
+	"github.com/zvelocity/k8s-cli/internal/ui"
+)
+
+// triggerCronJob looks up the CronJob the pod list is scoped to, to ask
+// before creating a Job from it
+func (m Model) triggerCronJob() (tea.Model, tea.Cmd) {
+	if m.currentView != resources.PodView {
+		return m, nil
+	}
+	if m.workload == nil {
+		return m.setStatus(ui.StatusStyle.Render(fmt.Sprintf("Press %s to show the pods of a cron job first", m.keyHelp(actionWorkload))))
+	}
+	if m.workload.Kind != "CronJob" {
+		return m.setStatus(ui.WarningStyle.Render(fmt.Sprintf("%s is not a cron job, only cron jobs can be triggered", m.workload)))
+	}
+	if !m.can("create", "jobs") {
+		return m.setStatus(ui.WarningStyle.Render("Not allowed to create jobs here"))
+	}
+
+	ctx := m.beginLoad(fmt.Sprintf("Fetching %s...", m.workload))
+	return m, m.loadCmd(getCronJob(ctx, m.client, *m.workload))
+}
+
+// confirmTrigger asks before creating a Job from the CronJob fetched. A
+// suspended CronJob can still be triggered by hand, the confirmation warns
+// about it. On protected contexts the CronJob's name has to be typed.
+func (m Model) confirmTrigger(msg cronJobMsg) (tea.Model, tea.Cmd) {
+	m.loading = false
+	if msg.err != nil {
+		return m.setStatus(ui.ErrorStyle.Render(msg.err.Error()))
+	}
+
+	cronJob := msg.cronJob
+	title := fmt.Sprintf("Run cronjob/%s now?", cronJob.Name)
+	body := fmt.Sprintf("This creates a Job from its template outside the schedule %q.", cronJob.Schedule)
+	if cronJob.Suspended {
+		body += "\n\nThe cron job is suspended, it will still run this once."
+	}
+	trigger := func(m Model, _ string) (tea.Model, tea.Cmd) {
+		ctx := m.beginLoad(fmt.Sprintf("Creating a job from cronjob/%s...", cronJob.Name))
+		m.loadMutates = true
+		return m, m.loadCmd(runCronJob(ctx, m.client, cronJob.Namespace, cronJob.Name))
+	}
+
+	if !m.protected() {
+		return m.openModal(ui.NewConfirmModal(title, body), trigger)
+	}
+
+	instruction := fmt.Sprintf("Protected context %s: type the cron job name to run it.", m.context)
+	return m.openModal(ui.NewPromptModal(title, body+"\n\n"+instruction, cronJob.Name), func(m Model, value string) (tea.Model, tea.Cmd) {
+		if value != cronJob.Name {
+			return m.setStatus(ui.WarningStyle.Render("Confirmation does not match, nothing created"))
+		}
+		return trigger(m, value)
+	})
+}
+
+// cronJobTriggered follows the Job created through its pods
+func (m Model) cronJobTriggered(msg cronJobTriggeredMsg) (tea.Model, tea.Cmd) {
+	m.loading = false
+	m.loadMutates = false
+	if denial, ok := resources.ParseAdmissionDenial(msg.err); ok {
+		return m.openModal(ui.NewDenialModal(denial, "creating a job from cronjob/"+msg.cronJob, "Try running it again?"), func(m Model, _ string) (tea.Model, tea.Cmd) {
+			ctx := m.beginLoad(fmt.Sprintf("Creating a job from cronjob/%s...", msg.cronJob))
+			m.loadMutates = true
+			return m, m.loadCmd(runCronJob(ctx, m.client, msg.namespace, msg.cronJob))
+		})
+	}
+	if msg.err != nil {
+		return m.setStatus(ui.ErrorStyle.Render(msg.err.Error()))
+	}
+
+	model, cmd := m.scopeToWorkload(&resources.Workload{Kind: "Job", Namespace: msg.namespace, Name: msg.job})
+	m = model.(Model)
+	m.status = ui.SuccessStyle.Render(fmt.Sprintf("Created job %s from cronjob/%s", msg.job, msg.cronJob))
+	m.statusID++
+	return m, tea.Batch(cmd, clearStatusAfter(m.statusID, statusTimeout))
+}
+
+type cronJobMsg struct {
+	cronJob resources.CronJobInfo
+	err     error
+}
+
+type cronJobTriggeredMsg struct {
+	namespace string
+	cronJob   string
+	job       string
+	err       error
+}
+
+func getCronJob(ctx context.Context, client *client.K8sClient, cronJob resources.Workload) tea.Cmd {
+	return func() tea.Msg {
+		info, err := client.GetCronJob(ctx, cronJob.Namespace, cronJob.Name)
+		return cronJobMsg{info, err}
+	}
+}
+
+func runCronJob(ctx context.Context, client *client.K8sClient, namespace, name string) tea.Cmd {
+	return func() tea.Msg {
+		job, err := client.TriggerCronJob(ctx, namespace, name)
+		return cronJobTriggeredMsg{namespace, name, job, err}
+	}
+}
@@ -0,0 +1,129 @@
+package model
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zvelocity/k8s-cli/internal/client"
+	"github.com/zvelocity/k8s-cli/internal/resources"
+)
+
+// showDashboard switches to the overview of the current namespace. The pods
+// and services already listed are summarized as they are, only the other
+// parts are fetched.
+func (m Model) showDashboard() (tea.Model, tea.Cmd) {
+	ctx := m.beginLoad("Fetching namespace overview...")
+	m.navigate(resources.DashboardView)
+	m.selectedItem = 0
+	m.dashboardGen++
+	return m, m.loadCmd(getDashboard(ctx, m.client, m.currentNS, m.fieldSelector, m.workload, false))
+}
+
+// refreshDashboard re-fetches the overview together with the pods and
+// services it summarizes
+func (m Model) refreshDashboard() (tea.Model, tea.Cmd) {
+	ctx := m.beginLoad("Refreshing namespace overview...")
+	m.dashboardGen++
+	return m, m.loadCmd(getDashboard(ctx, m.client, m.currentNS, m.fieldSelector, m.workload, true))
+}
+
+// updateDashboard handles the overview results and its periodic refresh,
+// which runs while the dashboard is shown
+func (m Model) updateDashboard(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case dashboardMsg:
+		m.loading = false
+		m.dashboard = msg.info
+
+		// Keep the previous lists when they could not be refreshed, which
+		// may be the connection going away
+		var cmd tea.Cmd
+		if msg.resources != nil && msg.resources.err == nil {
+			var model tea.Model
+			model, cmd = m.Update(*msg.resources)
+			m = model.(Model)
+		} else if msg.resources != nil {
+			cmd = m.probeConnection()
+		}
+		return m, tea.Batch(cmd, dashboardTickAfter(m.dashboardGen, m.options.RefreshInterval))
+
+	case dashboardTickMsg:
+		if msg.gen != m.dashboardGen || m.currentView != resources.DashboardView {
+			return m, nil
+		}
+		// Skip this round while something else is loading
+		if m.loading {
+			return m, dashboardTickAfter(msg.gen, m.options.RefreshInterval)
+		}
+		refresh := getDashboard(m.ctx, m.client, m.currentNS, m.fieldSelector, m.workload, true)
+		gen := msg.gen
+		return m, func() tea.Msg {
+			return dashboardRefreshMsg{gen, refresh()}
+		}
+
+	case dashboardRefreshMsg:
+		if msg.gen != m.dashboardGen || m.currentView != resources.DashboardView {
+			return m, nil
+		}
+		if m.loading {
+			return m, dashboardTickAfter(msg.gen, m.options.RefreshInterval)
+		}
+		model, cmd := m.Update(msg.msg)
+		m = model.(Model)
+		m.markFetched(time.Now(), fetchedViews(msg.msg)...)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+type dashboardMsg struct {
+	info resources.DashboardInfo
+
+	// resources holds the refreshed pods and services, nil when they were
+	// not fetched
+	resources *resourcesMsg
+}
+
+// dashboardTickMsg triggers a background refresh of the dashboard
+type dashboardTickMsg struct {
+	gen int
+}
+
+// dashboardRefreshMsg carries the result of a background refresh
+type dashboardRefreshMsg struct {
+	gen int
+	msg tea.Msg
+}
+
+// getDashboard fetches the overview of a namespace, also listing its pods
+// and services when withResources is true
+func getDashboard(ctx context.Context, client *client.K8sClient, namespace, fieldSelector string, workload *resources.Workload, withResources bool) tea.Cmd {
+	return func() tea.Msg {
+		var msg dashboardMsg
+
+		var wg sync.WaitGroup
+		if withResources {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				res := getResources(ctx, client, namespace, fieldSelector, workload)().(resourcesMsg)
+				msg.resources = &res
+			}()
+		}
+		msg.info = client.GetDashboard(ctx, namespace)
+		wg.Wait()
+
+		return msg
+	}
+}
+
+// dashboardTickAfter schedules the next dashboard refresh
+func dashboardTickAfter(gen int, d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return dashboardTickMsg{gen}
+	})
+}
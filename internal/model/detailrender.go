@@ -0,0 +1,87 @@
+package model
+
+import (
+	"github.com/zvelocity/k8s-cli/internal/resources"
+	"github.com/zvelocity/k8s-cli/internal/ui"
+)
+
+// renderedDetail keeps the fields and the rendered body of the detail, which
+// View would otherwise rebuild on every spinner tick: timestamps, flags
+// and wrapping are slow on the details of pathological pods. The model
+// holds it by pointer so every copy shares it.
+type renderedDetail struct {
+	// fields are the fields of fieldsOf
+	fieldsOf string
+	fields   []resources.DetailField
+
+	// body and row render content with the other inputs
+	content  string
+	usage    string
+	width    int
+	absolute bool
+	selected int
+	body     string
+	row      int
+}
+
+// setDetail shows a detail written with its fields
+func (m *Model) setDetail(detail resources.Detail) {
+	m.detailContent = detail.Text
+	m.detailWritten = detail
+}
+
+// detailFields returns the fields of the detail for inspect mode, the ones
+// written with it or else the ones read back from its text
+func (m Model) detailFields() []resources.DetailField {
+	if m.detailWritten.Text == m.detailContent && m.detailWritten.Fields != nil {
+		return m.detailWritten.Fields
+	}
+	c := m.detailRendered
+	if c.fieldsOf != m.detailContent || c.fields == nil {
+		c.fieldsOf = m.detailContent
+		c.fields = resources.DetailFields(m.detailContent)
+	}
+	return c.fields
+}
+
+// detailFlags returns the flags written with the detail shown, none for the
+// details written without them
+func (m Model) detailFlags() []resources.DetailFlag {
+	if m.detailWritten.Text != m.detailContent {
+		return nil
+	}
+	return m.detailWritten.Flags
+}
+
+// detailContainers returns the containers of the pod detail shown, none
+// for the other details
+func (m Model) detailContainers() []string {
+	if m.detailWritten.Text != m.detailContent {
+		return nil
+	}
+	return m.detailWritten.Containers
+}
+
+// renderDetailBody renders the detail body like ui.RenderDetailBody,
+// reusing the last rendering when nothing changed
+func (m Model) renderDetailBody(usage string, selected int) (string, int) {
+	c := m.detailRendered
+	if c.body != "" && c.content == m.detailContent && c.usage == usage && c.width == m.detailViewport.Width &&
+		c.absolute == m.absoluteTime && c.selected == selected {
+		return c.body, c.row
+	}
+
+	body, row := ui.RenderDetailBody(m.detailContent, m.detailFlags(), usage, m.detailViewport.Width, m.absoluteTime, selected)
+	*c = renderedDetail{
+		fieldsOf: c.fieldsOf,
+		fields:   c.fields,
+		content:  m.detailContent,
+		usage:    usage,
+		width:    m.detailViewport.Width,
+		absolute: m.absoluteTime,
+		selected: selected,
+		body:     body,
+		row:      row,
+	}
+	return body, row
+}
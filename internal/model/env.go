@@ -0,0 +1,83 @@
+package model
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zvelocity/k8s-cli/internal/client"
+	"github.com/zvelocity/k8s-cli/internal/resources"
+	"github.com/zvelocity/k8s-cli/internal/ui"
+)
+
+// showEnv lists the environment variables of a container of the pod
+// detail: the one whose part holds the inspected field, or the only one the
+// pod has. Values from Secrets are masked until revealed.
+func (m Model) showEnv() (tea.Model, tea.Cmd) {
+	var container string
+	if field, ok := m.inspectedField(); ok {
+		if field.Container == "" {
+			return m.setStatus(ui.StatusStyle.Render("Select a field of a container to list its environment"))
+		}
+		container = field.Container
+	} else {
+		containers := m.detailContainers()
+		if len(containers) != 1 {
+			return m.setStatus(ui.StatusStyle.Render(fmt.Sprintf("Press %s and select a field of a container to list its environment", m.keyHelp(actionInspect))))
+		}
+		container = containers[0]
+	}
+
+	m.stopFollow()
+	m.envNamespace, m.envPod, m.envContainer = m.detailNamespace, m.detailName, container
+	m.envVars, m.envReveal = nil, false
+	ctx := m.beginLoad(fmt.Sprintf("Fetching environment of %s...", container))
+	m.navigate(resources.EnvView)
+	m.selectedItem = 0
+	return m, m.loadCmd(getContainerEnv(ctx, m.client, m.envNamespace, m.envPod, m.envContainer, false))
+}
+
+// refreshEnv fetches the environment listed again, revealing the values from
+// Secrets or not
+func (m Model) refreshEnv() (tea.Model, tea.Cmd) {
+	ctx := m.beginLoad(fmt.Sprintf("Fetching environment of %s...", m.envContainer))
+	return m, m.loadCmd(getContainerEnv(ctx, m.client, m.envNamespace, m.envPod, m.envContainer, m.envReveal))
+}
+
+// toggleEnvReveal reveals the values from Secrets in the env view, or masks
+// them again. They are never revealed when Secrets are hidden.
+func (m Model) toggleEnvReveal() (tea.Model, tea.Cmd) {
+	if m.options.HideSecrets {
+		return m.setStatus(ui.WarningStyle.Render("Values from Secrets are hidden by --hide-secrets"))
+	}
+	m.envReveal = !m.envReveal
+	return m.refreshEnv()
+}
+
+// envRows returns the variables of the env view, only those whose name
+// matches the search while there is one
+func (m Model) envRows() []resources.EnvVar {
+	if m.listSearch == "" {
+		return m.envVars
+	}
+	var rows []resources.EnvVar
+	for _, v := range m.envVars {
+		if _, ok := ui.FuzzyMatch(m.listSearch, v.Name); ok {
+			rows = append(rows, v)
+		}
+	}
+	return rows
+}
+
+type containerEnvMsg struct {
+	vars []resources.EnvVar
+	err  error
+}
+
+func getContainerEnv(ctx context.Context, client *client.K8sClient, namespace, pod, container string, reveal bool) tea.Cmd {
+	return func() tea.Msg {
+		vars, err := client.GetContainerEnv(ctx, namespace, pod, container, reveal)
+		return containerEnvMsg{vars, err}
+	}
+}
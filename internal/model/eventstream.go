@@ -0,0 +1,151 @@
+package model
+
+import (
+	"context"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zvelocity/k8s-cli/internal/client"
+	"github.com/zvelocity/k8s-cli/internal/resources"
+	"github.com/zvelocity/k8s-cli/internal/ui"
+)
+
+// maxStreamEvents is the number of events kept by the event stream
+const maxStreamEvents = 1000
+
+// streamTypeFilters are the event types cycled through by the type filter
+var streamTypeFilters = []string{"", "Warning", "Normal"}
+
+// showEventStream switches to the event stream, (re)starting the watch
+func (m Model) showEventStream() (tea.Model, tea.Cmd) {
+	m.stopEventStream()
+
+	ctx, cancel := context.WithCancel(m.ctx)
+	m.eventStreamCancel = cancel
+	m.eventStreamGen++
+	m.eventStream = nil
+	m.eventStreamErr = ""
+	m.navigate(resources.EventStreamView)
+	m.refreshEventStream()
+
+	namespace := m.currentNS
+	if m.eventStreamAll {
+		namespace = ""
+	}
+	return m, watchEvents(ctx, m.client, namespace, m.eventStreamGen)
+}
+
+// stopEventStream ends the watch of the event stream, if any
+func (m *Model) stopEventStream() {
+	if m.eventStreamCancel != nil {
+		m.eventStreamCancel()
+		m.eventStreamCancel = nil
+	}
+	m.eventStreamGen++
+}
+
+// refreshEventStream renders the events matching the type filter into the
+// viewport, following new events while scrolled to the bottom
+func (m *Model) refreshEventStream() {
+	atBottom := m.eventViewport.AtBottom()
+
+	var lines []string
+	for _, event := range m.eventStream {
+		if m.eventStreamType == "" || event.Type == m.eventStreamType {
+			lines = append(lines, ui.RenderStreamEvent(event, m.eventStreamAll))
+		}
+	}
+	if len(lines) == 0 {
+		lines = append(lines, ui.StatusStyle.Render("  Waiting for events..."))
+	}
+
+	m.eventViewport.SetContent(strings.Join(lines, "\n"))
+	if atBottom {
+		m.eventViewport.GotoBottom()
+	}
+}
+
+// updateEventStream handles the messages of the event stream watch
+func (m Model) updateEventStream(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case eventStreamStartedMsg:
+		if msg.gen != m.eventStreamGen {
+			return m, nil
+		}
+		m.eventStreamCh = msg.ch
+		return m, waitForStreamEvent(m.eventStreamCh)
+
+	case eventStreamMsg:
+		if msg.gen != m.eventStreamGen {
+			return m, nil
+		}
+		// The view was left without esc, e.g. through the palette
+		if m.currentView != resources.EventStreamView {
+			m.stopEventStream()
+			return m, nil
+		}
+		m.eventStream = append(m.eventStream, msg.event)
+		if len(m.eventStream) > maxStreamEvents {
+			m.eventStream = m.eventStream[len(m.eventStream)-maxStreamEvents:]
+		}
+		m.refreshEventStream()
+		return m, waitForStreamEvent(m.eventStreamCh)
+
+	case eventStreamEndMsg:
+		if msg.gen != m.eventStreamGen {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.eventStreamErr = msg.err.Error()
+		}
+		return m, nil
+	}
+	return m, nil
+}
+
+type eventStreamStartedMsg struct {
+	gen int
+	ch  <-chan tea.Msg
+}
+
+type eventStreamMsg struct {
+	gen   int
+	event resources.EventInfo
+}
+
+type eventStreamEndMsg struct {
+	gen int
+	err error
+}
+
+// watchEvents starts watching events in the background. Events are delivered
+// one at a time through the channel of eventStreamStartedMsg.
+func watchEvents(ctx context.Context, client *client.K8sClient, namespace string, gen int) tea.Cmd {
+	return func() tea.Msg {
+		ch := make(chan tea.Msg)
+		go func() {
+			err := client.WatchEvents(ctx, namespace, func(event resources.EventInfo) {
+				select {
+				case ch <- eventStreamMsg{gen, event}:
+				case <-ctx.Done():
+				}
+			})
+			if ctx.Err() != nil {
+				return
+			}
+			select {
+			case ch <- eventStreamEndMsg{gen, err}:
+			case <-ctx.Done():
+			}
+		}()
+		return eventStreamStartedMsg{gen, ch}
+	}
+}
+
+// waitForStreamEvent waits for the next message of the event stream watch
+func waitForStreamEvent(ch <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-ch
+	}
+}
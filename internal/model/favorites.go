@@ -0,0 +1,72 @@
+package model
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zvelocity/k8s-cli/internal/config"
+	"github.com/zvelocity/k8s-cli/internal/resources"
+)
+
+// isFavorite reports whether a namespace is a favorite in the current context
+func (m Model) isFavorite(name string) bool {
+	return slices.Contains(m.options.FavoriteNamespaces[m.context], name)
+}
+
+// sortFavoritesFirst sorts the favorite namespaces to the top of the picker,
+// each group by name
+func (m *Model) sortFavoritesFirst() {
+	slices.SortStableFunc(m.namespaces, func(a, b resources.NamespaceInfo) int {
+		fa, fb := m.isFavorite(a.Name), m.isFavorite(b.Name)
+		switch {
+		case fa && !fb:
+			return -1
+		case fb && !fa:
+			return 1
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+}
+
+// toggleFavorite adds the selected namespace to the favorites of the current
+// context or removes it, and saves them to the config file
+func (m Model) toggleFavorite() (tea.Model, tea.Cmd) {
+	if len(m.namespaces) == 0 {
+		return m, nil
+	}
+	name := m.namespaces[m.selectedItem].Name
+
+	favorites := slices.Clone(m.options.FavoriteNamespaces[m.context])
+	if i := slices.Index(favorites, name); i >= 0 {
+		favorites = slices.Delete(favorites, i, i+1)
+	} else {
+		favorites = append(favorites, name)
+		slices.Sort(favorites)
+	}
+
+	// The options are shared with earlier copies of the model
+	m.options.FavoriteNamespaces = maps.Clone(m.options.FavoriteNamespaces)
+	if m.options.FavoriteNamespaces == nil {
+		m.options.FavoriteNamespaces = make(map[string][]string)
+	}
+	m.options.FavoriteNamespaces[m.context] = favorites
+
+	// Follow the namespace to where it moved
+	m.sortFavoritesFirst()
+	m.selectedItem = slices.IndexFunc(m.namespaces, func(ns resources.NamespaceInfo) bool { return ns.Name == name })
+
+	return m, saveFavorites(m.context, favorites)
+}
+
+type favoritesSavedMsg struct {
+	err error
+}
+
+func saveFavorites(context string, names []string) tea.Cmd {
+	return func() tea.Msg {
+		return favoritesSavedMsg{config.SaveFavoriteNamespaces(context, names)}
+	}
+}
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zvelocity/k8s-cli/internal/client"
+	"github.com/zvelocity/k8s-cli/internal/resources"
+	"github.com/zvelocity/k8s-cli/internal/ui"
+)
+
+// showFleet switches to the summary of the cluster of every kubeconfig
+// context. There are no contexts when connected with a token.
+func (m Model) showFleet() (tea.Model, tea.Cmd) {
+	if m.options.Server != "" {
+		return m.setStatus(ui.WarningStyle.Render("There are no contexts to summarize when connected with a token"))
+	}
+	ctx := m.beginLoad("Asking the cluster of every context...")
+	m.navigate(resources.FleetView)
+	m.selectedItem = 0
+	return m, m.loadCmd(getFleet(ctx, m.client))
+}
+
+// switchContext connects to the cluster of another kubeconfig context and
+// shows its pods, in the namespace the session started in. What was fetched
+// from the previous cluster is dropped.
+func (m Model) switchContext(name string) (tea.Model, tea.Cmd) {
+	if name == m.context {
+		m.navigate(resources.PodView)
+		m.selectedItem = 0
+		return m, nil
+	}
+
+	m.stopFollow()
+	m.stopLogFollow()
+	m.stopEventStream()
+	m.setNamespace(m.options.Namespace)
+	m.resourceData = resources.ResourceData{}
+	m.listSnapshot = nil
+	m.namespaces = nil
+	m.customTypes = nil
+	m.permissions = nil
+	m.fieldSelector = ""
+
+	m.options.Context = name
+	m.navigate(resources.PodView)
+	m.selectedItem = 0
+	m.beginLoad(fmt.Sprintf("Connecting to context %s...", name))
+	return m, m.loadCmd(initK8sClient(m.options))
+}
+
+type fleetMsg struct {
+	clusters []resources.ClusterSummary
+	err      error
+}
+
+func getFleet(ctx context.Context, client *client.K8sClient) tea.Cmd {
+	return func() tea.Msg {
+		clusters, err := client.GetFleet(ctx)
+		return fleetMsg{clusters, err}
+	}
+}
@@ -0,0 +1,120 @@
+package model
+
+import (
+	"fmt"
+	"maps"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zvelocity/k8s-cli/internal/resources"
+	"github.com/zvelocity/k8s-cli/internal/ui"
+)
+
+const (
+	// freshnessInterval is how often the age of the data shown is redrawn
+	freshnessInterval = time.Second
+
+	// staleAfter is the age from which the data shown is flagged as stale
+	staleAfter = 5 * time.Minute
+)
+
+// fetchedViews returns the views whose data a fetch result carries, none
+// for the messages that don't carry data to show
+func fetchedViews(msg tea.Msg) []resources.ViewType {
+	switch msg := msg.(type) {
+	case resourcesMsg:
+		return []resources.ViewType{resources.PodView, resources.ServiceView}
+	case dashboardMsg:
+		if msg.resources != nil && msg.resources.err == nil {
+			return []resources.ViewType{resources.DashboardView, resources.PodView, resources.ServiceView}
+		}
+		return []resources.ViewType{resources.DashboardView}
+	case secretsMsg:
+		return []resources.ViewType{resources.SecretView}
+	case namespacesMsg:
+		return []resources.ViewType{resources.NamespaceView}
+	case podDetailMsg, serviceDetailMsg, secretDetailMsg, routeDetailMsg, rbacDetailMsg, customDetailMsg, rawMsg:
+		return []resources.ViewType{resources.DetailView}
+	case podLogsMsg:
+		return []resources.ViewType{resources.LogView}
+	case eventsMsg:
+		return []resources.ViewType{resources.EventsView}
+	case customTypesMsg:
+		return []resources.ViewType{resources.CustomTypeView}
+	case customResourcesMsg:
+		return []resources.ViewType{resources.CustomResourceView}
+	case diagnosisMsg:
+		return []resources.ViewType{resources.DiagnosisView}
+	case topMetricsMsg:
+		return []resources.ViewType{resources.TopView}
+	case routesMsg:
+		return []resources.ViewType{resources.RouteView}
+	case quotasMsg:
+		return []resources.ViewType{resources.ResourceQuotaView}
+	case whoCanMsg:
+		return []resources.ViewType{resources.WhoCanView}
+	case serviceAccountsMsg:
+		return []resources.ViewType{resources.ServiceAccountView}
+	case rolesMsg:
+		return []resources.ViewType{resources.RoleView}
+	case roleBindingsMsg:
+		return []resources.ViewType{resources.RoleBindingView}
+	case rolloutHistoryMsg:
+		return []resources.ViewType{resources.HistoryView}
+	case fleetMsg:
+		return []resources.ViewType{resources.FleetView}
+	case certificatesMsg:
+		return []resources.ViewType{resources.CertificateView}
+	case containerEnvMsg:
+		return []resources.ViewType{resources.EnvView}
+	}
+	return nil
+}
+
+// markFetched records when the data of views was fetched
+func (m *Model) markFetched(at time.Time, views ...resources.ViewType) {
+	if len(views) == 0 {
+		return
+	}
+	// A new map, older copies of the model keep theirs
+	fetched := make(map[resources.ViewType]time.Time, len(m.fetchedAt)+len(views))
+	maps.Copy(fetched, m.fetchedAt)
+	for _, v := range views {
+		fetched[v] = at
+	}
+	m.fetchedAt = fetched
+}
+
+// freshness tells how long ago the data of the current view was fetched,
+// flagged once it is stale. It is empty for views not fetched yet.
+func (m Model) freshness() string {
+	view := m.currentView
+	if view == resources.ContainerView {
+		// The containers are those of the pod list
+		view = resources.PodView
+	}
+	at, ok := m.fetchedAt[view]
+	if !ok {
+		return ""
+	}
+
+	age := time.Since(at)
+	text := fmt.Sprintf("updated %ds ago", int(age.Seconds()))
+	if age >= time.Minute {
+		text = fmt.Sprintf("updated %s ago", resources.FormatDuration(age))
+	}
+	if age >= staleAfter {
+		return ui.WarningStyle.Render(text)
+	}
+	return ui.StatusStyle.Render(text)
+}
+
+// freshnessTickMsg redraws the age of the data shown
+type freshnessTickMsg struct{}
+
+func freshnessTick() tea.Cmd {
+	return tea.Tick(freshnessInterval, func(time.Time) tea.Msg {
+		return freshnessTickMsg{}
+	})
+}
@@ -0,0 +1,28 @@
+package model
+
+import "github.com/zvelocity/k8s-cli/internal/resources"
+
+// usageHistoryLen is the number of metric samples kept per pod
+const usageHistoryLen = 30
+
+// usageHistory holds the most recent CPU and memory samples of a pod
+type usageHistory struct {
+	cpu []int64
+	mem []int64
+}
+
+// add records a sample, dropping the oldest one once the history is full
+func (h *usageHistory) add(sample resources.PodMetricsInfo) {
+	h.cpu = append(h.cpu, sample.CPUMilli)
+	h.mem = append(h.mem, sample.MemoryBytes)
+
+	if len(h.cpu) > usageHistoryLen {
+		h.cpu = h.cpu[len(h.cpu)-usageHistoryLen:]
+		h.mem = h.mem[len(h.mem)-usageHistoryLen:]
+	}
+}
+
+// podKey returns the key used to track per-pod state
+func podKey(namespace, name string) string {
+	return namespace + "/" + name
+}
@@ -0,0 +1,106 @@
+package model
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zvelocity/k8s-cli/internal/resources"
+	"github.com/zvelocity/k8s-cli/internal/ui"
+)
+
+// toggleInspect starts or stops selecting the fields of the detail, one at
+// a time, to copy their value
+func (m Model) toggleInspect() (tea.Model, tea.Cmd) {
+	if m.detailInspect {
+		m.detailInspect = false
+		return m, nil
+	}
+	if len(m.detailFields()) == 0 {
+		return m.setStatus(ui.StatusStyle.Render("No fields to inspect"))
+	}
+	m.detailInspect = true
+	m.detailField = 0
+	m.scrollToField()
+	return m, nil
+}
+
+// updateInspect handles the keys that move the field selection, reporting
+// false for the keys the detail view handles as usual
+func (m Model) updateInspect(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	switch m.keys.action(msg.String(), m.currentView) {
+	case actionUp:
+		if m.detailField > 0 {
+			m.detailField--
+		}
+	case actionDown:
+		if m.detailField < len(m.detailFields())-1 {
+			m.detailField++
+		}
+	case actionJump:
+		switch msg.String() {
+		case "home":
+			m.detailField = 0
+		case "end":
+			m.detailField = max(len(m.detailFields())-1, 0)
+		default:
+			return m, nil, false
+		}
+	case actionBack, actionInspect:
+		m.detailInspect = false
+		return m, nil, true
+	default:
+		return m, nil, false
+	}
+	m.scrollToField()
+	return m, nil, true
+}
+
+// inspectedField returns the selected field of the detail, false when not
+// inspecting. The selection is kept in range when a refresh drops fields.
+func (m Model) inspectedField() (resources.DetailField, bool) {
+	if !m.detailInspect {
+		return resources.DetailField{}, false
+	}
+	fields := m.detailFields()
+	if len(fields) == 0 {
+		return resources.DetailField{}, false
+	}
+	return fields[min(m.detailField, len(fields)-1)], true
+}
+
+// scrollToField scrolls the detail just enough for the selected field to be
+// visible
+func (m *Model) scrollToField() {
+	body, row := m.detailBody()
+	m.detailViewport.SetContent(body)
+	if row < 0 {
+		return
+	}
+	if row < m.detailViewport.YOffset {
+		m.detailViewport.SetYOffset(row)
+	} else if row >= m.detailViewport.YOffset+m.detailViewport.Height {
+		m.detailViewport.SetYOffset(row - m.detailViewport.Height + 1)
+	}
+}
+
+// openDetailLogs opens the logs of a container of the pod detail: the one
+// whose part holds the inspected field, or the only one the pod has
+func (m Model) openDetailLogs() (tea.Model, tea.Cmd) {
+	var container string
+	if field, ok := m.inspectedField(); ok {
+		if field.Container == "" {
+			return m.setStatus(ui.StatusStyle.Render("Select a field of a container to open its logs"))
+		}
+		container = field.Container
+	} else {
+		containers := m.detailContainers()
+		if len(containers) != 1 {
+			return m.setStatus(ui.StatusStyle.Render(fmt.Sprintf("Press %s and select a field of a container to open its logs", m.keyHelp(actionInspect))))
+		}
+		container = containers[0]
+	}
+
+	m.stopFollow()
+	return m.openPodLogs(m.detailNamespace, m.detailName, container)
+}
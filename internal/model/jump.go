@@ -0,0 +1,115 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zvelocity/k8s-cli/internal/client"
+	"github.com/zvelocity/k8s-cli/internal/resources"
+	"github.com/zvelocity/k8s-cli/internal/ui"
+)
+
+// jumpKinds maps the prefixes accepted by the jump input, kubectl style, to
+// the kinds they name
+var jumpKinds = map[string]resources.ResourceKind{
+	"pod":      resources.KindPod,
+	"pods":     resources.KindPod,
+	"po":       resources.KindPod,
+	"service":  resources.KindService,
+	"services": resources.KindService,
+	"svc":      resources.KindService,
+}
+
+// openJump starts typing the name of a pod or service to open directly
+func (m Model) openJump() (tea.Model, tea.Cmd) {
+	m.jumpInput.SetValue("")
+	return m, m.jumpInput.Focus()
+}
+
+// updateJumpInput handles keys while the name to jump to is being typed
+func (m Model) updateJumpInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+
+	case "esc":
+		m.jumpInput.Blur()
+		return m, nil
+
+	case "enter":
+		kind, name, err := m.parseJump(m.jumpInput.Value())
+		if err != nil {
+			return m.setStatus(ui.ErrorStyle.Render(err.Error()))
+		}
+		m.jumpInput.Blur()
+		ctx := m.beginLoad(fmt.Sprintf("Looking up %s %s...", strings.ToLower(string(kind)), name))
+		return m, m.loadCmd(lookupResource(ctx, m.client, kind, m.currentNS, name))
+	}
+
+	var cmd tea.Cmd
+	m.jumpInput, cmd = m.jumpInput.Update(msg)
+	return m, cmd
+}
+
+// parseJump reads "name" or "kind/name". A bare name is a service in the
+// service list and a pod anywhere else.
+func (m Model) parseJump(value string) (resources.ResourceKind, string, error) {
+	value = strings.TrimSpace(value)
+	kind := resources.KindPod
+	if m.currentView == resources.ServiceView {
+		kind = resources.KindService
+	}
+
+	if prefix, name, ok := strings.Cut(value, "/"); ok {
+		k, known := jumpKinds[strings.ToLower(prefix)]
+		if !known {
+			return "", "", fmt.Errorf("unknown kind %q, expected pod or svc", prefix)
+		}
+		kind, value = k, name
+	}
+	if value == "" {
+		return "", "", fmt.Errorf("no name to go to")
+	}
+	return kind, value, nil
+}
+
+// openJumpTarget opens the detail of the resource found by the jump input,
+// staying on the current view when there is none
+func (m Model) openJumpTarget(msg jumpMsg) (tea.Model, tea.Cmd) {
+	m.loading = false
+	if msg.err != nil {
+		return m.setStatus(ui.ErrorStyle.Render(msg.err.Error()))
+	}
+	kind := strings.ToLower(string(msg.kind))
+	if !msg.found {
+		return m.setStatus(ui.ErrorStyle.Render(fmt.Sprintf("no %s named %s in namespace %s", kind, msg.name, msg.namespace)))
+	}
+
+	ctx := m.beginDetail(msg.kind, msg.namespace, msg.name, false)
+	if msg.kind != resources.KindPod {
+		return m, m.loadCmd(m.detailCmd(ctx))
+	}
+
+	// Sample the pod's usage like when it is opened from the list
+	m.metricsGen++
+	metrics := getPodMetrics(m.ctx, m.client, msg.namespace, msg.name, m.metricsGen)
+	return m, tea.Batch(m.loadCmd(m.detailCmd(ctx)), metrics)
+}
+
+type jumpMsg struct {
+	kind      resources.ResourceKind
+	namespace string
+	name      string
+	found     bool
+	err       error
+}
+
+func lookupResource(ctx context.Context, client *client.K8sClient, kind resources.ResourceKind, namespace, name string) tea.Cmd {
+	return func() tea.Msg {
+		found, err := client.ResourceExists(ctx, kind, namespace, name)
+		return jumpMsg{kind, namespace, name, found, err}
+	}
+}
@@ -0,0 +1,344 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/zvelocity/k8s-cli/internal/resources"
+	"github.com/zvelocity/k8s-cli/internal/ui"
+)
+
+// action is what a key press does. Update switches on the action bound to
+// the key pressed rather than on the key, so every action can be moved to
+// another key.
+type action int
+
+const (
+	noAction action = iota
+	actionUp
+	actionDown
+	actionJump
+	actionOpen
+	actionBack
+	actionSearch
+	actionPalette
+	actionQuit
+	actionRefresh
+	actionPods
+	actionServices
+	actionSecrets
+	actionNamespaces
+	actionGoNamespace
+	actionGoTo
+	actionCompletedJobs
+	actionUnhealthy
+	actionWide
+	actionSort
+	actionAbsoluteTime
+	actionMouse
+	actionWorkload
+	actionRecent
+	actionRolloutHistory
+	actionRollback
+	actionRunCronJob
+	actionEvents
+	actionEventStream
+	actionEventType
+	actionAllNamespaces
+	actionTop
+	actionRoutes
+	actionDashboard
+	actionQuotas
+	actionCertificates
+	actionCustomResources
+	actionFieldSelector
+	actionFavorite
+	actionWhoCan
+	actionServiceAccounts
+	actionRoles
+	actionRoleBindings
+	actionLogs
+	actionMark
+	actionDelete
+	actionWhyPending
+	actionCopy
+	actionKubectl
+	actionInspect
+	actionEdit
+	actionEnv
+	actionEnvList
+	actionFollow
+	actionMergeLogs
+	actionMoreLogs
+	actionFewerLogs
+	actionPreviousLogs
+	actionLogWindow
+	actionSaveLogs
+	actionHelp
+)
+
+// keyAction is an action that can be remapped, by the name used in the
+// configuration file and the key it is bound to by default. An action
+// limited to a view may share its key with an action of all views, which it
+// overrides in that view, e.g. save-logs and wide.
+type keyAction struct {
+	action action
+	name   string
+	key    string
+
+	// view is the only view of the action, "" when it is not limited to one
+	view resources.ViewType
+
+	// help tells what the action does in the key bindings overlay
+	help string
+}
+
+// keyActions are the remappable bindings. esc, enter, the arrows, home/end
+// and the ctrl keys are not remappable.
+var keyActions = []keyAction{
+	{actionUp, "up", "k", "", "move up"},
+	{actionDown, "down", "j", "", "move down"},
+	{actionSearch, "search", "/", "", "search the list"},
+	{actionPalette, "palette", ":", "", "command palette"},
+	{actionQuit, "quit", "q", "", "quit"},
+	{actionRefresh, "refresh", "r", "", "refresh"},
+	{actionPods, "pods", "p", "", "pods"},
+	{actionServices, "services", "s", "", "services"},
+	{actionSecrets, "secrets", "S", "", "secrets"},
+	{actionNamespaces, "namespaces", "n", "", "namespaces"},
+	{actionGoNamespace, "go-namespace", "g", "", "go to namespace"},
+	{actionGoTo, "go-to", "G", "", "go to pod/svc"},
+	{actionCompletedJobs, "completed-jobs", "h", "", "show completed job pods"},
+	{actionUnhealthy, "unhealthy", "U", "", "unhealthy only"},
+	{actionWide, "wide", "w", "", "wide columns"},
+	{actionSort, "sort", "t", "", "sort"},
+	{actionAbsoluteTime, "absolute-time", "T", "", "absolute/relative times"},
+	{actionMouse, "mouse", "M", "", "mouse on/off"},
+	{actionWorkload, "workload", "O", "", "workload pods"},
+	{actionRecent, "recent", "H", "", "recently opened"},
+	{actionRolloutHistory, "rollout-history", "Y", "", "rollout history"},
+	{actionRollback, "rollback", "b", "", "roll back"},
+	{actionRunCronJob, "run-cronjob", "J", "", "run cron job now"},
+	{actionEvents, "events", "v", "", "events"},
+	{actionEventStream, "event-stream", "E", "", "event stream"},
+	{actionEventType, "event-type", "t", resources.EventStreamView, "warnings only/all events"},
+	{actionAllNamespaces, "all-namespaces", "a", "", "all namespaces"},
+	{actionTop, "top", "u", "", "top"},
+	{actionRoutes, "routes", "o", "", "routes"},
+	{actionDashboard, "dashboard", "D", "", "dashboard"},
+	{actionQuotas, "quotas", "Q", "", "quotas"},
+	{actionCertificates, "certificates", "X", "", "certificates"},
+	{actionCustomResources, "custom-resources", "C", "", "custom resources"},
+	{actionFieldSelector, "field-selector", "f", "", "field selector"},
+	{actionFavorite, "favorite", "f", resources.NamespaceView, "favorite namespace"},
+	{actionWhoCan, "who-can", "W", "", "who can"},
+	{actionServiceAccounts, "service-accounts", "A", "", "service accounts"},
+	{actionRoles, "roles", "R", "", "roles"},
+	{actionRoleBindings, "role-bindings", "B", "", "role bindings"},
+	{actionLogs, "logs", "l", "", "logs"},
+	{actionMark, "mark", " ", "", "mark for deletion"},
+	{actionDelete, "delete", "d", "", "delete"},
+	{actionWhyPending, "why-pending", "x", "", "why pending"},
+	{actionCopy, "copy", "c", "", "copy"},
+	{actionKubectl, "kubectl", "K", "", "copy kubectl command"},
+	{actionInspect, "inspect", "i", "", "inspect fields"},
+	{actionEdit, "edit", "e", "", "edit"},
+	{actionEnv, "env", "V", "", "env values"},
+	{actionEnvList, "env-list", "N", "", "env list"},
+	{actionFollow, "follow", "F", "", "follow"},
+	{actionMergeLogs, "merge-logs", "m", resources.LogView, "merge containers"},
+	{actionMoreLogs, "more-logs", "+", resources.LogView, "more lines"},
+	{actionFewerLogs, "fewer-logs", "-", resources.LogView, "fewer lines"},
+	{actionPreviousLogs, "previous-logs", "P", resources.LogView, "previous container"},
+	{actionLogWindow, "log-window", "t", resources.LogView, "log window"},
+	{actionSaveLogs, "save-logs", "w", resources.LogView, "save logs"},
+	{actionHelp, "help", "?", "", "key bindings"},
+}
+
+// writeActions change the cluster, they are left out of the key bindings
+// overlay in read-only mode
+var writeActions = map[action]bool{
+	actionMark:       true,
+	actionDelete:     true,
+	actionRollback:   true,
+	actionRunCronJob: true,
+	actionEdit:       true,
+}
+
+// reservedKeys keep their action whatever the bindings, they are not
+// remappable and no action can be bound to them
+var reservedKeys = map[string]action{
+	"esc":    actionBack,
+	"enter":  actionOpen,
+	"up":     actionUp,
+	"down":   actionDown,
+	"home":   actionJump,
+	"end":    actionJump,
+	"ctrl+d": actionJump,
+	"ctrl+u": actionJump,
+	"ctrl+c": actionQuit,
+	"ctrl+p": actionPalette,
+}
+
+// overlaps reports whether two actions may be bound to the same key pressed
+// in some view
+func (a keyAction) overlaps(b keyAction) bool {
+	return a.view == "" || b.view == "" || a.view == b.view
+}
+
+// KeyBindings are the keys bound to the remappable actions. The zero value
+// binds every action to its built-in key.
+type KeyBindings struct {
+	// keys are the keys bound in place of the built-in ones
+	keys map[action]string
+}
+
+// NewKeyBindings binds the actions of the configuration file, by name, to
+// the keys given instead of their built-in ones. Entries for unknown
+// actions or reserved keys are left out, and so are the ones whose key is
+// already bound to another action of the same views, returning a warning
+// for each.
+func NewKeyBindings(config map[string]string) (KeyBindings, []string) {
+	var warnings []string
+
+	names := make([]string, 0, len(config))
+	for name := range config {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	bound := make(map[string]string, len(keyActions))
+	for _, a := range keyActions {
+		bound[a.name] = a.key
+	}
+	configured := make(map[string]bool)
+	for _, name := range names {
+		key := config[name]
+		if key == "space" {
+			key = " "
+		}
+		_, reserved := reservedKeys[key]
+		switch _, ok := bound[name]; {
+		case !ok:
+			warnings = append(warnings, fmt.Sprintf("keys: unknown action %q", name))
+		case reserved:
+			warnings = append(warnings, fmt.Sprintf("keys: %s is reserved, %s keeps %s", keyName(key), name, keyName(bound[name])))
+		case key == "" || len(key) > 1 && !strings.HasPrefix(key, "ctrl+") && !strings.HasPrefix(key, "alt+"):
+			warnings = append(warnings, fmt.Sprintf("keys: invalid key %q for %s, expected a single character, ctrl+<key> or alt+<key>", config[name], name))
+		default:
+			bound[name] = key
+			configured[name] = true
+		}
+	}
+
+	// A configured key taken by another action of the same views is
+	// dropped, which may free the built-in key of yet another one, until no
+	// key is bound twice
+	for conflict := true; conflict; {
+		conflict = false
+		for _, a := range keyActions {
+			if !configured[a.name] || bound[a.name] == a.key {
+				continue
+			}
+			var others []string
+			for _, b := range keyActions {
+				if b.name != a.name && bound[b.name] == bound[a.name] && a.overlaps(b) {
+					others = append(others, b.name)
+				}
+			}
+			if len(others) == 0 {
+				continue
+			}
+			warnings = append(warnings, fmt.Sprintf("keys: %s is already bound to %s, %s keeps %s", keyName(bound[a.name]), strings.Join(others, " and "), a.name, keyName(a.key)))
+			bound[a.name] = a.key
+			delete(configured, a.name)
+			conflict = true
+			break
+		}
+	}
+
+	var b KeyBindings
+	for _, a := range keyActions {
+		if !configured[a.name] || bound[a.name] == a.key {
+			continue
+		}
+		if b.keys == nil {
+			b.keys = make(map[action]string)
+		}
+		b.keys[a.action] = bound[a.name]
+	}
+	return b, warnings
+}
+
+// key returns the key bound to an action, "" for the actions of reserved
+// keys only
+func (b KeyBindings) key(act action) string {
+	if key, ok := b.keys[act]; ok {
+		return key
+	}
+	for _, a := range keyActions {
+		if a.action == act {
+			return a.key
+		}
+	}
+	return ""
+}
+
+// action returns the action of a key pressed in a view, noAction when the
+// key is bound to nothing there. An action limited to the view wins over
+// the action of all views bound to the same key.
+func (b KeyBindings) action(key string, view resources.ViewType) action {
+	if act, ok := reservedKeys[key]; ok {
+		return act
+	}
+	found := noAction
+	for _, a := range keyActions {
+		if b.key(a.action) != key {
+			continue
+		}
+		if a.view == view {
+			return a.action
+		}
+		if a.view == "" {
+			found = a.action
+		}
+	}
+	return found
+}
+
+// help returns the keys of the actions as help lines show them
+func (b KeyBindings) help() ui.Keys {
+	keys := make(ui.Keys, len(keyActions))
+	for _, a := range keyActions {
+		keys[a.name] = keyName(b.key(a.action))
+	}
+	return keys
+}
+
+// keyName returns a key as it is written in help lines and messages
+func keyName(key string) string {
+	if key == " " {
+		return "space"
+	}
+	return key
+}
+
+// keyHelp returns the key bound to an action as help lines show it
+func (m Model) keyHelp(act action) string {
+	return keyName(m.keys.key(act))
+}
+
+// keyBindingsHelp returns the keys of the actions of the current view for
+// the key bindings overlay, leaving out the ones changing the cluster in
+// read-only mode
+func (m Model) keyBindingsHelp() []ui.KeyHelp {
+	bindings := []ui.KeyHelp{{Key: "enter", Help: "open"}, {Key: "esc", Help: "back"}}
+	for _, a := range keyActions {
+		if a.view != "" && a.view != m.currentView || m.options.ReadOnly && writeActions[a.action] {
+			continue
+		}
+		bindings = append(bindings, ui.KeyHelp{Key: keyName(m.keys.key(a.action)), Help: a.help})
+	}
+	return bindings
+}
@@ -0,0 +1,136 @@
+package model
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/zvelocity/k8s-cli/internal/resources"
+)
+
+func TestKeyBindingsSharedKeys(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]string
+		key    string
+		view   resources.ViewType
+		want   action
+	}{
+		{"wide by default", nil, "w", resources.PodView, actionWide},
+		{"save logs by default", nil, "w", resources.LogView, actionSaveLogs},
+		{"sort by default", nil, "t", resources.ServiceView, actionSort},
+		{"log window by default", nil, "t", resources.LogView, actionLogWindow},
+		{"event type by default", nil, "t", resources.EventStreamView, actionEventType},
+		{"wide moved", map[string]string{"wide": "z"}, "z", resources.PodView, actionWide},
+		{"wide moved, save logs stays", map[string]string{"wide": "z"}, "w", resources.LogView, actionSaveLogs},
+		{"wide moved, w unbound elsewhere", map[string]string{"wide": "z"}, "w", resources.PodView, noAction},
+		{"save logs moved", map[string]string{"save-logs": "ctrl+s"}, "ctrl+s", resources.LogView, actionSaveLogs},
+		{"save logs moved, wide stays", map[string]string{"save-logs": "ctrl+s"}, "w", resources.PodView, actionWide},
+		{"save logs moved, w is wide in logs", map[string]string{"save-logs": "ctrl+s"}, "w", resources.LogView, actionWide},
+		{"log window moved, sort stays", map[string]string{"log-window": "L"}, "t", resources.TopView, actionSort},
+		{"log window moved", map[string]string{"log-window": "L"}, "L", resources.LogView, actionLogWindow},
+		{"reserved", nil, "esc", resources.LogView, actionBack},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, warnings := NewKeyBindings(tt.config)
+			if len(warnings) > 0 {
+				t.Fatalf("warnings: %q", warnings)
+			}
+			if got := b.action(tt.key, tt.view); got != tt.want {
+				t.Errorf("action of %q in %s = %d, want %d", tt.key, tt.view, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyBindingsWarnings(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]string
+		want   string
+	}{
+		{"unknown action", map[string]string{"launch": "L"}, `unknown action "launch"`},
+		{"reserved key", map[string]string{"quit": "esc"}, "esc is reserved, quit keeps q"},
+		{"invalid key", map[string]string{"quit": "QQ"}, `invalid key "QQ" for quit`},
+		{"taken key", map[string]string{"delete": "p"}, "p is already bound to pods, delete keeps d"},
+		{"taken in the same view", map[string]string{"save-logs": "m"}, "m is already bound to merge-logs, save-logs keeps w"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, warnings := NewKeyBindings(tt.config)
+			if len(warnings) != 1 || !strings.Contains(warnings[0], tt.want) {
+				t.Errorf("warnings = %q, want one with %q", warnings, tt.want)
+			}
+			if len(b.keys) != 0 {
+				t.Errorf("bound %v, want the built-in keys", b.keys)
+			}
+		})
+	}
+}
+
+func TestKeyBindingsHelp(t *testing.T) {
+	b, _ := NewKeyBindings(map[string]string{"wide": "z", "mark": "x", "why-pending": "space"})
+	help := b.help()
+	for name, want := range map[string]string{"wide": "z", "save-logs": "w", "mark": "x", "why-pending": "space", "quit": "q"} {
+		if help[name] != want {
+			t.Errorf("help key of %s = %q, want %q", name, help[name], want)
+		}
+	}
+}
+
+func TestKeyBindingsOverlay(t *testing.T) {
+	keys, _ := NewKeyBindings(map[string]string{"delete": "ctrl+x"})
+	tests := []struct {
+		name     string
+		readOnly bool
+		view     resources.ViewType
+		want     []string
+		wantNot  []string
+	}{
+		{"pods", false, resources.PodView, []string{"ctrl+x delete", "? key bindings", "esc back"}, []string{"save logs"}},
+		{"read-only", true, resources.PodView, []string{"? key bindings"}, []string{"delete", "mark for deletion", "roll back", "edit"}},
+		{"logs", false, resources.LogView, []string{"w save logs", "t log window"}, []string{"warnings only"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(context.Background(), Options{Keys: keys, ReadOnly: tt.readOnly})
+			m.currentView = tt.view
+			listed := make(map[string]bool)
+			for _, b := range m.keyBindingsHelp() {
+				listed[b.Key+" "+b.Help] = true
+				listed[b.Help] = true
+			}
+			for _, want := range tt.want {
+				if !listed[want] {
+					t.Errorf("%q not listed", want)
+				}
+			}
+			for _, help := range tt.wantNot {
+				if listed[help] {
+					t.Errorf("%q listed", help)
+				}
+			}
+		})
+	}
+}
+
+func TestHelpOverlayClosesOnAnyKey(t *testing.T) {
+	m := New(context.Background(), Options{})
+	m.loading = false
+	m.currentView = resources.PodView
+
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	m = model.(Model)
+	if !m.showHelp || !strings.Contains(ansi.Strip(m.View()), "Key bindings") {
+		t.Fatal("? did not open the key bindings overlay")
+	}
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	m = model.(Model)
+	if m.showHelp || m.currentView != resources.PodView {
+		t.Errorf("s left the overlay open or switched to %s", m.currentView)
+	}
+}
@@ -0,0 +1,146 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/zvelocity/k8s-cli/internal/resources"
+)
+
+// kubectlCommand builds a kubectl command line for a context and namespace.
+// The context is left out when unknown and the namespace for cluster-scoped
+// resources.
+func kubectlCommand(context, namespace string, args ...string) string {
+	parts := []string{"kubectl"}
+	if context != "" && context != "unknown-context" {
+		parts = append(parts, "--context", context)
+	}
+	if namespace != "" {
+		parts = append(parts, "-n", namespace)
+	}
+	parts = append(parts, args...)
+
+	for i, part := range parts {
+		parts[i] = shellQuote(part)
+	}
+	return strings.Join(parts, " ")
+}
+
+// kubectlGet returns the command printing the YAML of a resource
+func kubectlGet(context, namespace, resource, name string) string {
+	return kubectlCommand(context, namespace, "get", resource, name, "-o", "yaml")
+}
+
+// kubectlLogs returns the command printing the logs of a container
+func kubectlLogs(context, namespace, pod, container string, previous bool) string {
+	args := []string{"logs", pod, "-c", container}
+	if previous {
+		args = append(args, "--previous")
+	}
+	return kubectlCommand(context, namespace, args...)
+}
+
+// kubectlEvents returns the command listing the events of a resource
+func kubectlEvents(context, namespace string, kind resources.ResourceKind, name string) string {
+	return kubectlCommand(context, namespace, "events", "--for", string(kind)+"/"+name)
+}
+
+// safeShellWord matches words that need no quoting in a POSIX shell
+var safeShellWord = regexp.MustCompile(`^[A-Za-z0-9_./:=@,+-]+$`)
+
+// shellQuote quotes s for a POSIX shell when needed
+func shellQuote(s string) string {
+	if safeShellWord.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// customResourceName is the fully qualified resource name of a custom
+// resource type, as accepted by kubectl
+func customResourceName(t resources.CustomResourceType) string {
+	return t.Resource + "." + t.Group
+}
+
+// kubectlValue returns the kubectl command equivalent to the current view
+// and selection, or "" when there is none
+func (m Model) kubectlValue() string {
+	switch m.currentView {
+	case resources.PodView:
+		if len(m.resourceData.Pods) > 0 {
+			pod := m.resourceData.Pods[m.selectedItem]
+			return kubectlGet(m.context, pod.Namespace, "pod", pod.Name)
+		}
+	case resources.ServiceView:
+		if len(m.resourceData.Services) > 0 {
+			svc := m.resourceData.Services[m.selectedItem]
+			return kubectlGet(m.context, svc.Namespace, "service", svc.Name)
+		}
+	case resources.SecretView:
+		if len(m.resourceData.Secrets) > 0 {
+			secret := m.resourceData.Secrets[m.selectedItem]
+			// describe only prints the sizes of the values
+			if m.options.HideSecrets {
+				return kubectlCommand(m.context, secret.Namespace, "describe", "secret", secret.Name)
+			}
+			return kubectlGet(m.context, secret.Namespace, "secret", secret.Name)
+		}
+	case resources.NamespaceView:
+		if len(m.namespaces) > 0 {
+			return kubectlGet(m.context, "", "namespace", m.namespaces[m.selectedItem].Name)
+		}
+	case resources.TopView:
+		return kubectlCommand(m.context, m.currentNS, "top", "pods")
+	case resources.RouteView:
+		if len(m.routes) > 0 {
+			route := m.routes[m.selectedItem]
+			return kubectlGet(m.context, route.Namespace, "route", route.Name)
+		}
+	case resources.ServiceAccountView:
+		if len(m.serviceAccounts) > 0 {
+			sa := m.serviceAccounts[m.selectedItem]
+			return kubectlGet(m.context, sa.Namespace, "serviceaccount", sa.Name)
+		}
+	case resources.RoleView:
+		if len(m.roles) > 0 {
+			role := m.roles[m.selectedItem]
+			return kubectlGet(m.context, role.Namespace, strings.ToLower(string(role.Kind)), role.Name)
+		}
+	case resources.RoleBindingView:
+		if len(m.roleBindings) > 0 {
+			b := m.roleBindings[m.selectedItem]
+			return kubectlGet(m.context, b.Namespace, strings.ToLower(string(b.Kind)), b.Name)
+		}
+	case resources.CustomTypeView:
+		if len(m.customTypes) > 0 {
+			t := m.customTypes[m.selectedItem]
+			namespace := ""
+			if t.Namespaced {
+				namespace = m.currentNS
+			}
+			return kubectlCommand(m.context, namespace, "get", customResourceName(t))
+		}
+	case resources.CustomResourceView:
+		if len(m.customResources) > 0 {
+			item := m.customResources[m.selectedItem]
+			return kubectlGet(m.context, item.Namespace, customResourceName(m.customType), item.Name)
+		}
+	case resources.DetailView:
+		if m.detailQuery != nil {
+			return m.rawKubectl(*m.detailQuery)
+		}
+		resource := strings.ToLower(string(m.detailKind))
+		if m.detailCustom {
+			resource = customResourceName(m.customType)
+		}
+		return kubectlGet(m.context, m.detailNamespace, resource, m.detailName)
+	case resources.EventsView:
+		return kubectlEvents(m.context, m.eventsNamespace, m.eventsKind, m.eventsName)
+	case resources.LogView:
+		if m.logMerged {
+			return kubectlCommand(m.context, m.logNamespace, "logs", m.logPod, "--all-containers", "--prefix", "--timestamps")
+		}
+		return kubectlLogs(m.context, m.logNamespace, m.logPod, m.logContainer, m.logPrevious)
+	}
+	return ""
+}
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/zvelocity/k8s-cli/internal/resources"
+)
+
+func TestKubectlGet(t *testing.T) {
+	tests := []struct {
+		name                              string
+		context, namespace, resource, pod string
+		want                              string
+	}{
+		{"namespaced", "prod", "shop", "pod", "web-0", "kubectl --context prod -n shop get pod web-0 -o yaml"},
+		{"unknown context", "unknown-context", "shop", "pod", "web-0", "kubectl -n shop get pod web-0 -o yaml"},
+		{"no context", "", "shop", "service", "web", "kubectl -n shop get service web -o yaml"},
+		{"cluster-scoped", "prod", "", "clusterrole", "admin", "kubectl --context prod get clusterrole admin -o yaml"},
+		{"context with spaces", "my cluster", "shop", "pod", "web-0", "kubectl --context 'my cluster' -n shop get pod web-0 -o yaml"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := kubectlGet(tt.context, tt.namespace, tt.resource, tt.pod); got != tt.want {
+				t.Errorf("got  %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKubectlLogs(t *testing.T) {
+	if got, want := kubectlLogs("prod", "shop", "web-0", "app", false), "kubectl --context prod -n shop logs web-0 -c app"; got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+	if got, want := kubectlLogs("prod", "shop", "web-0", "app", true), "kubectl --context prod -n shop logs web-0 -c app --previous"; got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestKubectlEvents(t *testing.T) {
+	if got, want := kubectlEvents("unknown-context", "shop", resources.KindPod, "web-0"), "kubectl -n shop events --for Pod/web-0"; got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+	if got, want := kubectlEvents("prod", "", resources.KindClusterRole, "admin"), "kubectl --context prod events --for ClusterRole/admin"; got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct {
+		s, want string
+	}{
+		{"web-0", "web-0"},
+		{"arn:aws:eks:eu-west-1:123:cluster/prod", "arn:aws:eks:eu-west-1:123:cluster/prod"},
+		{"user@example.com", "user@example.com"},
+		{"my cluster", "'my cluster'"},
+		{"it's", `'it'\''s'`},
+		{`say "hi"`, `'say "hi"'`},
+		{"$HOME", "'$HOME'"},
+		{"a;rm -rf /", "'a;rm -rf /'"},
+		{"", "''"},
+	}
+	for _, tt := range tests {
+		if got := shellQuote(tt.s); got != tt.want {
+			t.Errorf("shellQuote(%q) = %s, want %s", tt.s, got, tt.want)
+		}
+	}
+}
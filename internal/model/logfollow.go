@@ -0,0 +1,267 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zvelocity/k8s-cli/internal/client"
+	"github.com/zvelocity/k8s-cli/internal/resources"
+	"github.com/zvelocity/k8s-cli/internal/ui"
+)
+
+const (
+	// logFollowFirstDelay is the wait before reopening a log stream that
+	// ended, doubled after each attempt up to logFollowMaxDelay
+	logFollowFirstDelay = time.Second
+	logFollowMaxDelay   = 30 * time.Second
+
+	// maxFollowedLogBytes caps the logs kept while following, the oldest
+	// half is dropped beyond it
+	maxFollowedLogBytes = 4 * 1024 * 1024
+
+	// maxLogBatch is how many followed lines are queued, and added to the
+	// logs shown at once when they arrive faster than they are shown
+	maxLogBatch = 1000
+)
+
+// toggleLogFollow starts streaming the new lines of the logs shown, or stops
+// it. Only the current logs of a single container can be followed.
+func (m Model) toggleLogFollow() (tea.Model, tea.Cmd) {
+	if m.logFollow {
+		m.stopLogFollow()
+		return m, nil
+	}
+	if m.logMerged || m.logPrevious {
+		return m.setStatus(ui.WarningStyle.Render("Only the current logs of a single container can be followed"))
+	}
+
+	m.logFollow = true
+	m.logFollowAttempt = 0
+	return m, m.startLogFollow(false)
+}
+
+// startLogFollow opens the log stream from the last line seen. reconnect is
+// set when reopening a stream that ended.
+func (m *Model) startLogFollow(reconnect bool) tea.Cmd {
+	if m.logFollowCancel != nil {
+		m.logFollowCancel()
+	}
+	ctx, cancel := context.WithCancel(m.ctx)
+	m.logFollowCancel = cancel
+	m.logFollowGen++
+	return followLogs(ctx, m.client, m.logNamespace, m.logPod, m.logContainer, m.logFollowSince, m.logFollowGen, reconnect)
+}
+
+// stopLogFollow ends following the logs, if they are followed
+func (m *Model) stopLogFollow() {
+	if m.logFollowCancel != nil {
+		m.logFollowCancel()
+		m.logFollowCancel = nil
+	}
+	m.logFollow = false
+	m.logFollowGen++
+}
+
+// appendLog adds lines of text to the logs shown, following them while
+// scrolled to the bottom. Only the new lines are filtered, unless the oldest
+// logs were dropped.
+func (m *Model) appendLog(text string) {
+	atBottom := m.logViewport.AtBottom()
+
+	if m.logContent != "" && !strings.HasSuffix(m.logContent, "\n") {
+		m.logContent += "\n"
+	}
+	m.logContent += text + "\n"
+	dropped := len(m.logContent) > maxFollowedLogBytes
+	if dropped {
+		keep := m.logContent[len(m.logContent)-maxFollowedLogBytes/2:]
+		if i := strings.IndexByte(keep, '\n'); i >= 0 {
+			keep = keep[i+1:]
+		}
+		m.logContent = keep
+	}
+
+	if m.logFilter == "" || dropped {
+		m.refreshLogViewport()
+	} else {
+		filtered, matches := ui.FilterLines(text, ui.CompileFilter(m.logFilter))
+		if matches > 0 {
+			if m.logMatches > 0 {
+				m.logFiltered += "\n"
+			}
+			m.logFiltered += filtered
+			m.logMatches += matches
+		}
+		m.showFilteredLogs()
+	}
+	if atBottom {
+		m.logViewport.GotoBottom()
+	}
+}
+
+// updateLogFollow handles the messages of the followed log stream. A stream
+// that ends is reopened with a backoff while the pod exists.
+func (m Model) updateLogFollow(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case logFollowStartedMsg:
+		if msg.gen != m.logFollowGen {
+			return m, nil
+		}
+		m.logFollowCh = msg.ch
+		if msg.reconnect {
+			m.appendLog(ui.StatusStyle.Render("--- reconnected ---"))
+		}
+		return m, waitForLogLines(m.logFollowCh)
+
+	case logLinesMsg:
+		if msg.gen != m.logFollowGen {
+			return m, nil
+		}
+		// The view was left without esc, e.g. through the palette
+		if m.currentView != resources.LogView {
+			m.stopLogFollow()
+			return m, nil
+		}
+		texts := make([]string, len(msg.lines))
+		for i, line := range msg.lines {
+			texts[i] = line.Text
+		}
+		m.logFollowSince = msg.lines[len(msg.lines)-1].Time
+		m.logFollowAttempt = 0
+		m.appendLog(strings.Join(texts, "\n"))
+		m.markFetched(time.Now(), resources.LogView)
+		if msg.ended {
+			return m, checkLogPod(m.ctx, m.client, m.logNamespace, m.logPod, msg.gen)
+		}
+		return m, waitForLogLines(m.logFollowCh)
+
+	case logFollowEndMsg:
+		if msg.gen != m.logFollowGen {
+			return m, nil
+		}
+		return m, checkLogPod(m.ctx, m.client, m.logNamespace, m.logPod, msg.gen)
+
+	case logPodCheckMsg:
+		if msg.gen != m.logFollowGen {
+			return m, nil
+		}
+		// An error may be the connection going away, keep trying
+		if msg.err == nil && !msg.exists {
+			m.stopLogFollow()
+			m.appendLog(ui.WarningStyle.Render("--- pod no longer exists ---"))
+			return m.setStatus(ui.WarningStyle.Render(fmt.Sprintf("Pod %s no longer exists, stopped following its logs", m.logPod)))
+		}
+
+		delay := logFollowMaxDelay
+		if m.logFollowAttempt < 5 {
+			delay = min(logFollowFirstDelay<<m.logFollowAttempt, logFollowMaxDelay)
+		}
+		m.logFollowAttempt++
+		gen := msg.gen
+		return m, tea.Tick(delay, func(time.Time) tea.Msg {
+			return logFollowRetryMsg{gen}
+		})
+
+	case logFollowRetryMsg:
+		if msg.gen != m.logFollowGen || m.currentView != resources.LogView {
+			return m, nil
+		}
+		return m, m.startLogFollow(true)
+	}
+	return m, nil
+}
+
+type logFollowStartedMsg struct {
+	gen       int
+	ch        <-chan tea.Msg
+	reconnect bool
+}
+
+// logLinesMsg holds followed lines, one as sent by the stream and the
+// ones queued behind it once received
+type logLinesMsg struct {
+	gen   int
+	lines []resources.LogLine
+
+	// ended is set when the stream ended after the lines
+	ended bool
+}
+
+type logFollowEndMsg struct {
+	gen int
+}
+
+type logPodCheckMsg struct {
+	gen    int
+	exists bool
+	err    error
+}
+
+type logFollowRetryMsg struct {
+	gen int
+}
+
+// followLogs starts streaming logs in the background. Lines are delivered
+// through the channel of logFollowStartedMsg, which queues maxLogBatch of
+// them.
+func followLogs(ctx context.Context, client *client.K8sClient, namespace, pod, container string, since time.Time, gen int, reconnect bool) tea.Cmd {
+	return func() tea.Msg {
+		ch := make(chan tea.Msg, maxLogBatch)
+		go func() {
+			// Failing to open the stream ends it like a drop, the pod is
+			// checked before trying again
+			client.FollowPodLogs(ctx, namespace, pod, container, since, func(line resources.LogLine) {
+				select {
+				case ch <- logLinesMsg{gen: gen, lines: []resources.LogLine{line}}:
+				case <-ctx.Done():
+				}
+			})
+			if ctx.Err() != nil {
+				return
+			}
+			select {
+			case ch <- logFollowEndMsg{gen}:
+			case <-ctx.Done():
+			}
+		}()
+		return logFollowStartedMsg{gen, ch, reconnect}
+	}
+}
+
+// waitForLogLines waits for the next message of the followed log stream.
+// The lines queued behind a line are taken along, so a burst is added to the
+// logs shown at once rather than line by line.
+func waitForLogLines(ch <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg := <-ch
+		batch, ok := msg.(logLinesMsg)
+		if !ok {
+			return msg
+		}
+		for len(batch.lines) < maxLogBatch {
+			select {
+			case msg := <-ch:
+				next, ok := msg.(logLinesMsg)
+				if !ok {
+					batch.ended = true
+					return batch
+				}
+				batch.lines = append(batch.lines, next.lines...)
+			default:
+				return batch
+			}
+		}
+		return batch
+	}
+}
+
+func checkLogPod(ctx context.Context, client *client.K8sClient, namespace, pod string, gen int) tea.Cmd {
+	return func() tea.Msg {
+		exists, err := client.ResourceExists(ctx, resources.KindPod, namespace, pod)
+		return logPodCheckMsg{gen, exists, err}
+	}
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zvelocity/k8s-cli/internal/resources"
+	"github.com/zvelocity/k8s-cli/internal/ui"
+)
+
+func TestWaitForLogLinesDrainsQueue(t *testing.T) {
+	ch := make(chan tea.Msg, maxLogBatch)
+	for i := range 3 {
+		ch <- logLinesMsg{gen: 7, lines: []resources.LogLine{{Text: fmt.Sprintf("line %d", i)}}}
+	}
+
+	msg, ok := waitForLogLines(ch)().(logLinesMsg)
+	if !ok || len(msg.lines) != 3 || msg.ended {
+		t.Fatalf("got %+v, want the 3 queued lines", msg)
+	}
+	for i, line := range msg.lines {
+		if want := fmt.Sprintf("line %d", i); line.Text != want {
+			t.Errorf("line %d = %q, want %q", i, line.Text, want)
+		}
+	}
+
+	// The end of the stream queued behind lines comes with them
+	ch <- logLinesMsg{gen: 7, lines: []resources.LogLine{{Text: "last"}}}
+	ch <- logFollowEndMsg{7}
+	if msg, ok := waitForLogLines(ch)().(logLinesMsg); !ok || len(msg.lines) != 1 || !msg.ended {
+		t.Errorf("got %+v, want the last line and the end", msg)
+	}
+
+	ch <- logFollowEndMsg{7}
+	if msg, ok := waitForLogLines(ch)().(logFollowEndMsg); !ok || msg.gen != 7 {
+		t.Errorf("got %+v, want the end of the stream", msg)
+	}
+}
+
+func TestAppendLogFiltersNewLines(t *testing.T) {
+	m := New(context.Background(), Options{})
+	m.logFilter = "error"
+	m.logContent = "error: one\ninfo: two\n"
+	m.refreshLogViewport()
+
+	m.appendLog("info: three\nerror: four")
+	m.appendLog("info: five")
+	m.appendLog("error: six")
+
+	want, matches := ui.FilterLines(m.logContent, ui.CompileFilter(m.logFilter))
+	if m.logFiltered != want || m.logMatches != matches {
+		t.Errorf("filtered as lines came:\n%q (%d)\nwant:\n%q (%d)", m.logFiltered, m.logMatches, want, matches)
+	}
+	if m.logMatches != 3 {
+		t.Errorf("matches = %d, want 3", m.logMatches)
+	}
+}
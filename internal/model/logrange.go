@@ -0,0 +1,60 @@
+package model
+
+import (
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// logTailSteps are the numbers of log lines + and - step through
+var logTailSteps = []int64{100, 500, 1000, 5000, 20000}
+
+// logSinceSteps are the windows of time t cycles the logs through, zero
+// fetching the lines of any time
+var logSinceSteps = []time.Duration{0, 5 * time.Minute, 15 * time.Minute, time.Hour, 6 * time.Hour, 24 * time.Hour}
+
+// changeLogTail fetches more or fewer log lines, the next step from the
+// current number. Nothing changes past the last step.
+func (m Model) changeLogTail(more bool) (tea.Model, tea.Cmd) {
+	tail := m.logRange.TailLines
+	if more {
+		i := slices.IndexFunc(logTailSteps, func(n int64) bool { return n > tail })
+		if i < 0 {
+			return m, nil
+		}
+		tail = logTailSteps[i]
+	} else {
+		i := slices.IndexFunc(logTailSteps, func(n int64) bool { return n >= tail })
+		if i == 0 {
+			return m, nil
+		}
+		if i < 0 {
+			i = len(logTailSteps)
+		}
+		tail = logTailSteps[i-1]
+	}
+
+	m.logRange.TailLines = tail
+	return m.refetchLogs()
+}
+
+// cycleLogSince fetches the logs of the next window of time, after the
+// widest one going back to logs of any time
+func (m Model) cycleLogSince() (tea.Model, tea.Cmd) {
+	since := logSinceSteps[0]
+	if i := slices.IndexFunc(logSinceSteps, func(d time.Duration) bool { return d > m.logRange.Since }); i >= 0 {
+		since = logSinceSteps[i]
+	}
+
+	m.logRange.Since = since
+	return m.refetchLogs()
+}
+
+// refetchLogs fetches the logs again after their range changed. Following
+// stops, the new lines come with the fetched ones.
+func (m Model) refetchLogs() (tea.Model, tea.Cmd) {
+	m.stopLogFollow()
+	ctx := m.beginLoad("Fetching logs (" + m.logRange.String() + ")...")
+	return m, m.loadCmd(m.logsCmd(ctx))
+}
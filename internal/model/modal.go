@@ -0,0 +1,55 @@
+package model
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zvelocity/k8s-cli/internal/ui"
+)
+
+// activeModal is the modal shown over the current view with what to do when
+// it is confirmed
+type activeModal struct {
+	modal ui.Modal
+
+	// onConfirm runs with the typed text, empty for yes/no modals
+	onConfirm func(m Model, value string) (tea.Model, tea.Cmd)
+
+	// quitOnCtrlC makes ctrl+c quit right away instead of asking first,
+	// for the quit confirmation itself
+	quitOnCtrlC bool
+}
+
+// openModal shows a modal, which receives every key until it is dismissed
+func (m Model) openModal(modal ui.Modal, onConfirm func(m Model, value string) (tea.Model, tea.Cmd)) (tea.Model, tea.Cmd) {
+	m.modal = &activeModal{modal: modal, onConfirm: onConfirm}
+	return m, modal.Init()
+}
+
+// updateModal routes a key to the active modal and runs its action once it
+// is confirmed
+func (m Model) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "ctrl+c" {
+		quit := m.modal.quitOnCtrlC
+		m.modal = nil
+		if quit {
+			return m, tea.Quit
+		}
+		return m.quit()
+	}
+
+	modal, result, cmd := m.modal.modal.Update(msg)
+	switch result {
+	case ui.ModalConfirmed:
+		onConfirm := m.modal.onConfirm
+		m.modal = nil
+		return onConfirm(m, modal.Value())
+	case ui.ModalCancelled:
+		m.modal = nil
+		return m, nil
+	}
+
+	active := *m.modal
+	active.modal = modal
+	m.modal = &active
+	return m, cmd
+}
@@ -1,10 +1,27 @@
 package model
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
+	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
 
 	"github.com/zvelocity/k8s-cli/internal/client"
 	"github.com/zvelocity/k8s-cli/internal/resources"
@@ -23,37 +40,476 @@ type Model struct {
 	message      string
 	error        string
 
+	// In-flight load. Results from an older generation are dropped so a
+	// cancelled or superseded request can't overwrite the current view.
+	ctx        context.Context
+	loadCtx    context.Context
+	loadCancel context.CancelFunc
+	loadGen    int
+	loadReturn resources.ViewType
+	loadSlow   bool
+
+	// Views left for nested ones, popped by esc, and the trail to restore
+	// when a load is cancelled
+	navStack     []navEntry
+	loadNavStack []navEntry
+
+	// loadMutates is set while the current load changes cluster state
+	loadMutates bool
+
+	// Set while the API server is unreachable and the client is being
+	// recreated, see reconnect.go. Stale attempts are dropped by generation.
+	reconnecting     bool
+	reconnectAttempt int
+	reconnectErr     string
+	reconnectGen     int
+	reconnectRetry   time.Time
+
+	// Transient status line shown below the current view
+	status   string
+	statusID int
+
+	// listWarning is the status reporting resource types that failed to
+	// list, cleared once they list again
+	listWarning string
+
+	// Mouse reporting is on; turning it off restores terminal text selection
+	mouseEnabled bool
+
+	// Keys bound to the actions, see keys.go, listed over the view while
+	// showHelp is set
+	keys     KeyBindings
+	showHelp bool
+
+	// Dialog shown over the view, receiving every key while it is open
+	modal *activeModal
+
+	// Pods of the current namespace marked for bulk deletion, by name
+	marked map[string]bool
+
+	// Group services by type instead of listing them by name
+	servicesByType bool
+
+	// Show absolute timestamps instead of relative ages in every view
+	absoluteTime bool
+
+	// Show the extra columns of the pod and service lists
+	wide bool
+
+	// Pods of completed jobs are left out of the pod list and kept in
+	// completedJobPods unless showCompletedJobs is set
+	showCompletedJobs bool
+	completedJobPods  []resources.PodInfo
+
+	// Healthy pods and services are left out of the lists and kept in
+	// healthyPods and healthyServices while onlyUnhealthy is set, for triage
+	onlyUnhealthy   bool
+	healthyPods     []resources.PodInfo
+	healthyServices []resources.ServiceInfo
+
+	// Last pods and services listed and the rows that changed with them,
+	// highlighted for a moment, see changes.go
+	listSnapshot   *resources.ResourceData
+	listScope      string
+	podChanges     ui.RowChanges
+	serviceChanges ui.RowChanges
+	changesGen     int
+
+	// When the data of each view was last fetched, see freshness.go
+	fetchedAt map[resources.ViewType]time.Time
+
+	// Resources opened in the detail view, most recent first, see recent.go
+	recent []recentEntry
+
+	// Clusters of every kubeconfig context, see fleet.go
+	fleet []resources.ClusterSummary
+
+	// Revisions of the Deployment shown in the history view, see rollout.go
+	rolloutDeployment resources.Workload
+	revisions         []resources.RevisionInfo
+
+	// Certificates of the TLS secrets of the namespace, see certificates.go
+	certs []resources.CertInfo
+
+	// Environment variables of the container shown in the env view, see
+	// env.go
+	envVars      []resources.EnvVar
+	envNamespace string
+	envPod       string
+	envContainer string
+	envReveal    bool
+
+	// Workload the pod list is scoped to, see workload.go
+	workload *resources.Workload
+
+	// Server-side field selector applied to the pod list
+	fieldSelector string
+	fieldInput    textinput.Model
+
+	// Quick namespace switch by name
+	nsInput textinput.Model
+
+	// Name of a pod or service to open directly, see jump.go
+	jumpInput textinput.Model
+
+	// "Who can" RBAC query, as "verb resource", and its results
+	whoCanInput    textinput.Model
+	whoCanQuery    string
+	whoCanSubjects []resources.Subject
+
+	// Command palette for switching between resource types
+	paletteInput textinput.Model
+	paletteIndex int
+
 	// Data
+	options       Options
 	client        *client.K8sClient
-	namespaces    []string
+	namespaces    []resources.NamespaceInfo
 	currentNS     string
 	context       string
 	resourceData  resources.ResourceData
 	detailContent string
+
+	// detailWritten is the last detail written with its fields, which
+	// hold for detailContent while it is that detail's text
+	detailWritten resources.Detail
+
+	// Pod counts of the namespace picker, see nscounts.go
+	nsPodCounts        map[string]resources.PodCounts
+	nsPodCountsAt      time.Time
+	nsPodCountsPending bool
+
+	// Resource shown in the detail view
+	detailKind      resources.ResourceKind
+	detailNamespace string
+	detailName      string
+	detailCustom    bool
+	detailViewport  viewport.Model
+
+	// Raw query shown in the detail view instead of a resource, see raw.go
+	detailQuery *resources.RawQuery
+
+	// How the pod detail shows environment variables from ConfigMaps and
+	// Secrets, back to naming their sources for every new detail
+	detailEnv resources.EnvMode
+
+	// Inspect mode selects one field of the detail at a time for copying,
+	// see inspect.go
+	detailInspect bool
+	detailField   int
+
+	// Last rendering of the detail body, see detailrender.go
+	detailRendered *renderedDetail
+
+	// Pod details prefetched for the selected row, keyed by podKey. Only
+	// the latest prefetch generation runs.
+	detailCache    map[string]cachedDetail
+	prefetchGen    int
+	prefetchCancel context.CancelFunc
+
+	// Follow mode re-fetches the detail periodically. Results from an older
+	// generation are dropped.
+	detailFollow       bool
+	detailFollowGen    int
+	detailFollowCancel context.CancelFunc
+
+	// Likely causes of the diagnosed pod being pending
+	diagnoses     []resources.Diagnosis
+	diagnosisName string
+
+	// Pod resource usage of the current namespace, sorted by CPU or memory.
+	// topUnavailable is set when metrics-server is not installed.
+	topMetrics     []resources.PodMetricsInfo
+	topByMemory    bool
+	topUnavailable bool
+
+	// Overview of the current namespace, refreshed periodically while
+	// shown. Refreshes from an older generation are dropped.
+	dashboard    resources.DashboardInfo
+	dashboardGen int
+
+	// Resource quotas of the current namespace
+	quotas []resources.ResourceQuotaInfo
+
+	// OpenShift resources served by the cluster and the routes of the
+	// current namespace
+	openShift openShiftAPIs
+	routes    []resources.RouteInfo
+
+	// RBAC objects of the current namespace, roles and bindings include the
+	// cluster-wide ones
+	serviceAccounts []resources.ServiceAccountInfo
+	roles           []resources.RoleInfo
+	roleBindings    []resources.RoleBindingInfo
+
+	// Custom resource types and the instances of the selected type
+	customTypes     []resources.CustomResourceType
+	customType      resources.CustomResourceType
+	customResources []resources.CustomResourceInfo
+
+	// In-progress edit of the detail resource, kept after a failed apply
+	// so the edited buffer can be fixed
+	editPath     string
+	editOriginal string
+
+	// usage holds recent metric samples per pod, keyed by podKey
+	usage      map[string]*usageHistory
+	metricsGen int
+
+	// permissions holds SelfSubjectAccessReview results for the current
+	// namespace, keyed by "verb/resource"
+	permissions map[string]bool
+
+	// forbidden holds the resources the server refused to list in the
+	// current namespace, e.g. "pods", shown in place of their lists
+	forbidden map[string]bool
+
+	// Live event stream of the current namespace, or of all namespaces.
+	// Messages from an older generation are dropped.
+	eventStream       []resources.EventInfo
+	eventStreamAll    bool
+	eventStreamType   string
+	eventStreamErr    string
+	eventStreamGen    int
+	eventStreamCancel context.CancelFunc
+	eventStreamCh     <-chan tea.Msg
+	eventViewport     viewport.Model
+
+	// Logs
+	logViewport  viewport.Model
+	logNamespace string
+	logPod       string
+	logContainer string
+	logPrevious  bool
+	logMerged    bool
+	logRange     resources.LogRange
+	logContent   string
+	logFilter    string
+	filterInput  textinput.Model
+
+	// logFiltered holds the lines of logContent matching logFilter,
+	// highlighted, and logMatches their count. Followed lines are filtered
+	// as they come rather than the whole logs again.
+	logFiltered string
+	logMatches  int
+
+	// Follow mode streams the new lines of the logs, reopening the stream
+	// when it ends, see logfollow.go
+	logFollow        bool
+	logFollowGen     int
+	logFollowCancel  context.CancelFunc
+	logFollowCh      <-chan tea.Msg
+	logFollowSince   time.Time
+	logFollowAttempt int
+
+	// Incremental search of the names of the current list
+	listSearch  string
+	searchInput textinput.Model
+
+	// Events of a single resource
+	events          []resources.EventInfo
+	eventsKind      resources.ResourceKind
+	eventsNamespace string
+	eventsName      string
+
+	// Container picker shown before acting on a multi-container pod
+	containerChoices []containerChoice
+	containerIndex   int
+
+	// Context picker shown when the kubeconfig has no current context
+	contexts     []string
+	contextIndex int
+}
+
+// containerChoice is an entry in the container picker
+type containerChoice struct {
+	name string
+	init bool
+}
+
+// Options holds startup settings for the model
+type Options struct {
+	// Kubeconfig is an explicit kubeconfig path, overriding KUBECONFIG
+	Kubeconfig string
+
+	// Context is the kubeconfig context to connect to, the current context
+	// when empty
+	Context string
+
+	// Server and Token connect directly to an API server, bypassing
+	// kubeconfig when Server is set
+	Server   string
+	Token    string
+	CACert   string
+	Insecure bool
+
+	// APIServer replaces the server URL of the kubeconfig context, keeping
+	// its credentials, e.g. to go through kubectl proxy
+	APIServer string
+
+	// As and AsGroups are the user and groups to impersonate, like kubectl's
+	// --as and --as-group
+	As       string
+	AsGroups []string
+
+	// ProtectedContexts are context name patterns where deletions must be
+	// confirmed by typing the resource name
+	ProtectedContexts []*regexp.Regexp
+
+	// LoadTimeout is how long a request may run before the loading screen
+	// offers to cancel it, defaults to defaultLoadTimeout
+	LoadTimeout time.Duration
+
+	// StartView and Namespace are shown at startup, defaulting to the
+	// dashboard of the default namespace
+	StartView resources.ViewType
+	Namespace string
+
+	// ReadOnly disables every action that changes the cluster, whatever
+	// RBAC allows, e.g. for demos
+	ReadOnly bool
+
+	// HideSecrets never shows what Secrets hold: environment variables
+	// from Secrets can't be revealed and secret data is not decoded, only
+	// key names and sizes are shown
+	HideSecrets bool
+
+	// Inline is set when rendering in the terminal rather than on the
+	// alternate screen. The mouse starts disabled so the wheel scrolls the
+	// terminal's scrollback.
+	Inline bool
+
+	// RefreshInterval is how often follow mode re-fetches the detail and
+	// the dashboard refreshes, defaults to defaultFollowInterval
+	RefreshInterval time.Duration
+
+	// LogTailLines is how many lines opening logs fetches, defaults to
+	// resources.DefaultLogTailLines. LogSince, when set, only fetches the
+	// lines written within it.
+	LogTailLines int64
+	LogSince     time.Duration
+
+	// Warning is shown in the status line at startup, e.g. for an unusable
+	// config file
+	Warning string
+
+	// FavoriteNamespaces are pinned to the top of the namespace picker, by
+	// context name
+	FavoriteNamespaces map[string][]string
+
+	// Keys are the key bindings of the configuration file, the built-in
+	// keys when zero
+	Keys KeyBindings
+}
+
+// startViews are the views that can be shown at startup, by name
+var startViews = map[string]resources.ViewType{
+	"dashboard":  resources.DashboardView,
+	"pods":       resources.PodView,
+	"services":   resources.ServiceView,
+	"secrets":    resources.SecretView,
+	"namespaces": resources.NamespaceView,
+	"top":        resources.TopView,
+	"fleet":      resources.FleetView,
+}
+
+// ParseStartView returns the view named by name, e.g. "services"
+func ParseStartView(name string) (resources.ViewType, error) {
+	view, ok := startViews[strings.ToLower(name)]
+	if !ok {
+		return "", fmt.Errorf("unknown view %q, expected dashboard, pods, services, secrets, namespaces, top or fleet", name)
+	}
+	return view, nil
 }
 
-// New creates a new model
-func New() Model {
+// defaultLoadTimeout is used when Options.LoadTimeout is not set
+const defaultLoadTimeout = 10 * time.Second
+
+// New creates a new model. Every request, watch and follow it starts is
+// bound to ctx and stops when it is cancelled.
+func New(ctx context.Context, opts Options) Model {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = ui.StatusStyle
 
-	return Model{
-		spinner:      s,
-		loading:      true,
-		currentView:  resources.PodView,
-		selectedItem: 0,
-		currentNS:    "default",
-		message:      "Connecting to Kubernetes cluster...",
+	fi := textinput.New()
+	fi.Prompt = "/"
+	fi.Placeholder = "filter (regex)"
+
+	fsi := textinput.New()
+	fsi.Prompt = "field selector: "
+	fsi.Placeholder = "e.g. status.phase=Pending (tab to complete)"
+	fsi.ShowSuggestions = true
+
+	nsi := textinput.New()
+	nsi.Prompt = "namespace: "
+	nsi.Placeholder = "name (tab to complete)"
+	nsi.ShowSuggestions = true
+
+	ji := textinput.New()
+	ji.Prompt = "go to: "
+	ji.Placeholder = "pod name, or svc/name"
+
+	wi := textinput.New()
+	wi.Prompt = "who can: "
+	wi.Placeholder = "verb resource, e.g. delete secrets"
+
+	pi := textinput.New()
+	pi.Prompt = ": "
+	pi.Placeholder = "resource type, or get <type>[/<name>] for any other"
+
+	si := textinput.New()
+	si.Prompt = "/"
+	si.Placeholder = "search names (fuzzy)"
+
+	if opts.LoadTimeout <= 0 {
+		opts.LoadTimeout = defaultLoadTimeout
 	}
+	if opts.RefreshInterval <= 0 {
+		opts.RefreshInterval = defaultFollowInterval
+	}
+	if opts.StartView == "" {
+		opts.StartView = resources.DashboardView
+	}
+	if opts.Namespace == "" {
+		opts.Namespace = "default"
+	}
+	if opts.LogTailLines <= 0 {
+		opts.LogTailLines = resources.DefaultLogTailLines
+	}
+
+	m := Model{
+		ctx:            ctx,
+		options:        opts,
+		spinner:        s,
+		currentView:    opts.StartView,
+		selectedItem:   0,
+		currentNS:      opts.Namespace,
+		mouseEnabled:   !opts.Inline,
+		keys:           opts.Keys,
+		logViewport:    viewport.New(80, 20),
+		eventViewport:  viewport.New(80, 20),
+		detailViewport: viewport.New(80, 20),
+		filterInput:    fi,
+		searchInput:    si,
+		fieldInput:     fsi,
+		nsInput:        nsi,
+		jumpInput:      ji,
+		detailRendered: &renderedDetail{},
+		paletteInput:   pi,
+		whoCanInput:    wi,
+	}
+	if opts.Warning != "" {
+		m.status = ui.WarningStyle.Render(opts.Warning)
+	}
+	m.beginLoad("Connecting to Kubernetes cluster...")
+
+	return m
 }
 
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(
-		m.spinner.Tick,
-		initK8sClient,
-	)
+	return tea.Batch(m.loadCmd(initK8sClient(m.options)), freshnessTick())
 }
 
 // Update handles messages and updates model state
@@ -62,219 +518,2485 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "q":
-			return m, tea.Quit
+		if m.modal != nil {
+			return m.updateModal(msg)
+		}
+
+		// Any key closes the key bindings overlay
+		if m.showHelp {
+			m.showHelp = false
+			if msg.String() == "ctrl+c" {
+				return m.quit()
+			}
+			return m, nil
+		}
+
+		if m.reconnecting {
+			return m.updateReconnectKeys(msg)
+		}
+
+		// Route keys to the filter input while it is being edited
+		if m.filterInput.Focused() {
+			return m.updateFilterInput(msg)
+		}
+
+		if m.searchInput.Focused() {
+			return m.updateSearchInput(msg)
+		}
+
+		if m.fieldInput.Focused() {
+			return m.updateFieldInput(msg)
+		}
+
+		if m.nsInput.Focused() {
+			return m.updateNamespaceInput(msg)
+		}
+
+		if m.jumpInput.Focused() {
+			return m.updateJumpInput(msg)
+		}
+
+		if m.paletteInput.Focused() {
+			return m.updatePalette(msg)
+		}
+
+		if m.whoCanInput.Focused() {
+			return m.updateWhoCanInput(msg)
+		}
+
+		if m.currentView == resources.DetailView && m.detailInspect && !m.loading {
+			if model, cmd, ok := m.updateInspect(msg); ok {
+				return model, cmd
+			}
+		}
+
+		if m.currentView == resources.ContainerView {
+			return m.updateContainerPicker(msg)
+		}
+
+		if m.currentView == resources.ContextView {
+			return m.updateContextPicker(msg)
+		}
+
+		if m.loading && msg.String() == "esc" {
+			return m.cancelLoad()
+		}
+
+		switch act := m.keys.action(msg.String(), m.currentView); act {
+		case actionQuit:
+			return m.quit()
 
-		case "p":
+		case actionPods:
 			if !m.loading {
-				m.currentView = resources.PodView
+				m.navigate(resources.PodView)
 				m.selectedItem = 0
 			}
 
-		case "s":
+		case actionServices:
 			if !m.loading {
-				m.currentView = resources.ServiceView
+				m.navigate(resources.ServiceView)
 				m.selectedItem = 0
 			}
 
-		case "esc":
-			if m.currentView == resources.DetailView {
-				m.currentView = resources.PodView
-			} else if m.currentView == resources.NamespaceView {
-				m.currentView = resources.PodView
+		case actionSecrets:
+			if !m.loading {
+				return m.showSecrets()
+			}
+
+		case actionBack:
+			switch {
+			case m.currentView == resources.PodView && len(m.marked) > 0:
+				m.marked = nil
+			case m.currentView == resources.LogView && m.logFilter != "":
+				// Clear an active filter before leaving the log view
+				m.logFilter = ""
+				m.refreshLogViewport()
+			case m.listSearch != "":
+				m.listSearch = ""
+			default:
+				return m.back()
+			}
+
+		case actionSearch:
+			if !m.loading && m.currentView == resources.LogView {
+				m.filterInput.SetValue(m.logFilter)
+				m.filterInput.CursorEnd()
+				return m, m.filterInput.Focus()
+			}
+			if _, ok := m.listLen(); ok && !m.loading {
+				return m.openSearch()
 			}
 
-		case "up", "k":
+		case actionUp:
 			if !m.loading {
-				if m.selectedItem > 0 {
+				if m.currentView == resources.LogView {
+					m.logViewport.ScrollUp(1)
+				} else if m.currentView == resources.EventStreamView {
+					m.eventViewport.ScrollUp(1)
+				} else if m.currentView == resources.DetailView {
+					m.detailViewport.ScrollUp(1)
+				} else if m.selectedItem > 0 {
 					m.selectedItem--
+					return m, m.schedulePrefetch()
 				}
 			}
 
-		case "down", "j":
+		case actionDown:
 			if !m.loading {
 				switch m.currentView {
+				case resources.LogView:
+					m.logViewport.ScrollDown(1)
+				case resources.EventStreamView:
+					m.eventViewport.ScrollDown(1)
+				case resources.DetailView:
+					body, _ := m.detailBody()
+					m.detailViewport.SetContent(body)
+					m.detailViewport.ScrollDown(1)
 				case resources.PodView:
 					if m.selectedItem < len(m.resourceData.Pods)-1 {
 						m.selectedItem++
+						return m, m.schedulePrefetch()
 					}
 				case resources.ServiceView:
 					if m.selectedItem < len(m.resourceData.Services)-1 {
 						m.selectedItem++
 					}
+				case resources.SecretView:
+					if m.selectedItem < len(m.resourceData.Secrets)-1 {
+						m.selectedItem++
+					}
 				case resources.NamespaceView:
 					if m.selectedItem < len(m.namespaces)-1 {
 						m.selectedItem++
 					}
+				case resources.CustomTypeView:
+					if m.selectedItem < len(m.customTypes)-1 {
+						m.selectedItem++
+					}
+				case resources.CustomResourceView:
+					if m.selectedItem < len(m.customResources)-1 {
+						m.selectedItem++
+					}
+				case resources.TopView:
+					if m.selectedItem < len(m.topMetrics)-1 {
+						m.selectedItem++
+					}
+				case resources.ServiceAccountView:
+					if m.selectedItem < len(m.serviceAccounts)-1 {
+						m.selectedItem++
+					}
+				case resources.RoleView:
+					if m.selectedItem < len(m.roles)-1 {
+						m.selectedItem++
+					}
+				case resources.RoleBindingView:
+					if m.selectedItem < len(m.roleBindings)-1 {
+						m.selectedItem++
+					}
+				case resources.WhoCanView:
+					if m.selectedItem < len(m.whoCanSubjects)-1 {
+						m.selectedItem++
+					}
+				case resources.RouteView:
+					if m.selectedItem < len(m.routes)-1 {
+						m.selectedItem++
+					}
+				case resources.RecentView:
+					if m.selectedItem < len(m.recentEntries())-1 {
+						m.selectedItem++
+					}
+				case resources.FleetView:
+					if m.selectedItem < len(m.fleet)-1 {
+						m.selectedItem++
+					}
+				case resources.HistoryView:
+					if m.selectedItem < len(m.revisions)-1 {
+						m.selectedItem++
+					}
+				case resources.CertificateView:
+					if m.selectedItem < len(m.certs)-1 {
+						m.selectedItem++
+					}
+				case resources.EnvView:
+					if m.selectedItem < len(m.envRows())-1 {
+						m.selectedItem++
+					}
 				}
 			}
 
-		case "enter":
+		case actionJump:
+			if !m.loading {
+				return m.jump(msg.String())
+			}
+
+		case actionOpen:
 			if !m.loading {
 				switch m.currentView {
 				case resources.PodView:
 					if len(m.resourceData.Pods) > 0 {
-						m.currentView = resources.DetailView
-						m.loading = true
 						selectedPod := m.resourceData.Pods[m.selectedItem]
-						return m, tea.Batch(
-							m.spinner.Tick,
-							getPodDetail(m.client, selectedPod.Namespace, selectedPod.Name),
-						)
+						detail, cached := m.cachedPodDetail(selectedPod)
+						ctx := m.beginDetail(resources.KindPod, selectedPod.Namespace, selectedPod.Name, false)
+
+						// Start a new sampling loop, stopping any previous one
+						m.metricsGen++
+						metrics := getPodMetrics(m.ctx, m.client, selectedPod.Namespace, selectedPod.Name, m.metricsGen)
+
+						// A prefetched detail of the unchanged pod opens instantly
+						if cached {
+							m.loading = false
+							m.setDetail(detail)
+							// It is as current as the pod list it was checked against
+							if at, ok := m.fetchedAt[resources.PodView]; ok {
+								m.markFetched(at, resources.DetailView)
+							}
+							return m, metrics
+						}
+						return m, tea.Batch(m.loadCmd(m.detailCmd(ctx)), metrics)
 					}
 				case resources.ServiceView:
 					if len(m.resourceData.Services) > 0 {
-						m.currentView = resources.DetailView
-						m.loading = true
 						selectedSvc := m.resourceData.Services[m.selectedItem]
-						return m, tea.Batch(
-							m.spinner.Tick,
-							getServiceDetail(m.client, selectedSvc.Namespace, selectedSvc.Name),
-						)
+						ctx := m.beginDetail(resources.KindService, selectedSvc.Namespace, selectedSvc.Name, false)
+						return m, m.loadCmd(m.detailCmd(ctx))
+					}
+				case resources.SecretView:
+					if len(m.resourceData.Secrets) > 0 {
+						selectedSecret := m.resourceData.Secrets[m.selectedItem]
+						ctx := m.beginDetail(resources.KindSecret, selectedSecret.Namespace, selectedSecret.Name, false)
+						return m, m.loadCmd(m.detailCmd(ctx))
 					}
 				case resources.NamespaceView:
 					if len(m.namespaces) > 0 {
-						m.currentNS = m.namespaces[m.selectedItem]
-						m.currentView = resources.PodView
-						m.loading = true
-						m.message = fmt.Sprintf("Switching to namespace: %s", m.currentNS)
-						return m, tea.Batch(
-							m.spinner.Tick,
-							getResources(m.client, m.currentNS),
-						)
+						return m.switchNamespace(m.namespaces[m.selectedItem].Name)
+					}
+				case resources.CustomTypeView:
+					if len(m.customTypes) > 0 {
+						return m.showCustomResources(m.customTypes[m.selectedItem])
+					}
+				case resources.CustomResourceView:
+					if len(m.customResources) > 0 {
+						selected := m.customResources[m.selectedItem]
+						ctx := m.beginDetail(resources.ResourceKind(m.customType.Kind), selected.Namespace, selected.Name, true)
+						return m, m.loadCmd(m.detailCmd(ctx))
+					}
+				case resources.ServiceAccountView:
+					if len(m.serviceAccounts) > 0 {
+						sa := m.serviceAccounts[m.selectedItem]
+						ctx := m.beginDetail(resources.KindServiceAccount, sa.Namespace, sa.Name, false)
+						return m, m.loadCmd(m.detailCmd(ctx))
+					}
+				case resources.RoleView:
+					if len(m.roles) > 0 {
+						role := m.roles[m.selectedItem]
+						ctx := m.beginDetail(role.Kind, role.Namespace, role.Name, false)
+						return m, m.loadCmd(m.detailCmd(ctx))
+					}
+				case resources.RoleBindingView:
+					if len(m.roleBindings) > 0 {
+						b := m.roleBindings[m.selectedItem]
+						ctx := m.beginDetail(b.Kind, b.Namespace, b.Name, false)
+						return m, m.loadCmd(m.detailCmd(ctx))
+					}
+				case resources.RouteView:
+					if len(m.routes) > 0 {
+						route := m.routes[m.selectedItem]
+						ctx := m.beginDetail(resources.KindRoute, route.Namespace, route.Name, false)
+						return m, m.loadCmd(m.detailCmd(ctx))
+					}
+				case resources.RecentView:
+					if recent := m.recentEntries(); len(recent) > 0 {
+						return m.openRecent(recent[m.selectedItem])
+					}
+				case resources.FleetView:
+					if len(m.fleet) > 0 {
+						return m.switchContext(m.fleet[m.selectedItem].Context)
+					}
+				case resources.HistoryView:
+					return m.showRevisionPods()
+				case resources.CertificateView:
+					if len(m.certs) > 0 {
+						cert := m.certs[m.selectedItem]
+						ctx := m.beginDetail(resources.KindSecret, cert.Namespace, cert.Secret, false)
+						return m, m.loadCmd(m.detailCmd(ctx))
+					}
+				}
+			}
+
+		case actionLogs:
+			if !m.loading && m.currentView == resources.DetailView && m.detailKind == resources.KindPod && !m.detailCustom {
+				return m.openDetailLogs()
+			}
+			if !m.loading && m.currentView == resources.PodView {
+				if len(m.resourceData.Pods) > 0 {
+					selectedPod := m.resourceData.Pods[m.selectedItem]
+
+					// Regular containers first so the default is the first non-init one
+					m.containerChoices = nil
+					for _, c := range selectedPod.Containers {
+						if !c.IsInit {
+							m.containerChoices = append(m.containerChoices, containerChoice{name: c.Name})
+						}
+					}
+					for _, c := range selectedPod.Containers {
+						if c.IsInit {
+							m.containerChoices = append(m.containerChoices, containerChoice{name: c.Name, init: true})
+						}
+					}
+					m.containerIndex = 0
+
+					if len(m.containerChoices) == 1 {
+						// Nothing to choose, go straight to the logs
+						return m.openLogs(m.containerChoices[0].name)
+					}
+					if len(m.containerChoices) > 1 {
+						m.navigate(resources.ContainerView)
 					}
 				}
 			}
 
-		case "r":
+		case actionCompletedJobs:
+			if !m.loading && m.currentView == resources.PodView {
+				m.showCompletedJobs = !m.showCompletedJobs
+				m.filterLists()
+				m.selectedItem = 0
+			}
+
+		case actionUnhealthy:
+			if !m.loading && (m.currentView == resources.PodView || m.currentView == resources.ServiceView) {
+				m.onlyUnhealthy = !m.onlyUnhealthy
+				m.filterLists()
+				m.selectedItem = 0
+			}
+
+		case actionWorkload:
+			if !m.loading {
+				return m.showWorkloadPods()
+			}
+
+		case actionRecent:
+			if !m.loading && m.currentView != resources.RecentView {
+				return m.showRecent()
+			}
+
+		case actionRolloutHistory:
 			if !m.loading {
-				m.loading = true
-				m.message = "Refreshing resources..."
-				return m, tea.Batch(
-					m.spinner.Tick,
-					getResources(m.client, m.currentNS),
-				)
+				return m.showRolloutHistory()
 			}
 
-		case "n":
+		case actionRollback:
+			if !m.loading && m.currentView == resources.HistoryView {
+				return m.confirmRollback()
+			}
+
+		case actionRunCronJob:
 			if !m.loading {
-				m.currentView = resources.NamespaceView
-				// Find current namespace in list
-				for i, ns := range m.namespaces {
-					if ns == m.currentNS {
-						m.selectedItem = i
+				return m.triggerCronJob()
+			}
+
+		case actionSort:
+			if !m.loading && m.currentView == resources.ServiceView {
+				m.servicesByType = !m.servicesByType
+				m.sortServices()
+				m.selectedItem = 0
+			}
+			if !m.loading && m.currentView == resources.TopView {
+				m.topByMemory = !m.topByMemory
+				if err := resources.SortPodMetrics(m.topMetrics, m.topByMemory); err != nil {
+					m.sortFailed(err)
+				}
+				m.selectedItem = 0
+			}
+
+		case actionEventType:
+			if m.currentView == resources.EventStreamView {
+				for i, t := range streamTypeFilters {
+					if t == m.eventStreamType {
+						m.eventStreamType = streamTypeFilters[(i+1)%len(streamTypeFilters)]
 						break
 					}
 				}
+				m.refreshEventStream()
 			}
-		}
 
-	case tea.WindowSizeMsg:
-		m.width, m.height = msg.Width, msg.Height
+		case actionLogWindow:
+			if !m.loading && m.currentView == resources.LogView {
+				return m.cycleLogSince()
+			}
 
-	case spinner.TickMsg:
-		var cmd tea.Cmd
-		m.spinner, cmd = m.spinner.Update(msg)
-		return m, cmd
+		case actionAbsoluteTime:
+			m.absoluteTime = !m.absoluteTime
 
-	case k8sClientMsg:
-		if msg.err != nil {
-			m.loading = false
-			m.error = fmt.Sprintf("Error connecting to Kubernetes: %v", msg.err)
-			return m, nil
-		}
-		m.client = msg.client
-		m.message = "Getting context information..."
-		return m, getContextInfo(m.client)
+		case actionMouse:
+			m.mouseEnabled = !m.mouseEnabled
+			if m.mouseEnabled {
+				model, cmd := m.setStatus(ui.StatusStyle.Render("Mouse enabled"))
+				return model, tea.Batch(tea.EnableMouseCellMotion, cmd)
+			}
+			model, cmd := m.setStatus(ui.StatusStyle.Render("Mouse disabled, terminal text selection available"))
+			return model, tea.Batch(tea.DisableMouse, cmd)
 
-	case contextInfoMsg:
-		if msg.err != nil {
-			m.context = "unknown-context"
-		} else {
-			m.context = msg.context
-		}
-		m.message = "Fetching namespaces..."
-		return m, getNamespaces(m.client)
+		case actionCopy:
+			if !m.loading {
+				if value := m.copyValue(); value != "" {
+					return m, copyToClipboard(value)
+				}
+			}
 
-	case namespacesMsg:
-		if msg.err != nil {
-			m.loading = false
-			m.error = fmt.Sprintf("Error fetching namespaces: %v", msg.err)
-			return m, nil
-		}
-		m.namespaces = msg.namespaces
-		m.message = "Fetching resources..."
-		return m, getResources(m.client, m.currentNS)
+		case actionKubectl:
+			if !m.loading {
+				if command := m.kubectlValue(); command != "" {
+					return m, copyToClipboard(command)
+				}
+			}
 
-	case resourcesMsg:
-		m.loading = false
-		if msg.err != nil {
-			m.error = fmt.Sprintf("Error fetching resources: %v", msg.err)
-			return m, nil
-		}
-		m.resourceData = msg.data
-		return m, nil
+		case actionEvents:
+			if !m.loading {
+				switch m.currentView {
+				case resources.PodView:
+					if len(m.resourceData.Pods) > 0 {
+						pod := m.resourceData.Pods[m.selectedItem]
+						return m.openEvents(resources.KindPod, pod.Namespace, pod.Name)
+					}
+				case resources.ServiceView:
+					if len(m.resourceData.Services) > 0 {
+						svc := m.resourceData.Services[m.selectedItem]
+						return m.openEvents(resources.KindService, svc.Namespace, svc.Name)
+					}
+				case resources.SecretView:
+					if len(m.resourceData.Secrets) > 0 {
+						secret := m.resourceData.Secrets[m.selectedItem]
+						return m.openEvents(resources.KindSecret, secret.Namespace, secret.Name)
+					}
+				case resources.RouteView:
+					if len(m.routes) > 0 {
+						route := m.routes[m.selectedItem]
+						return m.openEvents(resources.KindRoute, route.Namespace, route.Name)
+					}
+				case resources.CustomResourceView:
+					if len(m.customResources) > 0 {
+						item := m.customResources[m.selectedItem]
+						return m.openEvents(resources.ResourceKind(m.customType.Kind), item.Namespace, item.Name)
+					}
+				case resources.DetailView:
+					return m.openEvents(m.detailKind, m.detailNamespace, m.detailName)
+				}
+			}
+
+		case actionFavorite:
+			if !m.loading && m.currentView == resources.NamespaceView {
+				return m.toggleFavorite()
+			}
+
+		case actionFieldSelector:
+			if !m.loading && m.currentView == resources.PodView {
+				m.fieldInput.SetSuggestions(m.fieldSelectorSuggestions())
+				m.fieldInput.SetValue(m.fieldSelector)
+				m.fieldInput.CursorEnd()
+				return m, m.fieldInput.Focus()
+			}
+
+		case actionInspect:
+			if !m.loading && m.currentView == resources.DetailView {
+				return m.toggleInspect()
+			}
+
+		case actionEdit:
+			if !m.loading && m.currentView == resources.DetailView && !m.options.ReadOnly {
+				// Reopen the buffer of a failed edit instead of starting over
+				if m.editPath != "" {
+					return m, openEditor(m.editPath)
+				}
+				ctx := m.beginLoad(fmt.Sprintf("Fetching %s YAML...", strings.ToLower(string(m.detailKind))))
+				return m, m.loadCmd(prepareEdit(ctx, m.client, m.detailKind, m.detailNamespace, m.detailName))
+			}
+
+		case actionMark:
+			if !m.loading && m.currentView == resources.PodView && len(m.resourceData.Pods) > 0 && m.can("delete", "pods") {
+				name := m.resourceData.Pods[m.selectedItem].Name
+				if m.marked[name] {
+					delete(m.marked, name)
+				} else {
+					if m.marked == nil {
+						m.marked = make(map[string]bool)
+					}
+					m.marked[name] = true
+				}
+				if m.selectedItem < len(m.resourceData.Pods)-1 {
+					m.selectedItem++
+				}
+			}
+
+		case actionDelete:
+			if !m.loading && m.currentView == resources.PodView {
+				if len(m.resourceData.Pods) > 0 && m.can("delete", "pods") {
+					return m.confirmDeletePods()
+				}
+			}
+
+		case actionWhyPending:
+			if !m.loading && m.currentView == resources.PodView && len(m.resourceData.Pods) > 0 {
+				pod := m.resourceData.Pods[m.selectedItem]
+				if pod.Phase != "Pending" {
+					return m.setStatus(ui.StatusStyle.Render(fmt.Sprintf("%s is not pending", pod.Name)))
+				}
+				ctx := m.beginLoad(fmt.Sprintf("Diagnosing %s...", pod.Name))
+				m.navigate(resources.DiagnosisView)
+				m.diagnosisName = pod.Name
+				m.diagnoses = nil
+				return m, m.loadCmd(diagnosePod(ctx, m.client, pod.Namespace, pod.Name))
+			}
+
+		case actionEventStream:
+			if !m.loading {
+				switch m.currentView {
+				case resources.PodView, resources.ServiceView, resources.SecretView:
+					return m.showEventStream()
+				}
+			}
+
+		case actionAllNamespaces:
+			if m.currentView == resources.EventStreamView {
+				m.eventStreamAll = !m.eventStreamAll
+				return m.showEventStream()
+			}
+
+		case actionWhoCan:
+			if !m.loading && (isRBACView(m.currentView) || m.currentView == resources.WhoCanView) {
+				return m.openWhoCan()
+			}
+
+		case actionServiceAccounts, actionRoles, actionRoleBindings:
+			if !m.loading && (isRBACView(m.currentView) || m.currentView == resources.WhoCanView || m.currentView == resources.PodView ||
+				m.currentView == resources.ServiceView || m.currentView == resources.SecretView) {
+				switch act {
+				case actionServiceAccounts:
+					return m.showServiceAccounts()
+				case actionRoles:
+					return m.showRoles()
+				default:
+					return m.showRoleBindings()
+				}
+			}
+
+		case actionTop:
+			if !m.loading {
+				switch m.currentView {
+				case resources.PodView, resources.ServiceView, resources.SecretView, resources.DashboardView:
+					return m.showTop()
+				}
+			}
+
+		case actionRoutes:
+			if !m.loading && m.openShift.routes {
+				switch m.currentView {
+				case resources.PodView, resources.ServiceView, resources.SecretView, resources.DashboardView:
+					return m.showRoutes()
+				}
+			}
+
+		case actionDashboard:
+			if !m.loading {
+				switch m.currentView {
+				case resources.PodView, resources.ServiceView, resources.SecretView, resources.ResourceQuotaView:
+					return m.showDashboard()
+				}
+			}
+
+		case actionQuotas:
+			if !m.loading {
+				switch m.currentView {
+				case resources.PodView, resources.DashboardView:
+					return m.showQuotas()
+				}
+			}
+
+		case actionCertificates:
+			if !m.loading && m.currentView == resources.SecretView {
+				return m.showCertificates()
+			}
+
+		case actionFollow:
+			if !m.loading && m.currentView == resources.LogView {
+				return m.toggleLogFollow()
+			}
+			if !m.loading && m.currentView == resources.DetailView {
+				if m.detailFollow {
+					m.stopFollow()
+					return m, nil
+				}
+				m.detailFollow = true
+				m.detailFollowGen++
+				return m, detailTickAfter(m.detailFollowGen, m.options.RefreshInterval)
+			}
+
+		case actionEnvList:
+			if !m.loading && m.currentView == resources.DetailView && m.detailKind == resources.KindPod && !m.detailCustom {
+				return m.showEnv()
+			}
+
+		case actionEnv:
+			if !m.loading && m.currentView == resources.EnvView {
+				return m.toggleEnvReveal()
+			}
+			// Cycle between naming the sources of environment variables,
+			// resolving their values and revealing the ones from Secrets
+			if !m.loading && m.currentView == resources.DetailView && m.detailKind == resources.KindPod && !m.detailCustom {
+				// Values from Secrets are never revealed when they are hidden
+				last := resources.EnvRevealed
+				if m.options.HideSecrets {
+					last = resources.EnvResolved
+				}
+				m.detailEnv = (m.detailEnv + 1) % (last + 1)
+				ctx := m.beginLoad("Fetching pod details...")
+				return m, m.loadCmd(m.detailCmd(ctx))
+			}
+
+		case actionWide:
+			if m.currentView == resources.PodView || m.currentView == resources.ServiceView {
+				m.wide = !m.wide
+			}
+
+		case actionSaveLogs:
+			if !m.loading && m.currentView == resources.LogView {
+				model, statusCmd := m.setStatus(ui.StatusStyle.Render("Saving logs..."))
+				if m.logMerged {
+					return model, tea.Batch(statusCmd, saveMergedLogs(m.logPod, m.logContent))
+				}
+				return model, tea.Batch(statusCmd, saveLogs(m.ctx, m.client, m.logNamespace, m.logPod, m.logContainer, m.logPrevious))
+			}
+
+		case actionMergeLogs:
+			// Switch between the container the logs were opened for and all
+			// containers merged by time
+			if !m.loading && m.currentView == resources.LogView {
+				m.logMerged = !m.logMerged
+				m.logPrevious = false
+				ctx := m.beginLoad("Fetching logs...")
+				return m, m.loadCmd(m.logsCmd(ctx))
+			}
+
+		case actionMoreLogs, actionFewerLogs:
+			if !m.loading && m.currentView == resources.LogView {
+				return m.changeLogTail(act == actionMoreLogs)
+			}
+
+		case actionPreviousLogs:
+			if !m.loading && m.currentView == resources.LogView && !m.logMerged {
+				m.logPrevious = !m.logPrevious
+				ctx := m.beginLoad("Fetching logs...")
+				return m, m.loadCmd(m.logsCmd(ctx))
+			}
+
+		case actionRefresh:
+			if !m.loading {
+				return m.refresh()
+			}
+
+		case actionCustomResources:
+			if !m.loading {
+				switch m.currentView {
+				case resources.PodView, resources.ServiceView, resources.SecretView:
+					return m.showCustomTypes()
+				}
+			}
+
+		case actionGoNamespace:
+			if !m.loading {
+				switch m.currentView {
+				case resources.PodView, resources.ServiceView, resources.SecretView, resources.NamespaceView:
+					names := make([]string, 0, len(m.namespaces))
+					for _, ns := range m.namespaces {
+						names = append(names, ns.Name)
+					}
+					m.nsInput.SetSuggestions(names)
+					m.nsInput.SetValue("")
+					return m, m.nsInput.Focus()
+				}
+			}
+
+		case actionGoTo:
+			if !m.loading {
+				switch m.currentView {
+				case resources.PodView, resources.ServiceView, resources.SecretView:
+					return m.openJump()
+				}
+			}
+
+		case actionNamespaces:
+			if !m.loading {
+				return m.showNamespaces()
+			}
+
+		case actionPalette:
+			if !m.loading {
+				return m.openPalette()
+			}
+
+		case actionHelp:
+			m.showHelp = true
+		}
+
+	case tea.MouseMsg:
+		if !m.mouseEnabled || m.loading || m.modal != nil || msg.Action != tea.MouseActionPress {
+			return m, nil
+		}
+		return m.updateMouse(msg)
+
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		// Leave room for the log view header and help line
+		m.logViewport.Width = msg.Width
+		m.logViewport.Height = max(msg.Height-5, 1)
+		m.eventViewport.Width = msg.Width
+		m.eventViewport.Height = max(msg.Height-5, 1)
+		m.detailViewport.Width = msg.Width
+		m.detailViewport.Height = max(msg.Height-6, 1)
+
+	case eventStreamStartedMsg, eventStreamMsg, eventStreamEndMsg:
+		return m.updateEventStream(msg)
+
+	case logFollowStartedMsg, logLinesMsg, logFollowEndMsg, logPodCheckMsg, logFollowRetryMsg:
+		return m.updateLogFollow(msg)
+
+	case prefetchTickMsg, prefetchedDetailMsg:
+		return m.updatePrefetch(msg)
+
+	case dashboardMsg, dashboardTickMsg, dashboardRefreshMsg:
+		return m.updateDashboard(msg)
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case loadResultMsg:
+		// Drop results of cancelled or superseded loads
+		if msg.gen != m.loadGen {
+			return m, nil
+		}
+		model, cmd := m.Update(msg.msg)
+		next := model.(Model)
+
+		// A failed load may mean the connection is gone rather than the
+		// request being wrong
+		if next.error != "" {
+			if m.error == "" {
+				cmd = tea.Batch(cmd, next.probeConnection())
+			}
+			return next, cmd
+		}
+		next.markFetched(time.Now(), fetchedViews(msg.msg)...)
+		return next, cmd
+
+	case freshnessTickMsg:
+		return m, freshnessTick()
+
+	case connectionProbeMsg, reconnectTickMsg, reconnectedMsg:
+		return m.updateReconnect(msg)
+
+	case loadTimeoutMsg:
+		if msg.gen == m.loadGen && m.loading {
+			m.loadSlow = true
+		}
+		return m, nil
+
+	case k8sClientMsg:
+		// Let the user choose a context rather than failing
+		if errors.Is(msg.err, client.ErrNoCurrentContext) {
+			contexts, err := client.ListContexts(m.options.Kubeconfig)
+			if err == nil {
+				m.loading = false
+				m.contexts = contexts
+				m.contextIndex = 0
+				m.currentView = resources.ContextView
+				return m, nil
+			}
+		}
+		if msg.err != nil {
+			m.loading = false
+			m.error = fmt.Sprintf("Error connecting to Kubernetes: %v", msg.err)
+			return m, nil
+		}
+		m.client = msg.client
+		m.message = "Getting context information..."
+		return m, m.track(getContextInfo(m.client))
+
+	case contextInfoMsg:
+		if msg.err != nil {
+			m.context = "unknown-context"
+		} else {
+			m.context = msg.context
+		}
+		m.message = "Discovering API groups..."
+		return m, m.track(detectOpenShift(m.client))
+
+	case openShiftMsg:
+		m.openShift = msg.apis
+		m.message = "Fetching namespaces..."
+		if m.openShift.projects {
+			m.message = "Fetching projects..."
+		}
+		return m, m.track(getNamespaces(m.loadCtx, m.client, m.openShift.projects))
+
+	case namespacesMsg:
+		if msg.err != nil {
+			m.loading = false
+			m.error = fmt.Sprintf("Error fetching namespaces: %v", msg.err)
+			return m, nil
+		}
+		m.namespaces = msg.namespaces
+		m.nsPodCountsAt = time.Time{}
+		m.sortFavoritesFirst()
+		m.message = "Fetching resources..."
+		cmds := []tea.Cmd{
+			m.track(getResources(m.loadCtx, m.client, m.currentNS, m.fieldSelector, m.workload)),
+			getPermissions(m.ctx, m.client, m.currentNS),
+		}
+
+		// Views that are not filled by getResources when starting on them
+		switch m.currentView {
+		case resources.SecretView:
+			cmds = append(cmds, m.track(getSecrets(m.loadCtx, m.client, m.currentNS)))
+		case resources.TopView:
+			cmds = append(cmds, m.track(getTopMetrics(m.loadCtx, m.client, m.currentNS)))
+		case resources.DashboardView:
+			cmds = append(cmds, m.track(getDashboard(m.loadCtx, m.client, m.currentNS, m.fieldSelector, m.workload, false)))
+		case resources.FleetView:
+			cmds = append(cmds, m.track(getFleet(m.loadCtx, m.client)))
+		}
+		return m, tea.Batch(cmds...)
+
+	case secretsMsg:
+		m.loading = false
+		m.setForbidden("secrets", resources.IsForbidden(msg.err))
+		if msg.err != nil && !m.forbidden["secrets"] {
+			m.error = fmt.Sprintf("Error fetching secrets: %v", msg.err)
+			return m, nil
+		}
+		m.resourceData.Secrets = msg.secrets
+		m.clampSelection()
+		return m, nil
+
+	case secretDetailMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.error = fmt.Sprintf("Error fetching secret details: %v", msg.err)
+			return m, nil
+		}
+		m.setDetail(msg.detail)
+		return m, nil
+
+	case diagnosisMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.error = fmt.Sprintf("Error diagnosing pod: %v", msg.err)
+			return m, nil
+		}
+		m.diagnoses = msg.diagnoses
+		return m, nil
+
+	case serviceAccountsMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.error = fmt.Sprintf("Error fetching service accounts: %v", msg.err)
+			return m, nil
+		}
+		m.serviceAccounts = msg.accounts
+		return m, nil
+
+	case rolesMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.error = fmt.Sprintf("Error fetching roles: %v", msg.err)
+			return m, nil
+		}
+		m.roles = msg.roles
+		return m, nil
+
+	case roleBindingsMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.error = fmt.Sprintf("Error fetching role bindings: %v", msg.err)
+			return m, nil
+		}
+		m.roleBindings = msg.bindings
+		return m, nil
+
+	case jumpMsg:
+		return m.openJumpTarget(msg)
+
+	case podWorkloadMsg:
+		return m.openPodWorkload(msg)
+
+	case rolloutHistoryMsg:
+		return m.updateRolloutHistory(msg)
+
+	case deploymentRolledBackMsg:
+		return m.rolledBack(msg)
+
+	case cronJobMsg:
+		return m.confirmTrigger(msg)
+
+	case cronJobTriggeredMsg:
+		return m.cronJobTriggered(msg)
+
+	case nsPodCountsMsg:
+		// Counts from a context switched away from are dropped
+		m.nsPodCountsPending = false
+		if msg.context == m.context {
+			m.nsPodCounts = msg.counts
+			m.nsPodCountsAt = time.Now()
+		}
+		return m, nil
+
+	case quotasMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.error = fmt.Sprintf("Error fetching resource quotas: %v", msg.err)
+			return m, nil
+		}
+		m.quotas = msg.quotas
+		return m, nil
+
+	case fleetMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.error = fmt.Sprintf("Error summarizing contexts: %v", msg.err)
+			return m, nil
+		}
+		m.fleet = msg.clusters
+		return m, nil
+
+	case certificatesMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.error = fmt.Sprintf("Error scanning TLS secrets: %v", msg.err)
+			return m, nil
+		}
+		m.certs = msg.certs
+		return m, nil
+
+	case containerEnvMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.error = fmt.Sprintf("Error fetching environment: %v", msg.err)
+			return m, nil
+		}
+		m.envVars = msg.vars
+		m.clampSelection()
+		return m, nil
+
+	case routesMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.error = fmt.Sprintf("Error fetching routes: %v", msg.err)
+			return m, nil
+		}
+		m.routes = msg.routes
+		return m, nil
+
+	case routeDetailMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.error = fmt.Sprintf("Error fetching route details: %v", msg.err)
+			return m, nil
+		}
+		m.detailContent = msg.detail
+		return m, nil
+
+	case whoCanMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.error = fmt.Sprintf("Error checking who can %s: %v", m.whoCanQuery, msg.err)
+			return m, nil
+		}
+		m.whoCanSubjects = msg.subjects
+		return m, nil
+
+	case rbacDetailMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.error = fmt.Sprintf("Error fetching %s details: %v", strings.ToLower(string(m.detailKind)), msg.err)
+			return m, nil
+		}
+		m.detailContent = msg.detail
+		return m, nil
+
+	case topMetricsMsg:
+		m.loading = false
+		m.topUnavailable = errors.Is(msg.err, resources.ErrMetricsUnavailable)
+		if msg.err != nil && !m.topUnavailable {
+			m.error = fmt.Sprintf("Error fetching pod metrics: %v", msg.err)
+			return m, nil
+		}
+		m.topMetrics = msg.metrics
+		if err := resources.SortPodMetrics(m.topMetrics, m.topByMemory); err != nil {
+			m.sortFailed(err)
+		}
+		return m, nil
+
+	case customTypesMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.error = fmt.Sprintf("Error fetching custom resource types: %v", msg.err)
+			return m, nil
+		}
+		m.customTypes = msg.types
+		return m, nil
+
+	case customResourcesMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.error = fmt.Sprintf("Error fetching %s: %v", m.customType.Name, msg.err)
+			return m, nil
+		}
+		m.customResources = msg.items
+		return m, nil
+
+	case rawMsg:
+		m.loading = false
+		if m.detailQuery == nil {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.error = fmt.Sprintf("Error running %s: %v", m.detailQuery, msg.err)
+			return m, nil
+		}
+		m.detailKind = msg.result.Kind
+		m.detailContent = msg.result.Content
+		if msg.result.Truncated {
+			return m.setStatus(ui.WarningStyle.Render(fmt.Sprintf("Only the first %d are shown", resources.MaxRawItems)))
+		}
+		return m, nil
+
+	case customDetailMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.error = fmt.Sprintf("Error fetching %s details: %v", strings.ToLower(m.customType.Kind), msg.err)
+			return m, nil
+		}
+		m.detailContent = msg.detail
+		return m, nil
+
+	case eventsMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.error = fmt.Sprintf("Error fetching events: %v", msg.err)
+			return m, nil
+		}
+		m.events = msg.events
+		return m, nil
+
+	case editReadyMsg:
+		m.loading = false
+		if msg.err != nil {
+			return m.setStatus(ui.ErrorStyle.Render(msg.err.Error()))
+		}
+		m.editPath = msg.path
+		m.editOriginal = msg.original
+		return m, openEditor(m.editPath)
+
+	case editorClosedMsg:
+		if msg.err != nil {
+			return m.setStatus(ui.ErrorStyle.Render(fmt.Sprintf("Error running editor: %v", msg.err)))
+		}
+		ctx := m.beginLoad("Applying changes...")
+		m.loadMutates = true
+		return m, m.loadCmd(applyEdit(ctx, m.client, m.detailKind, m.detailNamespace, m.detailName, m.editOriginal, m.editPath))
+
+	case editAppliedMsg:
+		m.loading = false
+		if msg.err != nil {
+			// Keep the buffer around so the next edit picks up where this one left off
+			if denial, ok := resources.ParseAdmissionDenial(msg.err); ok {
+				action := fmt.Sprintf("the changes to %s %s", strings.ToLower(string(m.detailKind)), m.detailName)
+				return m.openModal(ui.NewDenialModal(denial, action, "Edit them again?"), func(m Model, _ string) (tea.Model, tea.Cmd) {
+					return m, openEditor(m.editPath)
+				})
+			}
+			return m.setStatus(ui.ErrorStyle.Render(fmt.Sprintf("%v (press %s to fix)", msg.err, m.keyHelp(actionEdit))))
+		}
+		m.discardEdit()
+		if !msg.changed {
+			return m.setStatus(ui.StatusStyle.Render("Edit cancelled, no changes made"))
+		}
+
+		ctx := m.beginLoad("Refreshing details...")
+		refresh := m.detailCmd(ctx)
+		model, statusCmd := m.setStatus(ui.SuccessStyle.Render(fmt.Sprintf("%s %s updated", m.detailKind, m.detailName)))
+		return model, tea.Batch(m.loadCmd(refresh), statusCmd)
+
+	case logsSavedMsg:
+		if msg.err != nil {
+			return m.setStatus(ui.ErrorStyle.Render(msg.err.Error()))
+		}
+		return m.setStatus(ui.SuccessStyle.Render(fmt.Sprintf("Logs saved to %s (%d bytes)", msg.path, msg.size)))
+
+	case clipboardMsg:
+		if msg.err != nil {
+			// No clipboard (e.g. over SSH), show the value so it can be copied by hand
+			return m.setStatus(ui.StatusStyle.Render(msg.value))
+		}
+		return m.setStatus(ui.SuccessStyle.Render("copied!"))
+
+	case podMetricsMsg:
+		key := podKey(msg.namespace, msg.name)
+		if msg.err == nil {
+			if m.usage == nil {
+				m.usage = make(map[string]*usageHistory)
+			}
+			if m.usage[key] == nil {
+				m.usage[key] = &usageHistory{}
+			}
+			m.usage[key].add(msg.metrics)
+		}
+
+		// Keep sampling while the pod's detail stays open
+		if msg.gen == m.metricsGen && m.currentView == resources.DetailView && m.detailKind == resources.KindPod {
+			return m, metricsTickAfter(msg.gen, metricsInterval)
+		}
+		return m, nil
+
+	case metricsTickMsg:
+		if msg.gen == m.metricsGen && m.currentView == resources.DetailView && m.detailKind == resources.KindPod {
+			return m, getPodMetrics(m.ctx, m.client, m.detailNamespace, m.detailName, msg.gen)
+		}
+		return m, nil
+
+	case detailTickMsg:
+		if msg.gen != m.detailFollowGen || !m.detailFollow || m.currentView != resources.DetailView {
+			return m, nil
+		}
+		// Skip this round while something else is loading
+		if m.loading {
+			return m, detailTickAfter(msg.gen, m.options.RefreshInterval)
+		}
+		var ctx context.Context
+		ctx, m.detailFollowCancel = context.WithCancel(m.ctx)
+		refresh := m.detailCmd(ctx)
+		gen := msg.gen
+		return m, func() tea.Msg {
+			return detailRefreshMsg{gen, refresh()}
+		}
+
+	case detailRefreshMsg:
+		if msg.gen != m.detailFollowGen || !m.detailFollow {
+			return m, nil
+		}
+		// A load started meanwhile (e.g. an edit) owns the view, skip this round
+		if m.loading {
+			return m, detailTickAfter(msg.gen, m.options.RefreshInterval)
+		}
+		model, cmd := m.Update(msg.msg)
+		m = model.(Model)
+		if m.error != "" {
+			// Stop following a resource that can no longer be fetched
+			m.stopFollow()
+			return m, cmd
+		}
+		m.markFetched(time.Now(), resources.DetailView)
+		return m, tea.Batch(cmd, detailTickAfter(msg.gen, m.options.RefreshInterval))
+
+	case favoritesSavedMsg:
+		if msg.err != nil {
+			return m.setStatus(ui.ErrorStyle.Render(fmt.Sprintf("Error saving favorites: %v", msg.err)))
+		}
+		return m, nil
+
+	case clearStatusMsg:
+		// Only clear the status this timer was started for
+		if msg.id == m.statusID {
+			m.status = ""
+		}
+		return m, nil
+
+	case permissionsMsg:
+		// Ignore results for a namespace we already left
+		if msg.namespace == m.currentNS {
+			m.permissions = msg.allowed
+		}
+		return m, nil
+
+	case podDeletedMsg:
+		if msg.err != nil {
+			m.loading = false
+			if denial, ok := resources.ParseAdmissionDenial(msg.err); ok {
+				m.loadMutates = false
+				return m.openModal(ui.NewDenialModal(denial, "deleting pod "+msg.name, "Try deleting it again?"), retryDeletePods([]string{msg.name}))
+			}
+			m.error = fmt.Sprintf("Error deleting pod: %v", msg.err)
+			return m, nil
+		}
+		delete(m.usage, podKey(m.currentNS, msg.name))
+		m.loadMutates = false
+		m.message = fmt.Sprintf("Deleted pod %s, refreshing...", msg.name)
+		return m, m.track(getResources(m.loadCtx, m.client, m.currentNS, m.fieldSelector, m.workload))
+
+	case podsDeletedMsg:
+		m.loadMutates = false
+		m.marked = nil
+		m.message = "Refreshing..."
+
+		if len(msg.failed) == 0 {
+			m.status = ui.SuccessStyle.Render(fmt.Sprintf("Deleted %d pods", msg.count))
+		} else {
+			failures := make([]string, 0, len(msg.failed))
+			for name, err := range msg.failed {
+				failures = append(failures, fmt.Sprintf("%s (%v)", name, err))
+			}
+			sort.Strings(failures)
+			m.status = ui.ErrorStyle.Render(fmt.Sprintf("Deleted %d of %d pods, failed: %s",
+				msg.count-len(msg.failed), msg.count, strings.Join(failures, ", ")))
+		}
+		m.statusID++
+		cmd := tea.Batch(
+			m.track(getResources(m.loadCtx, m.client, m.currentNS, m.fieldSelector, m.workload)),
+			clearStatusAfter(m.statusID, statusTimeout),
+		)
+
+		// The pods an admission webhook kept can be deleted again once
+		// the policy allows it, the reasons of the first one are shown
+		var denied []string
+		for name, err := range msg.failed {
+			if _, ok := resources.ParseAdmissionDenial(err); ok {
+				denied = append(denied, name)
+			}
+		}
+		if len(denied) == 0 {
+			return m, cmd
+		}
+		sort.Strings(denied)
+		denial, _ := resources.ParseAdmissionDenial(msg.failed[denied[0]])
+		action := "deleting pod " + denied[0]
+		if len(denied) > 1 {
+			action += fmt.Sprintf(" and %d more", len(denied)-1)
+		}
+		model, modalCmd := m.openModal(ui.NewDenialModal(denial, action, "Try deleting them again?"), retryDeletePods(denied))
+		return model, tea.Batch(cmd, modalCmd)
+
+	case resourcesMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.error = fmt.Sprintf("Error fetching resources: %v", msg.err)
+			return m, nil
+		}
+		changes := m.trackChanges(msg.data, msg.failed)
+		m.resourceData = msg.data
+		m.completedJobPods, m.healthyPods, m.healthyServices = nil, nil, nil
+		m.filterLists()
+
+		// Show what could be listed and warn about the rest until a later
+		// refresh succeeds. What may not be listed at all is said in place
+		// of its list.
+		var warnings []string
+		for _, kind := range []string{"pods", "services"} {
+			err := msg.failed[kind]
+			m.setForbidden(kind, resources.IsForbidden(err))
+			if err != nil && !m.forbidden[kind] {
+				warnings = append(warnings, fmt.Sprintf("%s: %s", kind, resources.ShortError(err)))
+			}
+		}
+		if len(warnings) > 0 {
+			m.status = ui.WarningStyle.Render("Could not list " + strings.Join(warnings, ", "))
+			m.statusID++
+			m.listWarning = m.status
+		} else if m.listWarning != "" && m.status == m.listWarning {
+			m.status = ""
+			m.listWarning = ""
+		}
+
+		m.pruneDetailCache()
+
+		// Forget marks of pods that are gone
+		if len(m.marked) > 0 {
+			existing := make(map[string]bool, len(m.resourceData.Pods))
+			for _, pod := range m.resourceData.Pods {
+				existing[pod.Name] = true
+			}
+			for name := range m.marked {
+				if !existing[name] {
+					delete(m.marked, name)
+				}
+			}
+		}
+
+		// Deleted pods may have been at the bottom of the list
+		m.clampSelection()
+		return m, tea.Batch(m.schedulePrefetch(), changes)
+
+	case changesFadeMsg:
+		if msg.gen == m.changesGen {
+			m.podChanges, m.serviceChanges = ui.RowChanges{}, ui.RowChanges{}
+		}
+		return m, nil
+
+	case podDetailMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.error = fmt.Sprintf("Error fetching pod details: %v", msg.err)
+			return m, nil
+		}
+		m.setDetail(msg.detail)
+		return m, nil
+
+	case serviceDetailMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.error = fmt.Sprintf("Error fetching service details: %v", msg.err)
+			return m, nil
+		}
+		m.setDetail(msg.detail)
+		return m, nil
+
+	case podLogsMsg:
+		m.loading = false
+		// Following starts over from the refreshed logs
+		m.stopLogFollow()
+		m.logFollowSince = time.Now()
+		if errors.Is(msg.err, resources.ErrNoPreviousLogs) {
+			m.logContent = ""
+			m.logViewport.SetContent(ui.StatusStyle.Render("no previous logs"))
+			return m, nil
+		}
+		if msg.err != nil {
+			m.error = fmt.Sprintf("Error fetching pod logs: %v", msg.err)
+			return m, nil
+		}
+		m.logContent = msg.logs
+		m.refreshLogViewport()
+		m.logViewport.GotoBottom()
+		return m, nil
+	}
+
+	m.spinner, cmd = m.spinner.Update(msg)
+	return m, cmd
+}
+
+// View renders the current view
+func (m Model) View() string {
+	var view string
+	switch {
+	case m.reconnecting:
+		view = ui.RenderReconnectingView(m.spinner.View(), m.reconnectAttempt, time.Until(m.reconnectRetry), m.reconnectErr, m.keys.help())
+	case m.loading:
+		message := m.message
+		if m.loadSlow {
+			message += " still loading... (press esc to cancel)"
+		}
+		view = ui.RenderLoadingView(m.spinner.View(), message)
+	case m.error != "":
+		view = ui.RenderErrorView(m.error, m.keys.help())
+	default:
+		view = m.renderCurrentView()
+		// The trail goes on the blank line the views start with
+		if crumbs := m.breadcrumb(); crumbs != "" {
+			if rest, ok := strings.CutPrefix(view, "\n"); ok {
+				view = crumbs + "\n" + rest
+			} else {
+				view = crumbs + "\n" + view
+			}
+		}
+		status := m.status
+		if fresh := m.freshness(); fresh != "" {
+			status = strings.TrimSuffix(fresh+"  "+status, "  ")
+		}
+		if m.options.ReadOnly {
+			// Always on screen so nobody wonders why nothing can be changed
+			status = strings.TrimSuffix(ui.InfoStyle.Render("read-only mode")+"  "+status, "  ")
+		}
+		if m.options.HideSecrets {
+			status = strings.TrimSuffix(ui.InfoStyle.Render("secrets hidden")+"  "+status, "  ")
+		}
+		if m.protected() {
+			// On every view, not only the lists, e.g. while editing a
+			// detail
+			status = strings.TrimSuffix(ui.ProdBadgeStyle.Render("PROD")+"  "+status, "  ")
+		}
+		if status != "" {
+			view += "\n  " + status
+		}
+		if m.paletteInput.Focused() {
+			view = ui.Overlay(view, m.paletteView())
+		}
+	}
+
+	if m.showHelp {
+		view = ui.OverlayCenter(view, ui.RenderKeyBindings(m.keyBindingsHelp(), m.width), m.width, m.height)
+	}
+	if m.modal != nil {
+		view = ui.OverlayCenter(view, m.modal.modal.View(), m.width, m.height)
+	}
+
+	return view
+}
+
+// renderCurrentView renders the active view
+func (m Model) renderCurrentView() string {
+	lv := ui.ListView{
+		Namespace: m.currentNS,
+		Context:   m.context,
+		As:        m.options.As,
+		Selected:  m.selectedItem,
+		Width:     m.width,
+
+		AbsoluteTime: m.absoluteTime,
+		Marked:       m.marked,
+		Wide:         m.wide,
+		Height:       m.listHeight(),
+		Keys:         m.keys.help(),
+	}
+	if m.nsInput.Focused() {
+		lv.FilterBar = m.nsInput.View()
+	}
+	if m.jumpInput.Focused() {
+		lv.FilterBar = m.jumpInput.View()
+	}
+	if m.whoCanInput.Focused() {
+		lv.FilterBar = m.whoCanInput.View()
+	}
+	if m.searchInput.Focused() {
+		lv.FilterBar = m.searchInput.View()
+	} else if m.listSearch != "" {
+		lv.FilterBar = ui.StatusStyle.Render(fmt.Sprintf("search: %s (esc to clear)", m.listSearch))
+	}
+	lv.Search = m.listSearch
+
+	switch m.currentView {
+	case resources.PodView:
+		if m.fieldInput.Focused() {
+			lv.FilterBar = m.fieldInput.View()
+		} else if m.fieldSelector != "" && lv.FilterBar == "" {
+			lv.FilterBar = ui.StatusStyle.Render(fmt.Sprintf("field selector: %s (%s to change)", m.fieldSelector, m.keyHelp(actionFieldSelector)))
+		}
+		if m.workload != nil && lv.FilterBar == "" {
+			filterHelp := m.keyHelp(actionWorkload) + " to show all pods"
+			switch m.workload.Kind {
+			case "Deployment":
+				filterHelp += ", " + m.keyHelp(actionRolloutHistory) + " rollout history"
+			case "CronJob":
+				if m.can("create", "jobs") {
+					filterHelp += ", " + m.keyHelp(actionRunCronJob) + " run now"
+				}
+			}
+			lv.FilterBar = ui.InfoStyle.Render(fmt.Sprintf("workload: %s (%s)", m.workload, filterHelp))
+		}
+		lv.OnlyUnhealthy, lv.HiddenHealthy = m.onlyUnhealthy, len(m.healthyPods)
+		lv.Changes = m.podChanges
+		lv.Forbidden = m.forbidden["pods"]
+		return ui.RenderPodsView(m.resourceData.Pods, lv, m.can("delete", "pods"), len(m.completedJobPods))
+	case resources.ServiceView:
+		lv.OnlyUnhealthy, lv.HiddenHealthy = m.onlyUnhealthy, len(m.healthyServices)
+		lv.Changes = m.serviceChanges
+		lv.Forbidden = m.forbidden["services"]
+		return ui.RenderServicesView(m.resourceData.Services, lv, m.servicesByType)
+	case resources.SecretView:
+		lv.Forbidden = m.forbidden["secrets"]
+		return ui.RenderSecretsView(m.resourceData.Secrets, lv)
+	case resources.RouteView:
+		return ui.RenderRoutesView(m.routes, lv)
+	case resources.RecentView:
+		entries := m.recentEntries()
+		recent := make([]resources.RecentResource, len(entries))
+		for i, e := range entries {
+			recent[i] = e.RecentResource
+		}
+		return ui.RenderRecentView(recent, lv)
+	case resources.FleetView:
+		return ui.RenderFleetView(m.fleet, lv)
+	case resources.HistoryView:
+		return ui.RenderRolloutHistoryView(m.rolloutDeployment.String(), m.revisions, lv, m.can("patch", "deployments"))
+	case resources.CertificateView:
+		return ui.RenderCertificatesView(m.certs, lv)
+	case resources.EnvView:
+		return ui.RenderEnvView(m.envPod, m.envContainer, m.envRows(), len(m.envVars), lv, m.envReveal, !m.options.HideSecrets)
+	case resources.ResourceQuotaView:
+		return ui.RenderResourceQuotasView(m.quotas, lv)
+	case resources.DiagnosisView:
+		return ui.RenderDiagnosisView(m.diagnosisName, m.diagnoses, lv.Keys)
+	case resources.ServiceAccountView:
+		return ui.RenderServiceAccountsView(m.serviceAccounts, lv)
+	case resources.RoleView:
+		return ui.RenderRolesView(m.roles, lv)
+	case resources.RoleBindingView:
+		return ui.RenderRoleBindingsView(m.roleBindings, lv)
+	case resources.WhoCanView:
+		return ui.RenderWhoCanView(m.whoCanQuery, m.whoCanSubjects, lv)
+	case resources.TopView:
+		return ui.RenderTopView(m.topMetrics, lv, m.topByMemory, m.topUnavailable)
+	case resources.DashboardView:
+		// The overview counts the hidden pods and services too
+		data := m.resourceData
+		data.Pods = slices.Concat(data.Pods, m.completedJobPods, m.healthyPods)
+		data.Services = slices.Concat(data.Services, m.healthyServices)
+		return ui.RenderDashboardView(data, m.dashboard, lv)
+	case resources.CustomTypeView:
+		return ui.RenderCustomTypesView(m.customTypes, lv)
+	case resources.CustomResourceView:
+		return ui.RenderCustomResourcesView(m.customType, m.customResources, lv)
+	case resources.DetailView:
+		vp := m.detailViewport
+		body, _ := m.detailBody()
+		vp.SetContent(body)
+		return ui.RenderPodDetailView(vp.View(), m.detailFollow, m.detailKind == resources.KindPod && !m.detailCustom, m.detailEnv, !m.options.ReadOnly, m.detailInspect, m.options.HideSecrets, lv.Keys)
+	case resources.NamespaceView:
+		view := ui.RenderNamespacesView(m.namespaces, lv, m.openShift.projects, m.options.FavoriteNamespaces[m.context], m.nsPodCounts)
+		if m.nsInput.Focused() {
+			view += "\n  " + m.nsInput.View()
+		}
+		return view
+	case resources.EventsView:
+		return ui.RenderEventsView(m.events, string(m.eventsKind), m.eventsName, m.width, m.absoluteTime, lv.Keys)
+	case resources.ContainerView:
+		labels := make([]string, 0, len(m.containerChoices))
+		for _, c := range m.containerChoices {
+			if c.init {
+				labels = append(labels, c.name+" (init)")
+			} else {
+				labels = append(labels, c.name)
+			}
+		}
+		return ui.RenderContainerPicker(m.resourceData.Pods[m.selectedItem].Name, labels, m.containerIndex, lv.Keys)
+	case resources.ContextView:
+		return ui.RenderContextPicker(m.contexts, m.contextIndex, m.width, lv.Keys)
+	case resources.EventStreamView:
+		scope := "namespace " + m.currentNS
+		if m.eventStreamAll {
+			scope = "all namespaces"
+		}
+		return ui.RenderEventStreamView(m.eventViewport.View(), scope, m.eventStreamType, m.eventStreamErr, lv.Keys)
+	case resources.LogView:
+		return ui.RenderLogView(m.logViewport.View(), m.logPod, m.logContainer, m.logPrevious, m.logMerged, m.logFilterBar(), m.logFollow, m.logRange.String(), lv.Keys)
+	default:
+		return "Unknown view"
+	}
+}
+
+// updateMouse handles mouse presses by translating them into key presses
+func (m Model) updateMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return m.Update(tea.KeyMsg{Type: tea.KeyUp})
+
+	case tea.MouseButtonWheelDown:
+		return m.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+	case tea.MouseButtonLeft:
+		count, ok := m.listLen()
+		if !ok {
+			return m, nil
+		}
+
+		// Ignore clicks outside the rows of the current page
+		height := m.listHeight()
+		row := msg.Y - ui.ListHeaderHeight
+		if row < 0 || (height > 0 && row >= height) {
+			return m, nil
+		}
+		row += ui.PageStart(m.selectedItem, height)
+		if row >= count {
+			return m, nil
+		}
+
+		// A click selects a row, a second click on it opens it
+		if row == m.selectedItem {
+			return m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		}
+		m.selectedItem = row
+		return m, m.schedulePrefetch()
+	}
+
+	return m, nil
+}
+
+// listLen returns the number of rows of the current list view, ok is false
+// when the view is not a list
+func (m Model) listLen() (count int, ok bool) {
+	switch m.currentView {
+	case resources.PodView:
+		return len(m.resourceData.Pods), true
+	case resources.ServiceView:
+		return len(m.resourceData.Services), true
+	case resources.SecretView:
+		return len(m.resourceData.Secrets), true
+	case resources.NamespaceView:
+		return len(m.namespaces), true
+	case resources.CustomTypeView:
+		return len(m.customTypes), true
+	case resources.CustomResourceView:
+		return len(m.customResources), true
+	case resources.TopView:
+		return len(m.topMetrics), true
+	case resources.ServiceAccountView:
+		return len(m.serviceAccounts), true
+	case resources.RoleView:
+		return len(m.roles), true
+	case resources.RoleBindingView:
+		return len(m.roleBindings), true
+	case resources.WhoCanView:
+		return len(m.whoCanSubjects), true
+	case resources.RouteView:
+		return len(m.routes), true
+	case resources.RecentView:
+		return len(m.recentEntries()), true
+	case resources.FleetView:
+		return len(m.fleet), true
+	case resources.HistoryView:
+		return len(m.revisions), true
+	case resources.CertificateView:
+		return len(m.certs), true
+	case resources.EnvView:
+		return len(m.envRows()), true
+	}
+	return 0, false
+}
+
+// listFooterHeight is the number of lines kept below the rows of a list for
+// the page indicator, the pod status message, the help and the status line
+const listFooterHeight = 8
+
+// listHeight returns the number of list rows that fit on screen, 0 when the
+// terminal size is not known yet
+func (m Model) listHeight() int {
+	if m.height == 0 {
+		return 0
+	}
+	return max(m.height-ui.ListHeaderHeight-listFooterHeight, 1)
+}
+
+// jump handles home, end, ctrl+d and ctrl+u: it selects the first or last
+// row, or moves the selection half a page down or up. The log, detail and
+// event stream views are scrolled the same way instead.
+func (m Model) jump(key string) (tea.Model, tea.Cmd) {
+	var vp *viewport.Model
+	switch m.currentView {
+	case resources.LogView:
+		vp = &m.logViewport
+	case resources.EventStreamView:
+		vp = &m.eventViewport
+	case resources.DetailView:
+		body, _ := m.detailBody()
+		m.detailViewport.SetContent(body)
+		vp = &m.detailViewport
+	}
+	if vp != nil {
+		switch key {
+		case "home":
+			vp.GotoTop()
+		case "end":
+			vp.GotoBottom()
+		case "ctrl+d":
+			vp.HalfPageDown()
+		case "ctrl+u":
+			vp.HalfPageUp()
+		}
+		return m, nil
+	}
+
+	count, ok := m.listLen()
+	if !ok || count == 0 {
+		return m, nil
+	}
+	half := max(m.listHeight()/2, 1)
+	switch key {
+	case "home":
+		m.selectedItem = 0
+	case "end":
+		m.selectedItem = count - 1
+	case "ctrl+d":
+		m.selectedItem = min(m.selectedItem+half, count-1)
+	case "ctrl+u":
+		m.selectedItem = max(m.selectedItem-half, 0)
+	}
+	return m, m.schedulePrefetch()
+}
+
+// updateContainerPicker handles key presses in the container picker
+func (m Model) updateContainerPicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m.quit()
+
+	case "esc":
+		return m.back()
+
+	case "up", "k":
+		if m.containerIndex > 0 {
+			m.containerIndex--
+		}
+
+	case "down", "j":
+		if m.containerIndex < len(m.containerChoices)-1 {
+			m.containerIndex++
+		}
+
+	case "enter":
+		return m.openLogs(m.containerChoices[m.containerIndex].name)
+	}
+
+	return m, nil
+}
+
+// updateContextPicker handles key presses in the context picker shown
+// before connecting
+func (m Model) updateContextPicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q", "esc":
+		return m.quit()
+
+	case "up", "k":
+		if m.contextIndex > 0 {
+			m.contextIndex--
+		}
+
+	case "down", "j":
+		if m.contextIndex < len(m.contexts)-1 {
+			m.contextIndex++
+		}
+
+	case "enter":
+		m.options.Context = m.contexts[m.contextIndex]
+		m.currentView = m.options.StartView
+		m.beginLoad(fmt.Sprintf("Connecting to context %s...", m.options.Context))
+		return m, m.loadCmd(initK8sClient(m.options))
+	}
+
+	return m, nil
+}
+
+// openEvents switches to the events view for a resource
+func (m Model) openEvents(kind resources.ResourceKind, namespace, name string) (tea.Model, tea.Cmd) {
+	ctx := m.beginLoad(fmt.Sprintf("Fetching events for %s...", name))
+	m.navigate(resources.EventsView)
+	m.eventsKind = kind
+	m.eventsNamespace = namespace
+	m.eventsName = name
+	m.events = nil
+
+	return m, m.loadCmd(getEvents(ctx, m.client, kind, namespace, name))
+}
+
+// openLogs switches to the log view for a container of the selected pod
+func (m Model) openLogs(container string) (tea.Model, tea.Cmd) {
+	selectedPod := m.resourceData.Pods[m.selectedItem]
+	return m.openPodLogs(selectedPod.Namespace, selectedPod.Name, container)
+}
+
+// openPodLogs switches to the log view for a container of a pod
+func (m Model) openPodLogs(namespace, pod, container string) (tea.Model, tea.Cmd) {
+	ctx := m.beginLoad(fmt.Sprintf("Fetching logs for %s...", pod))
+	m.navigate(resources.LogView)
+	m.logNamespace = namespace
+	m.logPod = pod
+	m.logContainer = container
+	m.logPrevious = false
+	m.logMerged = false
+	m.logRange = resources.LogRange{TailLines: m.options.LogTailLines, Since: m.options.LogSince}
+	m.logFilter = ""
+	m.stopLogFollow()
+
+	return m, m.loadCmd(m.logsCmd(ctx))
+}
+
+// confirmDeletePods asks before deleting the marked pods, or the highlighted
+// one when none are marked. On protected contexts the pod name, or the number
+// of pods, has to be typed.
+func (m Model) confirmDeletePods() (tea.Model, tea.Cmd) {
+	title := fmt.Sprintf("Delete pod %s?", m.resourceData.Pods[m.selectedItem].Name)
+	body := ""
+	if len(m.marked) > 0 {
+		title = fmt.Sprintf("Delete %d pods?", len(m.marked))
+		body = strings.Join(m.markedPods(), ", ")
+	}
+
+	if !m.protected() {
+		modal := ui.NewConfirmModal(title, body)
+		modal.Danger = true
+		return m.openModal(modal, func(m Model, _ string) (tea.Model, tea.Cmd) {
+			return m.deletePods()
+		})
+	}
+
+	instruction := fmt.Sprintf("Protected context %s: type the pod name to delete it.", m.context)
+	if len(m.marked) > 0 {
+		instruction = fmt.Sprintf("Protected context %s: type %d to delete them.", m.context, len(m.marked))
+	}
+	if body != "" {
+		body += "\n\n"
+	}
+	modal := ui.NewPromptModal(title, body+instruction, m.deleteConfirmation())
+	modal.Danger = true
+	return m.openModal(modal, func(m Model, value string) (tea.Model, tea.Cmd) {
+		if value != m.deleteConfirmation() {
+			return m.setStatus(ui.WarningStyle.Render("Confirmation does not match, nothing deleted"))
+		}
+		return m.deletePods()
+	})
+}
+
+// deletePods deletes the marked pods, or the highlighted one when none are
+// marked
+func (m Model) deletePods() (tea.Model, tea.Cmd) {
+	if len(m.marked) > 0 {
+		return m.deleteMarkedPods()
+	}
+	return m.deleteSelectedPod()
+}
+
+// deleteSelectedPod deletes the highlighted pod
+func (m Model) deleteSelectedPod() (tea.Model, tea.Cmd) {
+	selectedPod := m.resourceData.Pods[m.selectedItem]
+	ctx := m.beginLoad(fmt.Sprintf("Deleting pod %s...", selectedPod.Name))
+	m.loadMutates = true
+	return m, m.loadCmd(deletePod(ctx, m.client, selectedPod.Namespace, selectedPod.Name))
+}
+
+// deleteMarkedPods deletes all marked pods
+func (m Model) deleteMarkedPods() (tea.Model, tea.Cmd) {
+	names := m.markedPods()
+	ctx := m.beginLoad(fmt.Sprintf("Deleting %d pods...", len(names)))
+	m.loadMutates = true
+	return m, m.loadCmd(deletePods(ctx, m.client, m.currentNS, names))
+}
+
+// retryDeletePods returns the action deleting again the pods an admission
+// webhook or policy denied deleting
+func retryDeletePods(names []string) func(m Model, _ string) (tea.Model, tea.Cmd) {
+	return func(m Model, _ string) (tea.Model, tea.Cmd) {
+		if len(names) == 1 {
+			ctx := m.beginLoad(fmt.Sprintf("Deleting pod %s...", names[0]))
+			m.loadMutates = true
+			return m, m.loadCmd(deletePod(ctx, m.client, m.currentNS, names[0]))
+		}
+		ctx := m.beginLoad(fmt.Sprintf("Deleting %d pods...", len(names)))
+		m.loadMutates = true
+		return m, m.loadCmd(deletePods(ctx, m.client, m.currentNS, names))
+	}
+}
+
+// markedPods returns the names of the marked pods, sorted
+func (m Model) markedPods() []string {
+	names := make([]string, 0, len(m.marked))
+	for name := range m.marked {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// deleteConfirmation is what must be typed to delete on a protected context:
+// the pod name, or the number of pods for a bulk deletion
+func (m Model) deleteConfirmation() string {
+	if len(m.marked) > 0 {
+		return fmt.Sprintf("%d", len(m.marked))
+	}
+	return m.resourceData.Pods[m.selectedItem].Name
+}
+
+// quit exits the program, asking for confirmation first when that would
+// interrupt operations in progress
+func (m Model) quit() (tea.Model, tea.Cmd) {
+	if m.operationsInProgress() {
+		m.modal = &activeModal{
+			modal: ui.NewConfirmModal("Quit?", "Operations in progress will be interrupted."),
+			onConfirm: func(m Model, _ string) (tea.Model, tea.Cmd) {
+				return m, tea.Quit
+			},
+			quitOnCtrlC: true,
+		}
+		return m, nil
+	}
+	return m, tea.Quit
+}
+
+// operationsInProgress reports whether quitting now would interrupt work that
+// shouldn't be dropped silently: a change being made, or logs, a detail or
+// events being followed
+func (m Model) operationsInProgress() bool {
+	return m.loading && m.loadMutates || m.logFollow || m.detailFollow || m.eventStreamCancel != nil
+}
+
+// filterLists moves the pods of completed jobs, and the healthy pods and
+// services while only unhealthy ones are shown, out of the lists. Items
+// hidden before are put back in order first, so it applies the current
+// settings whatever they were.
+func (m *Model) filterLists() {
+	all := slices.Concat(m.resourceData.Pods, m.completedJobPods, m.healthyPods)
+	if len(all) > len(m.resourceData.Pods) {
+		if err := resources.SortBy(all, resources.SortNamespace, "", true); err != nil {
+			m.sortFailed(err)
+		}
+	}
+	m.completedJobPods, m.healthyPods = nil, nil
+
+	pods := make([]resources.PodInfo, 0, len(all))
+	for _, pod := range all {
+		switch {
+		case pod.IsCompletedJob() && !m.showCompletedJobs:
+			m.completedJobPods = append(m.completedJobPods, pod)
+		case m.onlyUnhealthy && resources.IsPodHealthy(pod):
+			m.healthyPods = append(m.healthyPods, pod)
+		default:
+			pods = append(pods, pod)
+		}
+	}
+	m.resourceData.Pods = pods
+
+	services := slices.Concat(m.resourceData.Services, m.healthyServices)
+	m.healthyServices = nil
+	m.resourceData.Services = make([]resources.ServiceInfo, 0, len(services))
+	for _, svc := range services {
+		if m.onlyUnhealthy && resources.IsServiceHealthy(svc) {
+			m.healthyServices = append(m.healthyServices, svc)
+		} else {
+			m.resourceData.Services = append(m.resourceData.Services, svc)
+		}
+	}
+	m.sortServices()
+}
+
+// sortServices orders the service list according to the active sort mode
+func (m *Model) sortServices() {
+	sortServices := resources.SortServicesByName
+	if m.servicesByType {
+		sortServices = resources.SortServicesByType
+	}
+	if err := sortServices(m.resourceData.Services); err != nil {
+		m.sortFailed(err)
+	}
+}
+
+// sortFailed says a list could not be sorted, it is left in the order it
+// was in
+func (m *Model) sortFailed(err error) {
+	m.status = ui.ErrorStyle.Render(fmt.Sprintf("Error sorting: %v", err))
+	m.statusID++
+}
+
+// copyValue returns the text copied by the copy action in the current view
+func (m Model) copyValue() string {
+	switch m.currentView {
+	case resources.PodView:
+		if len(m.resourceData.Pods) > 0 {
+			return m.resourceData.Pods[m.selectedItem].Name
+		}
+	case resources.ServiceView:
+		if len(m.resourceData.Services) > 0 {
+			return m.resourceData.Services[m.selectedItem].Name
+		}
+	case resources.SecretView:
+		if len(m.resourceData.Secrets) > 0 {
+			return m.resourceData.Secrets[m.selectedItem].Name
+		}
+	case resources.NamespaceView:
+		if len(m.namespaces) > 0 {
+			return m.namespaces[m.selectedItem].Name
+		}
+	case resources.CustomTypeView:
+		if len(m.customTypes) > 0 {
+			return m.customTypes[m.selectedItem].Name
+		}
+	case resources.CustomResourceView:
+		if len(m.customResources) > 0 {
+			return m.customResources[m.selectedItem].Name
+		}
+	case resources.ServiceAccountView:
+		if len(m.serviceAccounts) > 0 {
+			return m.serviceAccounts[m.selectedItem].Name
+		}
+	case resources.RoleView:
+		if len(m.roles) > 0 {
+			return m.roles[m.selectedItem].Name
+		}
+	case resources.RoleBindingView:
+		if len(m.roleBindings) > 0 {
+			return m.roleBindings[m.selectedItem].Name
+		}
+	case resources.WhoCanView:
+		if len(m.whoCanSubjects) > 0 {
+			return m.whoCanSubjects[m.selectedItem].Name
+		}
+	case resources.RouteView:
+		if len(m.routes) > 0 {
+			return m.routes[m.selectedItem].Name
+		}
+	case resources.EnvView:
+		if rows := m.envRows(); len(rows) > 0 {
+			return rows[m.selectedItem].Value
+		}
+	case resources.DetailView:
+		if field, ok := m.inspectedField(); ok {
+			return field.Value
+		}
+		return m.detailContent
+	}
+	return ""
+}
+
+// discardEdit removes the buffer of an in-progress edit
+func (m *Model) discardEdit() {
+	if m.editPath != "" {
+		os.Remove(m.editPath)
+	}
+	m.editPath = ""
+	m.editOriginal = ""
+}
+
+// setStatus shows a status line that clears itself after a few seconds
+func (m Model) setStatus(status string) (tea.Model, tea.Cmd) {
+	m.status = status
+	m.statusID++
+	return m, clearStatusAfter(m.statusID, statusTimeout)
+}
+
+// can reports whether an action is permitted in the current namespace.
+// Actions are allowed until a permission check says otherwise.
+func (m Model) can(verb, resource string) bool {
+	// Read-only mode refuses every change, whatever RBAC allows
+	if m.options.ReadOnly && !readVerbs[verb] {
+		return false
+	}
+	allowed, ok := m.permissions[verb+"/"+resource]
+	return !ok || allowed
+}
+
+// updateFilterInput handles key presses while the filter input is focused
+func (m Model) updateFilterInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+
+	case "esc":
+		// Escape discards the filter entirely
+		m.filterInput.Blur()
+		m.logFilter = ""
+		m.refreshLogViewport()
+		return m, nil
+
+	case "enter":
+		m.filterInput.Blur()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.filterInput, cmd = m.filterInput.Update(msg)
+
+	// Filter as the user types
+	m.logFilter = m.filterInput.Value()
+	m.refreshLogViewport()
+	m.logViewport.GotoTop()
+
+	return m, cmd
+}
+
+// updateFieldInput handles key presses while the field selector input is focused
+func (m Model) updateFieldInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+
+	case "esc":
+		m.fieldInput.Blur()
+		return m, nil
+
+	case "enter":
+		selector := strings.TrimSpace(m.fieldInput.Value())
+		if selector != "" {
+			if err := resources.ValidateFieldSelector(selector); err != nil {
+				return m.setStatus(ui.ErrorStyle.Render(err.Error()))
+			}
+		}
+		m.fieldInput.Blur()
+
+		m.fieldSelector = selector
+		m.selectedItem = 0
+		ctx := m.beginLoad("Fetching resources...")
+		return m, m.loadCmd(getResources(ctx, m.client, m.currentNS, m.fieldSelector, m.workload))
+	}
+
+	var cmd tea.Cmd
+	m.fieldInput, cmd = m.fieldInput.Update(msg)
+	return m, cmd
+}
+
+// updateNamespaceInput handles keys while a namespace name is being typed
+func (m Model) updateNamespaceInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+
+	case "esc":
+		m.nsInput.Blur()
+		return m, nil
+
+	case "enter":
+		name := strings.TrimSpace(m.nsInput.Value())
+		for _, ns := range m.namespaces {
+			if ns.Name == name {
+				m.nsInput.Blur()
+				return m.switchNamespace(name)
+			}
+		}
+		return m.setStatus(ui.ErrorStyle.Render(fmt.Sprintf("no such namespace: %s", name)))
+	}
+
+	var cmd tea.Cmd
+	m.nsInput, cmd = m.nsInput.Update(msg)
+	return m, cmd
+}
+
+// beginDetail starts loading the detail of a resource and switches to the
+// detail view. custom is true for custom resources of m.customType.
+func (m *Model) beginDetail(kind resources.ResourceKind, namespace, name string, custom bool) context.Context {
+	ctx := m.beginLoad(fmt.Sprintf("Fetching %s details...", strings.ToLower(string(kind))))
+	m.navigate(resources.DetailView)
+	m.detailKind = kind
+	m.detailNamespace = namespace
+	m.detailName = name
+	m.detailCustom = custom
+	m.detailQuery = nil
+	m.detailEnv = resources.EnvSources
+	m.detailInspect = false
+	m.stopFollow()
+	m.detailViewport.GotoTop()
+	m.rememberRecent()
+	return ctx
+}
+
+// detailCmd fetches the detail of the resource shown in the detail view
+func (m Model) detailCmd(ctx context.Context) tea.Cmd {
+	if m.detailQuery != nil {
+		return getRaw(ctx, m.client, m.detailNamespace, *m.detailQuery, m.options.HideSecrets)
+	}
+	if m.detailCustom {
+		return getCustomResourceDetail(ctx, m.client, m.customType, m.detailNamespace, m.detailName)
+	}
+
+	switch m.detailKind {
+	case resources.KindService:
+		return getServiceDetail(ctx, m.client, m.detailNamespace, m.detailName)
+	case resources.KindSecret:
+		return getSecretDetail(ctx, m.client, m.detailNamespace, m.detailName, !m.options.HideSecrets)
+	case resources.KindRoute:
+		return getRouteDetail(ctx, m.client, m.detailNamespace, m.detailName)
+	case resources.KindServiceAccount, resources.KindRole, resources.KindClusterRole,
+		resources.KindRoleBinding, resources.KindClusterRoleBinding:
+		return getRBACDetail(ctx, m.client, m.detailKind, m.detailNamespace, m.detailName)
+	default:
+		return getPodDetail(ctx, m.client, m.detailNamespace, m.detailName, m.detailEnv)
+	}
+}
+
+// detailBody renders the scrollable content of the detail view and returns
+// the row of the inspected field, -1 when not inspecting
+func (m Model) detailBody() (string, int) {
+	usage := ""
+	if m.detailKind == resources.KindPod && !m.detailCustom {
+		if h := m.usage[podKey(m.detailNamespace, m.detailName)]; h != nil {
+			usage = ui.RenderUsage(h.cpu, h.mem)
+		} else {
+			usage = ui.StatusStyle.Render("No metrics available (is metrics-server installed?)")
+		}
+	}
+	selected := -1
+	if field, ok := m.inspectedField(); ok {
+		selected = field.Line
+	}
+	return m.renderDetailBody(usage, selected)
+}
+
+// stopFollow ends follow mode, cancelling a refresh in flight
+func (m *Model) stopFollow() {
+	if m.detailFollowCancel != nil {
+		m.detailFollowCancel()
+		m.detailFollowCancel = nil
+	}
+	m.detailFollow = false
+	m.detailFollowGen++
+}
+
+// showSecrets switches to the secrets list, fetching it on the way
+func (m Model) showSecrets() (tea.Model, tea.Cmd) {
+	ctx := m.beginLoad("Fetching secrets...")
+	m.navigate(resources.SecretView)
+	m.selectedItem = 0
+	return m, m.loadCmd(getSecrets(ctx, m.client, m.currentNS))
+}
+
+// showNamespaces opens the namespace picker on the current namespace
+func (m Model) showNamespaces() (tea.Model, tea.Cmd) {
+	m.navigate(resources.NamespaceView)
+	// Find current namespace in list
+	for i, ns := range m.namespaces {
+		if ns.Name == m.currentNS {
+			m.selectedItem = i
+			break
+		}
+	}
+	return m, m.refreshNamespacePodCounts()
+}
+
+// showCustomTypes switches to the list of custom resource types
+func (m Model) showCustomTypes() (tea.Model, tea.Cmd) {
+	ctx := m.beginLoad("Fetching custom resource types...")
+	m.navigate(resources.CustomTypeView)
+	m.selectedItem = 0
+	return m, m.loadCmd(getCustomResourceTypes(ctx, m.client))
+}
+
+// showTop switches to the resource usage of the pods in the current namespace
+func (m Model) showTop() (tea.Model, tea.Cmd) {
+	ctx := m.beginLoad("Fetching resource usage...")
+	m.navigate(resources.TopView)
+	m.selectedItem = 0
+	m.topMetrics = nil
+	return m, m.loadCmd(getTopMetrics(ctx, m.client, m.currentNS))
+}
+
+// showCustomResources switches to the instances of a custom resource type
+func (m Model) showCustomResources(crType resources.CustomResourceType) (tea.Model, tea.Cmd) {
+	ctx := m.beginLoad(fmt.Sprintf("Fetching %s...", crType.Name))
+	m.customType = crType
+	m.customResources = nil
+	m.navigate(resources.CustomResourceView)
+	m.selectedItem = 0
+	return m, m.loadCmd(getCustomResources(ctx, m.client, m.customType, m.currentNS))
+}
+
+// switchNamespace makes name the current namespace and reloads its pods
+func (m Model) switchNamespace(name string) (tea.Model, tea.Cmd) {
+	m.setNamespace(name)
+	ctx := m.beginLoad(fmt.Sprintf("Switching to namespace: %s", m.currentNS))
+	m.navigate(resources.PodView)
+	m.selectedItem = 0
+	return m, tea.Batch(
+		m.loadCmd(getResources(ctx, m.client, m.currentNS, m.fieldSelector, m.workload)),
+		getPermissions(m.ctx, m.client, m.currentNS),
+	)
+}
+
+// setNamespace makes name the current namespace, forgetting what was
+// fetched for the previous one
+func (m *Model) setNamespace(name string) {
+	m.currentNS = name
+	m.usage = nil
+	m.marked = nil
+	m.detailCache = nil
+	m.resourceData.Secrets = nil
+	m.serviceAccounts, m.roles, m.roleBindings = nil, nil, nil
+	m.dashboard = resources.DashboardInfo{}
+	m.routes = nil
+	m.workload = nil
+	m.forbidden = nil
+	m.fetchedAt = nil
+}
+
+// setForbidden records whether the server refused to list a kind of
+// resource in the current namespace
+func (m *Model) setForbidden(kind string, denied bool) {
+	if m.forbidden[kind] == denied {
+		return
+	}
+	// A new map, older copies of the model keep theirs
+	forbidden := make(map[string]bool, len(m.forbidden)+1)
+	for k, v := range m.forbidden {
+		forbidden[k] = v
+	}
+	forbidden[kind] = denied
+	m.forbidden = forbidden
+}
+
+// fieldSelectorSuggestions returns common pod field selectors for completion
+func (m Model) fieldSelectorSuggestions() []string {
+	suggestions := []string{
+		"status.phase=Pending",
+		"status.phase=Running",
+		"status.phase=Failed",
+		"status.phase=Succeeded",
+		"status.phase!=Running",
+	}
 
-	case podDetailMsg:
-		m.loading = false
-		if msg.err != nil {
-			m.error = fmt.Sprintf("Error fetching pod details: %v", msg.err)
-			return m, nil
+	// Offer the node of the highlighted pod
+	if len(m.resourceData.Pods) > 0 {
+		if node := m.resourceData.Pods[m.selectedItem].Node; node != "" {
+			suggestions = append([]string{"spec.nodeName=" + node}, suggestions...)
 		}
-		m.detailContent = msg.detail
-		return m, nil
+	}
 
-	case serviceDetailMsg:
-		m.loading = false
-		if msg.err != nil {
-			m.error = fmt.Sprintf("Error fetching service details: %v", msg.err)
-			return m, nil
-		}
-		m.detailContent = msg.detail
-		return m, nil
+	return suggestions
+}
+
+// refreshLogViewport sets the log viewport content, applying the active filter
+func (m *Model) refreshLogViewport() {
+	if m.logFilter == "" {
+		m.logViewport.SetContent(m.logContent)
+		return
 	}
 
-	m.spinner, cmd = m.spinner.Update(msg)
-	return m, cmd
+	m.logFiltered, m.logMatches = ui.FilterLines(m.logContent, ui.CompileFilter(m.logFilter))
+	m.showFilteredLogs()
 }
 
-// View renders the current view
-func (m Model) View() string {
-	if m.loading {
-		return ui.RenderLoadingView(m.spinner.View(), m.message)
+// showFilteredLogs shows the log lines matching the filter
+func (m *Model) showFilteredLogs() {
+	if m.logMatches == 0 {
+		m.logViewport.SetContent(ui.StatusStyle.Render("no matching lines"))
+		return
+	}
+	m.logViewport.SetContent(m.logFiltered)
+}
+
+// beginLoad cancels any in-flight load and starts a new one showing message.
+// It returns the context the requests of the load must use. Call it before
+// switching views so cancelling returns to the view the load started from.
+func (m *Model) beginLoad(message string) context.Context {
+	if m.loadCancel != nil {
+		m.loadCancel()
+	}
+	m.loadCtx, m.loadCancel = context.WithCancel(m.ctx)
+	m.loadGen++
+	m.loadReturn = m.currentView
+	m.loadNavStack = m.navStack
+	m.loadSlow = false
+	m.loadMutates = false
+	m.loading = true
+	m.message = message
+	return m.loadCtx
+}
+
+// loadCmd runs cmd as the current load alongside the spinner and the
+// watchdog that flags the load as slow
+func (m Model) loadCmd(cmd tea.Cmd) tea.Cmd {
+	return tea.Batch(
+		m.spinner.Tick,
+		m.track(cmd),
+		loadTimeoutAfter(m.loadGen, m.options.LoadTimeout),
+	)
+}
+
+// track tags the result of cmd with the current load generation
+func (m Model) track(cmd tea.Cmd) tea.Cmd {
+	gen := m.loadGen
+	return func() tea.Msg {
+		return loadResultMsg{gen, cmd()}
 	}
+}
 
-	if m.error != "" {
-		return ui.RenderErrorView(m.error)
+// cancelLoad aborts the in-flight load and returns to the view it started from
+func (m Model) cancelLoad() (tea.Model, tea.Cmd) {
+	m.loadCancel()
+	m.loadGen++
+	m.loading = false
+	m.loadSlow = false
+	m.loadMutates = false
+
+	// Nothing to go back to before the first namespaces were fetched
+	if m.client == nil || m.namespaces == nil {
+		m.error = "Connection cancelled"
+		return m, nil
 	}
 
-	// Add context information to title
-	contextInfo := fmt.Sprintf(" (Context: %s)", m.context)
+	m.currentView = m.loadReturn
+	m.navStack = m.loadNavStack
+	return m.setStatus(ui.WarningStyle.Render("Request cancelled"))
+}
 
+// refresh fetches the content of the current view again
+func (m Model) refresh() (tea.Model, tea.Cmd) {
 	switch m.currentView {
-	case resources.PodView:
-		return ui.RenderPodsView(m.resourceData.Pods, m.selectedItem, m.currentNS) + contextInfo
-	case resources.ServiceView:
-		return ui.RenderServicesView(m.resourceData.Services, m.selectedItem, m.currentNS) + contextInfo
-	case resources.DetailView:
-		return ui.RenderPodDetailView(m.detailContent)
-	case resources.NamespaceView:
-		return ui.RenderNamespacesView(m.namespaces, m.selectedItem)
-	default:
-		return "Unknown view"
+	case resources.SecretView:
+		ctx := m.beginLoad("Refreshing secrets...")
+		return m, m.loadCmd(getSecrets(ctx, m.client, m.currentNS))
+	case resources.DiagnosisView:
+		ctx := m.beginLoad(fmt.Sprintf("Diagnosing %s...", m.diagnosisName))
+		return m, m.loadCmd(diagnosePod(ctx, m.client, m.currentNS, m.diagnosisName))
+	case resources.ServiceAccountView:
+		return m.showServiceAccounts()
+	case resources.WhoCanView:
+		if m.whoCanQuery != "" {
+			return m.runWhoCan(m.whoCanQuery)
+		}
+	case resources.RoleView:
+		return m.showRoles()
+	case resources.RoleBindingView:
+		return m.showRoleBindings()
+	case resources.DashboardView:
+		return m.refreshDashboard()
+	case resources.RouteView:
+		return m.showRoutes()
+	case resources.ResourceQuotaView:
+		return m.showQuotas()
+	case resources.FleetView:
+		return m.showFleet()
+	case resources.HistoryView:
+		return m.openRolloutHistory(m.rolloutDeployment)
+	case resources.CertificateView:
+		return m.showCertificates()
+	case resources.EnvView:
+		return m.refreshEnv()
+	case resources.TopView:
+		ctx := m.beginLoad("Refreshing resource usage...")
+		return m, m.loadCmd(getTopMetrics(ctx, m.client, m.currentNS))
+	case resources.CustomTypeView:
+		ctx := m.beginLoad("Refreshing custom resource types...")
+		return m, m.loadCmd(getCustomResourceTypes(ctx, m.client))
+	case resources.CustomResourceView:
+		ctx := m.beginLoad(fmt.Sprintf("Refreshing %s...", m.customType.Name))
+		return m, m.loadCmd(getCustomResources(ctx, m.client, m.customType, m.currentNS))
+	case resources.EventsView:
+		ctx := m.beginLoad("Refreshing events...")
+		return m, m.loadCmd(getEvents(ctx, m.client, m.eventsKind, m.eventsNamespace, m.eventsName))
+	case resources.LogView:
+		ctx := m.beginLoad("Refreshing logs...")
+		return m, m.loadCmd(m.logsCmd(ctx))
+	}
+
+	ctx := m.beginLoad("Refreshing resources...")
+	return m, m.loadCmd(getResources(ctx, m.client, m.currentNS, m.fieldSelector, m.workload))
+}
+
+// logFilterBar returns the filter line shown in the log view header
+func (m Model) logFilterBar() string {
+	if m.filterInput.Focused() {
+		return m.filterInput.View()
 	}
+	if m.logFilter != "" {
+		return ui.StatusStyle.Render(fmt.Sprintf("filter: %s (esc to clear)", m.logFilter))
+	}
+	return ""
 }
 
 // Message types and commands
+type loadResultMsg struct {
+	gen int
+	msg tea.Msg
+}
+
+type loadTimeoutMsg struct {
+	gen int
+}
+
+func loadTimeoutAfter(gen int, d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return loadTimeoutMsg{gen}
+	})
+}
+
 type k8sClientMsg struct {
 	client *client.K8sClient
 	err    error
 }
 
-func initK8sClient() tea.Msg {
-	client, err := client.New()
-	return k8sClientMsg{client, err}
+func initK8sClient(opts Options) tea.Cmd {
+	as := client.Impersonation{User: opts.As, Groups: opts.AsGroups}
+	return func() tea.Msg {
+		if opts.Server != "" {
+			client, err := client.NewFromToken(opts.Server, opts.Token, opts.CACert, opts.Insecure, as)
+			return k8sClientMsg{client, err}
+		}
+		client, err := client.NewWithContext(opts.Kubeconfig, opts.Context, opts.APIServer, as)
+		return k8sClientMsg{client, err}
+	}
 }
 
 type contextInfoMsg struct {
@@ -290,13 +3012,19 @@ func getContextInfo(client *client.K8sClient) tea.Cmd {
 }
 
 type namespacesMsg struct {
-	namespaces []string
+	namespaces []resources.NamespaceInfo
 	err        error
 }
 
-func getNamespaces(client *client.K8sClient) tea.Cmd {
+// getNamespaces lists the namespaces, or the OpenShift projects when
+// projects is true
+func getNamespaces(ctx context.Context, client *client.K8sClient, projects bool) tea.Cmd {
 	return func() tea.Msg {
-		namespaces, err := client.GetNamespaces()
+		if projects {
+			namespaces, err := client.GetProjects(ctx)
+			return namespacesMsg{namespaces, err}
+		}
+		namespaces, err := client.GetNamespaceInfos(ctx)
 		return namespacesMsg{namespaces, err}
 	}
 }
@@ -304,50 +3032,477 @@ func getNamespaces(client *client.K8sClient) tea.Cmd {
 type resourcesMsg struct {
 	data resources.ResourceData
 	err  error
+
+	// failed holds the errors of the resource types that could not be
+	// listed when others could, keyed by type
+	failed map[string]error
 }
 
-func getResources(client *client.K8sClient, namespace string) tea.Cmd {
+// getResources lists pods and services concurrently, only the pods of
+// workload when it is set. A type that fails to list is reported in failed;
+// err is only set when every type failed.
+func getResources(ctx context.Context, client *client.K8sClient, namespace, fieldSelector string, workload *resources.Workload) tea.Cmd {
 	return func() tea.Msg {
 		data := resources.ResourceData{}
 
-		// Get pods
-		pods, err := client.GetPods(namespace)
-		if err != nil {
-			return resourcesMsg{data, err}
-		}
-		data.Pods = pods
+		var podsErr, servicesErr error
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if workload != nil {
+				data.Pods, podsErr = client.GetWorkloadPods(ctx, namespace, fieldSelector, *workload)
+			} else {
+				data.Pods, podsErr = client.GetPods(ctx, namespace, fieldSelector)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			data.Services, servicesErr = client.GetServices(ctx, namespace)
+		}()
+		wg.Wait()
 
-		// Get services
-		services, err := client.GetServices(namespace)
-		if err != nil {
-			return resourcesMsg{data, err}
+		// Being refused both still lists the namespace, saying so in place
+		// of the lists
+		if podsErr != nil && servicesErr != nil && !resources.IsForbidden(podsErr) && !resources.IsForbidden(servicesErr) {
+			return resourcesMsg{data, podsErr, nil}
 		}
-		data.Services = services
 
-		return resourcesMsg{data, nil}
+		failed := make(map[string]error)
+		if podsErr != nil {
+			failed["pods"] = podsErr
+		}
+		if servicesErr != nil {
+			failed["services"] = servicesErr
+		}
+		return resourcesMsg{data, nil, failed}
 	}
 }
 
 type podDetailMsg struct {
-	detail string
+	detail resources.Detail
 	err    error
 }
 
-func getPodDetail(client *client.K8sClient, namespace, name string) tea.Cmd {
+func getPodDetail(ctx context.Context, client *client.K8sClient, namespace, name string, env resources.EnvMode) tea.Cmd {
 	return func() tea.Msg {
-		detail, err := client.GetPodDetail(namespace, name)
+		detail, err := client.GetPodDetail(ctx, namespace, name, env)
 		return podDetailMsg{detail, err}
 	}
 }
 
 type serviceDetailMsg struct {
-	detail string
+	detail resources.Detail
 	err    error
 }
 
-func getServiceDetail(client *client.K8sClient, namespace, name string) tea.Cmd {
+func getServiceDetail(ctx context.Context, client *client.K8sClient, namespace, name string) tea.Cmd {
 	return func() tea.Msg {
-		detail, err := client.GetServiceDetail(namespace, name)
+		detail, err := client.GetServiceDetail(ctx, namespace, name)
 		return serviceDetailMsg{detail, err}
 	}
 }
+
+// logsCmd fetches the logs shown in the log view
+func (m Model) logsCmd(ctx context.Context) tea.Cmd {
+	if m.logMerged {
+		return getMergedLogs(ctx, m.client, m.logNamespace, m.logPod, m.logRange)
+	}
+	return getPodLogs(ctx, m.client, m.logNamespace, m.logPod, m.logContainer, m.logPrevious, m.logRange)
+}
+
+type podLogsMsg struct {
+	logs string
+	err  error
+}
+
+func getPodLogs(ctx context.Context, client *client.K8sClient, namespace, name, container string, previous bool, r resources.LogRange) tea.Cmd {
+	return func() tea.Msg {
+		logs, err := client.GetPodLogs(ctx, namespace, name, container, previous, r)
+		return podLogsMsg{logs, err}
+	}
+}
+
+// getMergedLogs fetches the logs of all containers of a pod, rendered with
+// their container names
+func getMergedLogs(ctx context.Context, client *client.K8sClient, namespace, name string, r resources.LogRange) tea.Cmd {
+	return func() tea.Msg {
+		lines, err := client.GetPodLogsAllContainers(ctx, namespace, name, r)
+		if err != nil {
+			return podLogsMsg{err: err}
+		}
+		return podLogsMsg{logs: ui.RenderMergedLogs(lines)}
+	}
+}
+
+type permissionsMsg struct {
+	namespace string
+	allowed   map[string]bool
+}
+
+// checkedActions lists the verb/resource pairs checked before enabling actions
+var checkedActions = [][2]string{
+	{"delete", "pods"},
+	{"patch", "deployments"},
+	{"create", "jobs"},
+}
+
+// readVerbs are the verbs still allowed in read-only mode
+var readVerbs = map[string]bool{
+	"get":   true,
+	"list":  true,
+	"watch": true,
+}
+
+func getPermissions(ctx context.Context, client *client.K8sClient, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		allowed := make(map[string]bool)
+		for _, action := range checkedActions {
+			ok, err := client.CanI(ctx, action[0], action[1], namespace)
+			if err != nil {
+				// Leave the action enabled and let the API server decide
+				continue
+			}
+			allowed[action[0]+"/"+action[1]] = ok
+		}
+		return permissionsMsg{namespace, allowed}
+	}
+}
+
+type podDeletedMsg struct {
+	name string
+	err  error
+}
+
+func deletePod(ctx context.Context, client *client.K8sClient, namespace, name string) tea.Cmd {
+	return func() tea.Msg {
+		err := client.DeletePod(ctx, namespace, name)
+		return podDeletedMsg{name, err}
+	}
+}
+
+type podsDeletedMsg struct {
+	count  int
+	failed map[string]error
+}
+
+func deletePods(ctx context.Context, client *client.K8sClient, namespace string, names []string) tea.Cmd {
+	return func() tea.Msg {
+		failed := client.DeletePods(ctx, namespace, names)
+		return podsDeletedMsg{len(names), failed}
+	}
+}
+
+type logsSavedMsg struct {
+	path string
+	size int64
+	err  error
+}
+
+// saveLogs streams the full logs of a container to ./<pod>-<container>.log
+func saveLogs(ctx context.Context, client *client.K8sClient, namespace, pod, container string, previous bool) tea.Cmd {
+	return func() tea.Msg {
+		path := fmt.Sprintf("%s-%s.log", pod, container)
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+
+		f, err := os.Create(path)
+		if err != nil {
+			return logsSavedMsg{err: fmt.Errorf("error creating %s: %v", path, describeWriteError(err))}
+		}
+
+		size, err := client.StreamPodLogs(ctx, namespace, pod, container, previous, f)
+		if closeErr := f.Close(); err == nil && closeErr != nil {
+			err = closeErr
+		}
+		if err != nil {
+			// Don't leave a truncated file behind
+			os.Remove(path)
+			if errors.Is(err, resources.ErrNoPreviousLogs) {
+				return logsSavedMsg{err: err}
+			}
+			return logsSavedMsg{err: fmt.Errorf("error writing %s: %v", path, describeWriteError(err))}
+		}
+
+		return logsSavedMsg{path, size, nil}
+	}
+}
+
+// saveMergedLogs writes the merged logs shown, without colors, to
+// ./<pod>-all.log
+func saveMergedLogs(pod, content string) tea.Cmd {
+	return func() tea.Msg {
+		path := fmt.Sprintf("%s-all.log", pod)
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+
+		data := ansi.Strip(content) + "\n"
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			return logsSavedMsg{err: fmt.Errorf("error writing %s: %v", path, describeWriteError(err))}
+		}
+		return logsSavedMsg{path, int64(len(data)), nil}
+	}
+}
+
+// describeWriteError explains the common causes of failed file writes
+func describeWriteError(err error) error {
+	switch {
+	case errors.Is(err, fs.ErrPermission):
+		return errors.New("permission denied")
+	case errors.Is(err, syscall.ENOSPC):
+		return errors.New("no space left on device")
+	}
+	return err
+}
+
+type clipboardMsg struct {
+	value string
+	err   error
+}
+
+func copyToClipboard(value string) tea.Cmd {
+	return func() tea.Msg {
+		err := clipboard.WriteAll(value)
+		return clipboardMsg{value, err}
+	}
+}
+
+// statusTimeout is how long a transient status line stays visible
+const statusTimeout = 3 * time.Second
+
+// defaultFollowInterval is how often the detail is re-fetched in follow mode
+// when Options.RefreshInterval is not set
+const defaultFollowInterval = 2 * time.Second
+
+type detailTickMsg struct {
+	gen int
+}
+
+func detailTickAfter(gen int, d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return detailTickMsg{gen}
+	})
+}
+
+// detailRefreshMsg wraps the result of a follow mode refresh
+type detailRefreshMsg struct {
+	gen int
+	msg tea.Msg
+}
+
+type clearStatusMsg struct {
+	id int
+}
+
+func clearStatusAfter(id int, d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return clearStatusMsg{id}
+	})
+}
+
+type podMetricsMsg struct {
+	namespace string
+	name      string
+	gen       int
+	metrics   resources.PodMetricsInfo
+	err       error
+}
+
+func getPodMetrics(ctx context.Context, client *client.K8sClient, namespace, name string, gen int) tea.Cmd {
+	return func() tea.Msg {
+		metrics, err := client.GetPodMetrics(ctx, namespace, name)
+		return podMetricsMsg{namespace, name, gen, metrics, err}
+	}
+}
+
+// metricsInterval is how often pod metrics are sampled in the detail view
+const metricsInterval = 5 * time.Second
+
+type metricsTickMsg struct {
+	gen int
+}
+
+func metricsTickAfter(gen int, d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return metricsTickMsg{gen}
+	})
+}
+
+type editReadyMsg struct {
+	path     string
+	original string
+	err      error
+}
+
+// prepareEdit writes the YAML of a resource to a temporary file for editing
+func prepareEdit(ctx context.Context, client *client.K8sClient, kind resources.ResourceKind, namespace, name string) tea.Cmd {
+	return func() tea.Msg {
+		original, err := client.GetResourceYAML(ctx, kind, namespace, name)
+		if err != nil {
+			return editReadyMsg{err: err}
+		}
+
+		f, err := os.CreateTemp("", fmt.Sprintf("k8s-cli-%s-*.yaml", name))
+		if err != nil {
+			return editReadyMsg{err: fmt.Errorf("error creating temp file: %v", err)}
+		}
+		defer f.Close()
+
+		if _, err := f.WriteString(original); err != nil {
+			os.Remove(f.Name())
+			return editReadyMsg{err: fmt.Errorf("error writing temp file: %v", err)}
+		}
+
+		return editReadyMsg{f.Name(), original, nil}
+	}
+}
+
+type editorClosedMsg struct {
+	err error
+}
+
+// openEditor suspends the program and opens path in $EDITOR
+func openEditor(path string) tea.Cmd {
+	return tea.ExecProcess(editorCommand(path), func(err error) tea.Msg {
+		return editorClosedMsg{err}
+	})
+}
+
+// editorCommand returns the command opening path in $EDITOR, vi when it is
+// not set
+func editorCommand(path string) *exec.Cmd {
+	// Allow editors with arguments, e.g. "code --wait". An EDITOR of only
+	// spaces is as good as none.
+	args := strings.Fields(os.Getenv("EDITOR"))
+	if len(args) == 0 {
+		args = []string{"vi"}
+	}
+	return exec.Command(args[0], append(args[1:], path)...)
+}
+
+type editAppliedMsg struct {
+	changed bool
+	err     error
+}
+
+// applyEdit applies the edited buffer. On failure the error is written as a
+// comment at the top of the buffer, like kubectl edit does.
+func applyEdit(ctx context.Context, client *client.K8sClient, kind resources.ResourceKind, namespace, name, original, path string) tea.Cmd {
+	return func() tea.Msg {
+		edited, err := os.ReadFile(path)
+		if err != nil {
+			return editAppliedMsg{err: fmt.Errorf("error reading edited file: %v", err)}
+		}
+
+		changed, err := client.UpdateFromYAML(ctx, kind, namespace, name, original, string(edited))
+		if err != nil {
+			// Replace any previous error header with the new one
+			lines := strings.Split(string(edited), "\n")
+			for len(lines) > 0 && strings.HasPrefix(lines[0], "# ") {
+				lines = lines[1:]
+			}
+			header := "# " + strings.ReplaceAll(err.Error(), "\n", "\n# ")
+			os.WriteFile(path, []byte(header+"\n"+strings.Join(lines, "\n")), 0o600)
+		}
+
+		return editAppliedMsg{changed, err}
+	}
+}
+
+type eventsMsg struct {
+	events []resources.EventInfo
+	err    error
+}
+
+func getEvents(ctx context.Context, client *client.K8sClient, kind resources.ResourceKind, namespace, name string) tea.Cmd {
+	return func() tea.Msg {
+		events, err := client.GetEvents(ctx, kind, namespace, name)
+		return eventsMsg{events, err}
+	}
+}
+
+type secretsMsg struct {
+	secrets []resources.SecretInfo
+	err     error
+}
+
+func getSecrets(ctx context.Context, client *client.K8sClient, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		secrets, err := client.GetSecrets(ctx, namespace)
+		return secretsMsg{secrets, err}
+	}
+}
+
+type secretDetailMsg struct {
+	detail resources.Detail
+	err    error
+}
+
+func getSecretDetail(ctx context.Context, client *client.K8sClient, namespace, name string, summarize bool) tea.Cmd {
+	return func() tea.Msg {
+		detail, err := client.GetSecretDetail(ctx, namespace, name, summarize)
+		return secretDetailMsg{detail, err}
+	}
+}
+
+type customTypesMsg struct {
+	types []resources.CustomResourceType
+	err   error
+}
+
+func getCustomResourceTypes(ctx context.Context, client *client.K8sClient) tea.Cmd {
+	return func() tea.Msg {
+		types, err := client.GetCustomResourceTypes(ctx)
+		return customTypesMsg{types, err}
+	}
+}
+
+type customResourcesMsg struct {
+	items []resources.CustomResourceInfo
+	err   error
+}
+
+func getCustomResources(ctx context.Context, client *client.K8sClient, crType resources.CustomResourceType, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		items, err := client.GetCustomResources(ctx, crType, namespace)
+		return customResourcesMsg{items, err}
+	}
+}
+
+type customDetailMsg struct {
+	detail string
+	err    error
+}
+
+func getCustomResourceDetail(ctx context.Context, client *client.K8sClient, crType resources.CustomResourceType, namespace, name string) tea.Cmd {
+	return func() tea.Msg {
+		detail, err := client.GetCustomResourceYAML(ctx, crType, namespace, name)
+		return customDetailMsg{detail, err}
+	}
+}
+
+type diagnosisMsg struct {
+	diagnoses []resources.Diagnosis
+	err       error
+}
+
+func diagnosePod(ctx context.Context, client *client.K8sClient, namespace, name string) tea.Cmd {
+	return func() tea.Msg {
+		diagnoses, err := client.DiagnosePod(ctx, namespace, name)
+		return diagnosisMsg{diagnoses, err}
+	}
+}
+
+type topMetricsMsg struct {
+	metrics []resources.PodMetricsInfo
+	err     error
+}
+
+func getTopMetrics(ctx context.Context, client *client.K8sClient, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		metrics, err := client.GetPodMetricsList(ctx, namespace)
+		return topMetricsMsg{metrics, err}
+	}
+}
@@ -0,0 +1,104 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/zvelocity/k8s-cli/internal/resources"
+	"github.com/zvelocity/k8s-cli/internal/ui"
+)
+
+func TestEditorCommand(t *testing.T) {
+	tests := []struct {
+		name   string
+		editor string
+		want   []string
+	}{
+		{"unset", "", []string{"vi", "pod.yaml"}},
+		{"only spaces", "   ", []string{"vi", "pod.yaml"}},
+		{"with arguments", " code  --wait ", []string{"code", "--wait", "pod.yaml"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("EDITOR", tt.editor)
+			if got := editorCommand("pod.yaml").Args; !slices.Equal(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProdBadgeOnEveryView(t *testing.T) {
+	m := New(context.Background(), Options{ProtectedContexts: []*regexp.Regexp{regexp.MustCompile(`^prod-`)}})
+	m.loading = false
+	m.context = "prod-eu"
+	for _, view := range []resources.ViewType{resources.PodView, resources.DetailView, resources.LogView, resources.EventStreamView} {
+		m.currentView = view
+		if !strings.Contains(ansi.Strip(m.View()), "PROD") {
+			t.Errorf("no PROD badge on the %s view", view)
+		}
+	}
+
+	m.context = "staging"
+	if strings.Contains(ansi.Strip(m.View()), "PROD") {
+		t.Error("PROD badge on an unprotected context")
+	}
+}
+
+func TestTrackChangesSkipsFailedTypes(t *testing.T) {
+	m := New(context.Background(), Options{})
+	pods := []resources.PodInfo{{Namespace: "shop", Name: "web-0", Status: "Running"}}
+	services := []resources.ServiceInfo{{Namespace: "shop", Name: "web"}}
+	refused := map[string]error{"services": errors.New("connection reset")}
+
+	m.trackChanges(resources.ResourceData{Pods: pods, Services: services}, nil)
+
+	// Services failing to list are neither removed nor added back
+	pods = append(pods, resources.PodInfo{Namespace: "shop", Name: "web-1", Status: "Pending"})
+	m.trackChanges(resources.ResourceData{Pods: pods}, refused)
+	if len(m.serviceChanges.Removed) > 0 || len(m.serviceChanges.Added) > 0 {
+		t.Errorf("service changes on a failed list: %+v", m.serviceChanges)
+	}
+	if !m.podChanges.Added[ui.ChangeKey("shop", "web-1")] {
+		t.Errorf("pod changes = %+v, want web-1 added", m.podChanges)
+	}
+
+	m.trackChanges(resources.ResourceData{Pods: pods, Services: services}, nil)
+	if len(m.serviceChanges.Removed) > 0 || len(m.serviceChanges.Added) > 0 {
+		t.Errorf("service changes once listed again: %+v", m.serviceChanges)
+	}
+
+	// Nor when the first list already failed
+	m.listSnapshot = nil
+	m.trackChanges(resources.ResourceData{Pods: pods}, refused)
+	m.trackChanges(resources.ResourceData{Pods: pods, Services: services}, nil)
+	if len(m.serviceChanges.Added) > 0 {
+		t.Errorf("service changes after a failed first list: %+v", m.serviceChanges)
+	}
+}
+
+func TestReconnectKeysFollowBindings(t *testing.T) {
+	keys, _ := NewKeyBindings(map[string]string{"refresh": "ctrl+r"})
+	m := New(context.Background(), Options{Keys: keys})
+	m.reconnecting = true
+
+	model, _ := m.updateReconnectKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if got := model.(Model).reconnectAttempt; got != 0 {
+		t.Errorf("r retried with refresh moved, %d attempts", got)
+	}
+	model, _ = m.updateReconnectKeys(tea.KeyMsg{Type: tea.KeyCtrlR})
+	if got := model.(Model).reconnectAttempt; got != 1 {
+		t.Errorf("ctrl+r made %d attempts, want 1", got)
+	}
+	model, _ = m.updateReconnectKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	if model.(Model).reconnecting {
+		t.Error("esc did not give up reconnecting")
+	}
+}
@@ -0,0 +1,149 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zvelocity/k8s-cli/internal/resources"
+	"github.com/zvelocity/k8s-cli/internal/ui"
+)
+
+// navEntry is a view left for a nested one, restored by esc
+type navEntry struct {
+	view     resources.ViewType
+	selected int
+	crumb    string
+}
+
+// isRootView reports whether view is one of the main lists, which start a
+// new navigation trail
+func isRootView(view resources.ViewType) bool {
+	switch view {
+	case resources.PodView, resources.ServiceView, resources.SecretView:
+		return true
+	}
+	return false
+}
+
+// navigate switches to view. The main lists start a new trail, other views
+// nest in the current one so esc comes back to it. Going to a view already
+// on the trail returns to it instead of nesting it twice.
+func (m *Model) navigate(view resources.ViewType) {
+	switch {
+	case isRootView(view):
+		m.navStack = nil
+	case view == m.currentView:
+		// Re-opening the current view, e.g. to refresh it
+	default:
+		if i := slices.IndexFunc(m.navStack, func(e navEntry) bool { return e.view == view }); i >= 0 {
+			m.navStack = m.navStack[:i]
+			break
+		}
+		// The container picker is left for the logs, not returned to
+		if m.currentView != resources.ContainerView {
+			m.navStack = append(slices.Clip(m.navStack), navEntry{m.currentView, m.selectedItem, m.crumb()})
+		}
+	}
+	// A search only applies to the list it was typed in
+	if view != m.currentView {
+		m.listSearch = ""
+	}
+	m.currentView = view
+}
+
+// clampSelection keeps the selection within the current list after it
+// shrank, e.g. when a refresh drops the pods at its bottom
+func (m *Model) clampSelection() {
+	if n, ok := m.listLen(); ok && m.selectedItem >= n {
+		m.selectedItem = max(n-1, 0)
+	}
+}
+
+// back leaves the current view for the one it was opened from. Views opened
+// at startup go back to the pod list, the main lists stay where they are.
+func (m Model) back() (tea.Model, tea.Cmd) {
+	switch m.currentView {
+	case resources.DetailView:
+		m.stopFollow()
+		m.discardEdit()
+	case resources.EventStreamView:
+		m.stopEventStream()
+	case resources.LogView:
+		m.stopLogFollow()
+	}
+	m.listSearch = ""
+
+	if len(m.navStack) == 0 {
+		if !isRootView(m.currentView) {
+			m.currentView = resources.PodView
+			m.selectedItem = 0
+		}
+		return m, nil
+	}
+
+	last := m.navStack[len(m.navStack)-1]
+	m.navStack = m.navStack[:len(m.navStack)-1]
+	m.currentView = last.view
+	m.selectedItem = last.selected
+	m.clampSelection()
+
+	// Resume metrics sampling when returning to a pod's detail
+	if m.currentView == resources.DetailView && m.detailKind == resources.KindPod && !m.detailCustom {
+		m.metricsGen++
+		return m, getPodMetrics(m.ctx, m.client, m.detailNamespace, m.detailName, m.metricsGen)
+	}
+	return m, nil
+}
+
+// crumb names the current view in the breadcrumb trail, by default with the
+// name of its view type
+func (m Model) crumb() string {
+	switch m.currentView {
+	case resources.DetailView:
+		return m.detailName
+	case resources.LogView:
+		if m.logMerged {
+			return "logs"
+		}
+		return fmt.Sprintf("logs (%s)", m.logContainer)
+	case resources.DiagnosisView:
+		return "why pending"
+	case resources.CustomResourceView:
+		return strings.ToLower(m.customType.Kind)
+	case resources.CustomTypeView:
+		return "custom resources"
+	case resources.EventStreamView:
+		return "event stream"
+	case resources.ServiceAccountView:
+		return "service accounts"
+	case resources.RoleBindingView:
+		return "role bindings"
+	case resources.WhoCanView:
+		return "who can"
+	case resources.ResourceQuotaView:
+		return "resource quotas"
+	case resources.HistoryView:
+		return "rollout history"
+	case resources.CertificateView:
+		return "tls certificates"
+	case resources.EnvView:
+		return fmt.Sprintf("env (%s)", m.envContainer)
+	}
+	return string(m.currentView)
+}
+
+// breadcrumb returns the trail of views leading to the current one, empty
+// on the main lists
+func (m Model) breadcrumb() string {
+	if len(m.navStack) == 0 {
+		return ""
+	}
+	crumbs := make([]string, 0, len(m.navStack)+1)
+	for _, e := range m.navStack {
+		crumbs = append(crumbs, e.crumb)
+	}
+	return ui.RenderBreadcrumb(append(crumbs, m.crumb()))
+}
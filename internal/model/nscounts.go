@@ -0,0 +1,41 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zvelocity/k8s-cli/internal/client"
+	"github.com/zvelocity/k8s-cli/internal/resources"
+)
+
+// nsPodCountsTTL is how long the pod counts of the namespace picker are
+// reused before opening it counts them again
+const nsPodCountsTTL = 30 * time.Second
+
+// refreshNamespacePodCounts counts the pods of every namespace in the
+// background, unless the counts are recent or already being fetched
+func (m *Model) refreshNamespacePodCounts() tea.Cmd {
+	if m.client == nil || len(m.namespaces) == 0 || m.nsPodCountsPending || time.Since(m.nsPodCountsAt) < nsPodCountsTTL {
+		return nil
+	}
+	m.nsPodCountsPending = true
+
+	names := make([]string, len(m.namespaces))
+	for i, ns := range m.namespaces {
+		names[i] = ns.Name
+	}
+	return getNamespacePodCounts(m.ctx, m.client, m.context, names)
+}
+
+type nsPodCountsMsg struct {
+	context string
+	counts  map[string]resources.PodCounts
+}
+
+func getNamespacePodCounts(ctx context.Context, client *client.K8sClient, kubeContext string, namespaces []string) tea.Cmd {
+	return func() tea.Msg {
+		return nsPodCountsMsg{kubeContext, client.CountNamespacePods(ctx, namespaces)}
+	}
+}
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zvelocity/k8s-cli/internal/client"
+	"github.com/zvelocity/k8s-cli/internal/resources"
+)
+
+// openShiftAPIs records which OpenShift resources the cluster serves. All
+// are false on vanilla Kubernetes.
+type openShiftAPIs struct {
+	routes   bool
+	projects bool
+}
+
+// showRoutes switches to the OpenShift routes of the current namespace
+func (m Model) showRoutes() (tea.Model, tea.Cmd) {
+	ctx := m.beginLoad("Fetching routes...")
+	m.navigate(resources.RouteView)
+	m.selectedItem = 0
+	m.routes = nil
+	return m, m.loadCmd(getRoutes(ctx, m.client, m.currentNS))
+}
+
+type openShiftMsg struct {
+	apis openShiftAPIs
+}
+
+// detectOpenShift looks the OpenShift resources up through discovery
+func detectOpenShift(client *client.K8sClient) tea.Cmd {
+	return func() tea.Msg {
+		return openShiftMsg{openShiftAPIs{
+			routes:   client.Serves(resources.RouteResource),
+			projects: client.Serves(resources.ProjectResource),
+		}}
+	}
+}
+
+type routesMsg struct {
+	routes []resources.RouteInfo
+	err    error
+}
+
+func getRoutes(ctx context.Context, client *client.K8sClient, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		routes, err := client.GetRoutes(ctx, namespace)
+		return routesMsg{routes, err}
+	}
+}
+
+type routeDetailMsg struct {
+	detail string
+	err    error
+}
+
+func getRouteDetail(ctx context.Context, client *client.K8sClient, namespace, name string) tea.Cmd {
+	return func() tea.Msg {
+		detail, err := client.GetRouteDetail(ctx, namespace, name)
+		return routeDetailMsg{detail, err}
+	}
+}
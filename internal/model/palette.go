@@ -0,0 +1,151 @@
+package model
+
+import (
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zvelocity/k8s-cli/internal/resources"
+	"github.com/zvelocity/k8s-cli/internal/ui"
+)
+
+// paletteEntry is a resource type offered by the command palette
+type paletteEntry struct {
+	title  string
+	action func(m Model) (tea.Model, tea.Cmd)
+}
+
+// paletteEntries returns the resource types the palette can switch to,
+// including the custom resource types fetched so far
+func (m Model) paletteEntries() []paletteEntry {
+	entries := []paletteEntry{
+		{"Pods", func(m Model) (tea.Model, tea.Cmd) {
+			m.navigate(resources.PodView)
+			m.selectedItem = 0
+			return m, nil
+		}},
+		{"Services", func(m Model) (tea.Model, tea.Cmd) {
+			m.navigate(resources.ServiceView)
+			m.selectedItem = 0
+			return m, nil
+		}},
+		{"Secrets", Model.showSecrets},
+		{"Dashboard", Model.showDashboard},
+		{"Namespaces", Model.showNamespaces},
+		{"Resource Usage", Model.showTop},
+		{"Resource Quotas", Model.showQuotas},
+		{"Event Stream", Model.showEventStream},
+		{"Service Accounts", Model.showServiceAccounts},
+		{"Roles", Model.showRoles},
+		{"Role Bindings", Model.showRoleBindings},
+		{"Who Can", func(m Model) (tea.Model, tea.Cmd) {
+			m.navigate(resources.WhoCanView)
+			m.selectedItem = 0
+			return m.openWhoCan()
+		}},
+		{"Custom Resource Definitions", Model.showCustomTypes},
+		{"Recently Opened", Model.showRecent},
+		{"Cluster Fleet", Model.showFleet},
+		{"TLS Certificates", Model.showCertificates},
+	}
+
+	// Routes are only offered where discovery found them
+	if m.openShift.routes {
+		entries = append(entries, paletteEntry{"Routes", Model.showRoutes})
+	}
+
+	for _, t := range m.customTypes {
+		entries = append(entries, paletteEntry{t.Kind + " (" + t.Group + ")", func(m Model) (tea.Model, tea.Cmd) {
+			return m.showCustomResources(t)
+		}})
+	}
+
+	return entries
+}
+
+// paletteMatches returns the entries matching the palette input, best first
+func (m Model) paletteMatches() []paletteEntry {
+	entries := m.paletteEntries()
+	query := m.paletteInput.Value()
+	if raw, ok := m.rawEntry(query); ok {
+		return []paletteEntry{raw}
+	}
+	if query == "" {
+		return entries
+	}
+
+	type scored struct {
+		entry paletteEntry
+		score int
+	}
+	var matches []scored
+	for _, e := range entries {
+		if score, ok := ui.FuzzyMatch(query, e.title); ok {
+			matches = append(matches, scored{e, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	result := make([]paletteEntry, 0, len(matches))
+	for _, s := range matches {
+		result = append(result, s.entry)
+	}
+	return result
+}
+
+// openPalette shows the command palette
+func (m Model) openPalette() (tea.Model, tea.Cmd) {
+	m.paletteInput.SetValue("")
+	m.paletteIndex = 0
+	return m, m.paletteInput.Focus()
+}
+
+// updatePalette handles keys while the command palette is open
+func (m Model) updatePalette(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+
+	case "esc":
+		m.paletteInput.Blur()
+		return m, nil
+
+	case "up", "ctrl+k":
+		if m.paletteIndex > 0 {
+			m.paletteIndex--
+		}
+		return m, nil
+
+	case "down", "ctrl+j":
+		if m.paletteIndex < len(m.paletteMatches())-1 {
+			m.paletteIndex++
+		}
+		return m, nil
+
+	case "enter":
+		matches := m.paletteMatches()
+		if len(matches) == 0 {
+			return m, nil
+		}
+		m.paletteInput.Blur()
+		return matches[m.paletteIndex].action(m)
+	}
+
+	// Typing changes the matches, start again from the best one
+	var cmd tea.Cmd
+	m.paletteInput, cmd = m.paletteInput.Update(msg)
+	m.paletteIndex = 0
+	return m, cmd
+}
+
+// paletteView renders the command palette
+func (m Model) paletteView() string {
+	matches := m.paletteMatches()
+	titles := make([]string, 0, len(matches))
+	for _, e := range matches {
+		titles = append(titles, e.title)
+	}
+	return ui.RenderPalette(m.paletteInput.View(), titles, m.paletteIndex)
+}
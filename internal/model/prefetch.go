@@ -0,0 +1,114 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zvelocity/k8s-cli/internal/client"
+	"github.com/zvelocity/k8s-cli/internal/resources"
+)
+
+// prefetchDelay is how long the selection must rest on a pod before its
+// detail is prefetched, so scrolling through the list doesn't fetch every row
+const prefetchDelay = 300 * time.Millisecond
+
+// cachedDetail is a prefetched pod detail, valid while the pod keeps the
+// resource version it was fetched at
+type cachedDetail struct {
+	resourceVersion string
+	detail          resources.Detail
+}
+
+// schedulePrefetch starts the debounce before prefetching the detail of the
+// selected pod, cancelling the prefetch in flight
+func (m *Model) schedulePrefetch() tea.Cmd {
+	if m.prefetchCancel != nil {
+		m.prefetchCancel()
+		m.prefetchCancel = nil
+	}
+	m.prefetchGen++
+	if m.currentView != resources.PodView || len(m.resourceData.Pods) == 0 {
+		return nil
+	}
+
+	gen := m.prefetchGen
+	return tea.Tick(prefetchDelay, func(time.Time) tea.Msg {
+		return prefetchTickMsg{gen}
+	})
+}
+
+// cachedPodDetail returns the prefetched detail of a pod if it is current
+func (m Model) cachedPodDetail(pod resources.PodInfo) (resources.Detail, bool) {
+	cached, ok := m.detailCache[podKey(pod.Namespace, pod.Name)]
+	if !ok || cached.resourceVersion != pod.ResourceVersion {
+		return resources.Detail{}, false
+	}
+	return cached.detail, true
+}
+
+// pruneDetailCache drops the prefetched details of pods that changed or are
+// gone from the list
+func (m *Model) pruneDetailCache() {
+	current := make(map[string]string, len(m.resourceData.Pods))
+	for _, pod := range m.resourceData.Pods {
+		current[podKey(pod.Namespace, pod.Name)] = pod.ResourceVersion
+	}
+	for key, cached := range m.detailCache {
+		if current[key] != cached.resourceVersion {
+			delete(m.detailCache, key)
+		}
+	}
+}
+
+// updatePrefetch handles the debounce timer and the prefetched details
+func (m Model) updatePrefetch(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case prefetchTickMsg:
+		if msg.gen != m.prefetchGen || m.currentView != resources.PodView || m.selectedItem >= len(m.resourceData.Pods) {
+			return m, nil
+		}
+		pod := m.resourceData.Pods[m.selectedItem]
+		if _, ok := m.cachedPodDetail(pod); ok {
+			return m, nil
+		}
+
+		// Only one prefetch runs at a time, moving the selection cancels it
+		ctx, cancel := context.WithCancel(m.ctx)
+		m.prefetchCancel = cancel
+		return m, prefetchPodDetail(ctx, m.client, pod, msg.gen)
+
+	case prefetchedDetailMsg:
+		if msg.gen == m.prefetchGen {
+			m.prefetchCancel = nil
+		}
+		if msg.err != nil {
+			return m, nil
+		}
+		if m.detailCache == nil {
+			m.detailCache = make(map[string]cachedDetail)
+		}
+		m.detailCache[msg.key] = cachedDetail{msg.resourceVersion, msg.detail}
+	}
+	return m, nil
+}
+
+type prefetchTickMsg struct {
+	gen int
+}
+
+type prefetchedDetailMsg struct {
+	gen             int
+	key             string
+	resourceVersion string
+	detail          resources.Detail
+	err             error
+}
+
+func prefetchPodDetail(ctx context.Context, client *client.K8sClient, pod resources.PodInfo, gen int) tea.Cmd {
+	return func() tea.Msg {
+		detail, err := client.GetPodDetail(ctx, pod.Namespace, pod.Name, resources.EnvSources)
+		return prefetchedDetailMsg{gen, podKey(pod.Namespace, pod.Name), pod.ResourceVersion, detail, err}
+	}
+}
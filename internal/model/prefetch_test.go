@@ -0,0 +1,91 @@
+package model
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/zvelocity/k8s-cli/internal/client"
+	"github.com/zvelocity/k8s-cli/internal/resources"
+)
+
+// apiLatency is the round trip of a request to a remote API server the
+// benchmarks simulate
+const apiLatency = 20 * time.Millisecond
+
+// detailModel returns a model listing a pod of a fake cluster answering
+// every request after apiLatency
+func detailModel(b *testing.B) Model {
+	b.Helper()
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "shop", ResourceVersion: "42"},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name:  "app",
+			Image: "nginx:1.27",
+			EnvFrom: []corev1.EnvFromSource{{
+				ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "web"}},
+			}},
+		}}},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	clientset := fake.NewSimpleClientset(pod, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"},
+		Data:       map[string]string{"MODE": "production"},
+	})
+	clientset.PrependReactor("*", "*", func(k8stesting.Action) (bool, runtime.Object, error) {
+		time.Sleep(apiLatency)
+		return false, nil, nil
+	})
+
+	m := New(context.Background(), Options{Namespace: "shop"})
+	m.client = &client.K8sClient{Clientset: clientset}
+	m.loading = false
+	m.currentView = resources.PodView
+	m.resourceData.Pods = []resources.PodInfo{{Name: "web-0", Namespace: "shop", ResourceVersion: "42"}}
+	return m
+}
+
+// BenchmarkOpenPodDetail measures how long opening the detail of the
+// selected pod takes, fetching it or with it prefetched while the selection
+// rested on the pod
+func BenchmarkOpenPodDetail(b *testing.B) {
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+	b.Run("fetched", func(b *testing.B) {
+		m := detailModel(b)
+		pod := m.resourceData.Pods[0]
+		b.ResetTimer()
+		for range b.N {
+			next, _ := m.Update(enter)
+			if !next.(Model).loading {
+				b.Fatal("detail opened without fetching it")
+			}
+			if _, err := m.client.GetPodDetail(m.ctx, pod.Namespace, pod.Name, resources.EnvSources); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("prefetched", func(b *testing.B) {
+		m := detailModel(b)
+		next, _ := m.Update(prefetchTickMsg{m.prefetchGen})
+		m = next.(Model)
+		// The prefetch runs while the selection rests on the pod
+		next, _ = m.Update(prefetchPodDetail(m.ctx, m.client, m.resourceData.Pods[0], m.prefetchGen)())
+		m = next.(Model)
+		b.ResetTimer()
+		for range b.N {
+			next, _ := m.Update(enter)
+			if opened := next.(Model); opened.loading || opened.detailContent == "" {
+				b.Fatal("prefetched detail not used")
+			}
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ProtectedContextsEnv lists regular expressions of context names, separated
+// by commas, where destructive actions need the resource name typed to confirm
+const ProtectedContextsEnv = "K8S_CLI_PROTECTED_CONTEXTS"
+
+// ParseProtectedContexts compiles a comma separated list of context name
+// patterns. Patterns must match the whole context name.
+func ParseProtectedContexts(value string) ([]*regexp.Regexp, error) {
+	var patterns []*regexp.Regexp
+	for _, p := range strings.Split(value, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+
+		re, err := regexp.Compile("^(?:" + p + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid protected context pattern %q: %v", p, err)
+		}
+		patterns = append(patterns, re)
+	}
+	return patterns, nil
+}
+
+// protected reports whether the current context matches a protected pattern
+func (m Model) protected() bool {
+	for _, re := range m.options.ProtectedContexts {
+		if re.MatchString(m.context) {
+			return true
+		}
+	}
+	return false
+}
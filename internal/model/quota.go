@@ -0,0 +1,31 @@
+package model
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zvelocity/k8s-cli/internal/client"
+	"github.com/zvelocity/k8s-cli/internal/resources"
+)
+
+// showQuotas switches to the usage of the resource quotas of the current
+// namespace
+func (m Model) showQuotas() (tea.Model, tea.Cmd) {
+	ctx := m.beginLoad("Fetching resource quotas...")
+	m.navigate(resources.ResourceQuotaView)
+	m.selectedItem = 0
+	return m, m.loadCmd(getResourceQuotas(ctx, m.client, m.currentNS))
+}
+
+type quotasMsg struct {
+	quotas []resources.ResourceQuotaInfo
+	err    error
+}
+
+func getResourceQuotas(ctx context.Context, client *client.K8sClient, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		quotas, err := client.GetResourceQuotas(ctx, namespace)
+		return quotasMsg{quotas, err}
+	}
+}
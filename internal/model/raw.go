@@ -0,0 +1,75 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zvelocity/k8s-cli/internal/client"
+	"github.com/zvelocity/k8s-cli/internal/resources"
+	"github.com/zvelocity/k8s-cli/internal/ui"
+)
+
+// rawPrefix starts the palette input of a raw query, e.g. "get configmaps"
+const rawPrefix = "get "
+
+// rawEntry offers running the palette input as a raw query once it starts
+// with rawPrefix, for the resources without a view of their own
+func (m Model) rawEntry(input string) (paletteEntry, bool) {
+	if !strings.HasPrefix(input, rawPrefix) {
+		return paletteEntry{}, false
+	}
+	return paletteEntry{"Run: " + strings.TrimSpace(input), func(m Model) (tea.Model, tea.Cmd) {
+		q, err := resources.ParseRawQuery(input)
+		if err != nil {
+			return m.setStatus(ui.ErrorStyle.Render(err.Error()))
+		}
+		return m.openRaw(q)
+	}}, true
+}
+
+// openRaw shows the result of a raw query in the detail view. Namespaced
+// types are read from the current namespace.
+func (m Model) openRaw(q resources.RawQuery) (tea.Model, tea.Cmd) {
+	ctx := m.beginLoad(fmt.Sprintf("Running %s...", q))
+	m.navigate(resources.DetailView)
+	m.detailKind = ""
+	m.detailNamespace = m.currentNS
+	m.detailName = q.Name
+	if q.Name == "" {
+		m.detailName = q.Resource
+	}
+	m.detailCustom = false
+	m.detailQuery = &q
+	m.detailInspect = false
+	m.stopFollow()
+	m.detailViewport.GotoTop()
+	return m, m.loadCmd(getRaw(ctx, m.client, m.currentNS, q, m.options.HideSecrets))
+}
+
+// rawKubectl returns the kubectl command equivalent to a raw query
+func (m Model) rawKubectl(q resources.RawQuery) string {
+	args := []string{"get", q.Resource}
+	if q.Name != "" {
+		args = append(args, q.Name)
+	}
+	output := "yaml"
+	if q.JSON {
+		output = "json"
+	}
+	return kubectlCommand(m.context, m.detailNamespace, append(args, "-o", output)...)
+}
+
+type rawMsg struct {
+	result resources.RawResult
+	err    error
+}
+
+func getRaw(ctx context.Context, client *client.K8sClient, namespace string, q resources.RawQuery, hideSecrets bool) tea.Cmd {
+	return func() tea.Msg {
+		result, err := client.GetRaw(ctx, namespace, q, hideSecrets)
+		return rawMsg{result, err}
+	}
+}
@@ -0,0 +1,166 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zvelocity/k8s-cli/internal/client"
+	"github.com/zvelocity/k8s-cli/internal/resources"
+	"github.com/zvelocity/k8s-cli/internal/ui"
+)
+
+// rbacViews are the views listing RBAC objects, reachable from each other
+var rbacViews = []resources.ViewType{
+	resources.ServiceAccountView,
+	resources.RoleView,
+	resources.RoleBindingView,
+}
+
+// isRBACView reports whether view lists RBAC objects
+func isRBACView(view resources.ViewType) bool {
+	for _, v := range rbacViews {
+		if v == view {
+			return true
+		}
+	}
+	return false
+}
+
+// showServiceAccounts switches to the service accounts list, fetching it on the way
+func (m Model) showServiceAccounts() (tea.Model, tea.Cmd) {
+	ctx := m.beginLoad("Fetching service accounts...")
+	m.navigate(resources.ServiceAccountView)
+	m.selectedItem = 0
+	return m, m.loadCmd(getServiceAccounts(ctx, m.client, m.currentNS))
+}
+
+// showRoles switches to the roles list, fetching it on the way
+func (m Model) showRoles() (tea.Model, tea.Cmd) {
+	ctx := m.beginLoad("Fetching roles...")
+	m.navigate(resources.RoleView)
+	m.selectedItem = 0
+	return m, m.loadCmd(getRoles(ctx, m.client, m.currentNS))
+}
+
+// showRoleBindings switches to the role bindings list, fetching it on the way
+func (m Model) showRoleBindings() (tea.Model, tea.Cmd) {
+	ctx := m.beginLoad("Fetching role bindings...")
+	m.navigate(resources.RoleBindingView)
+	m.selectedItem = 0
+	return m, m.loadCmd(getRoleBindings(ctx, m.client, m.currentNS))
+}
+
+// openWhoCan focuses the "who can" query input, prefilled with the last query
+func (m Model) openWhoCan() (tea.Model, tea.Cmd) {
+	m.whoCanInput.SetValue(m.whoCanQuery)
+	m.whoCanInput.CursorEnd()
+	return m, m.whoCanInput.Focus()
+}
+
+// updateWhoCanInput handles key presses while the "who can" input is focused
+func (m Model) updateWhoCanInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+
+	case "esc":
+		m.whoCanInput.Blur()
+		return m, nil
+
+	case "enter":
+		query := strings.Join(strings.Fields(m.whoCanInput.Value()), " ")
+		if len(strings.Fields(query)) != 2 {
+			return m.setStatus(ui.ErrorStyle.Render("expected a verb and a resource, e.g. delete secrets"))
+		}
+		m.whoCanInput.Blur()
+		return m.runWhoCan(query)
+	}
+
+	var cmd tea.Cmd
+	m.whoCanInput, cmd = m.whoCanInput.Update(msg)
+	return m, cmd
+}
+
+// runWhoCan switches to the "who can" view for a "verb resource" query
+func (m Model) runWhoCan(query string) (tea.Model, tea.Cmd) {
+	verb, resource, _ := strings.Cut(query, " ")
+	ctx := m.beginLoad(fmt.Sprintf("Checking who can %s...", query))
+	m.navigate(resources.WhoCanView)
+	m.selectedItem = 0
+	m.whoCanQuery = query
+	m.whoCanSubjects = nil
+	return m, m.loadCmd(whoCan(ctx, m.client, verb, resource, m.currentNS))
+}
+
+type whoCanMsg struct {
+	subjects []resources.Subject
+	err      error
+}
+
+func whoCan(ctx context.Context, client *client.K8sClient, verb, resource, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		subjects, err := client.WhoCan(ctx, verb, resource, namespace)
+		return whoCanMsg{subjects, err}
+	}
+}
+
+type serviceAccountsMsg struct {
+	accounts []resources.ServiceAccountInfo
+	err      error
+}
+
+func getServiceAccounts(ctx context.Context, client *client.K8sClient, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		accounts, err := client.GetServiceAccounts(ctx, namespace)
+		return serviceAccountsMsg{accounts, err}
+	}
+}
+
+type rolesMsg struct {
+	roles []resources.RoleInfo
+	err   error
+}
+
+func getRoles(ctx context.Context, client *client.K8sClient, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		roles, err := client.GetRoles(ctx, namespace)
+		return rolesMsg{roles, err}
+	}
+}
+
+type roleBindingsMsg struct {
+	bindings []resources.RoleBindingInfo
+	err      error
+}
+
+func getRoleBindings(ctx context.Context, client *client.K8sClient, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		bindings, err := client.GetRoleBindings(ctx, namespace)
+		return roleBindingsMsg{bindings, err}
+	}
+}
+
+type rbacDetailMsg struct {
+	detail string
+	err    error
+}
+
+// getRBACDetail fetches the detail of a service account, role or binding
+func getRBACDetail(ctx context.Context, client *client.K8sClient, kind resources.ResourceKind, namespace, name string) tea.Cmd {
+	return func() tea.Msg {
+		var detail string
+		var err error
+		switch kind {
+		case resources.KindServiceAccount:
+			detail, err = client.GetServiceAccountDetail(ctx, namespace, name)
+		case resources.KindRole, resources.KindClusterRole:
+			detail, err = client.GetRoleDetail(ctx, kind, namespace, name)
+		default:
+			detail, err = client.GetRoleBindingDetail(ctx, kind, namespace, name)
+		}
+		return rbacDetailMsg{detail, err}
+	}
+}
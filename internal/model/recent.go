@@ -0,0 +1,103 @@
+package model
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zvelocity/k8s-cli/internal/resources"
+)
+
+// maxRecent is the number of opened resources the recent view remembers
+const maxRecent = 20
+
+// recentEntry is a resource opened in the detail view, with what is needed
+// to open it again
+type recentEntry struct {
+	resources.RecentResource
+
+	// context is the kubeconfig context the resource was opened in
+	context string
+
+	// customType is the type of a custom resource, nil for built-in kinds
+	customType *resources.CustomResourceType
+}
+
+// rememberRecent puts the resource of the detail view at the top of the
+// recent list, dropping the oldest entry once the list is full
+func (m *Model) rememberRecent() {
+	entry := recentEntry{
+		RecentResource: resources.RecentResource{
+			Kind:      m.detailKind,
+			Namespace: m.detailNamespace,
+			Name:      m.detailName,
+			Opened:    time.Now(),
+		},
+		context: m.context,
+	}
+	if m.detailCustom {
+		t := m.customType
+		entry.customType = &t
+	}
+
+	// A new slice, older copies of the model keep theirs
+	recent := make([]recentEntry, 0, maxRecent)
+	recent = append(recent, entry)
+	for _, e := range m.recent {
+		if len(recent) == maxRecent {
+			break
+		}
+		if e.context != entry.context || e.Kind != entry.Kind || e.Namespace != entry.Namespace || e.Name != entry.Name {
+			recent = append(recent, e)
+		}
+	}
+	m.recent = recent
+}
+
+// recentEntries returns the resources opened in the current context, most
+// recent first
+func (m Model) recentEntries() []recentEntry {
+	var entries []recentEntry
+	for _, e := range m.recent {
+		if e.context == m.context {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// showRecent switches to the list of resources opened recently
+func (m Model) showRecent() (tea.Model, tea.Cmd) {
+	m.navigate(resources.RecentView)
+	m.selectedItem = 0
+	return m, nil
+}
+
+// openRecent opens the detail of a recent resource again, switching to its
+// namespace first when needed. The lists of the namespace load alongside so
+// they are current when leaving the detail.
+func (m Model) openRecent(e recentEntry) (tea.Model, tea.Cmd) {
+	switched := e.Namespace != "" && e.Namespace != m.currentNS
+	if switched {
+		m.setNamespace(e.Namespace)
+	}
+	if e.customType != nil {
+		m.customType = *e.customType
+	}
+
+	ctx := m.beginDetail(e.Kind, e.Namespace, e.Name, e.customType != nil)
+	cmds := []tea.Cmd{m.loadCmd(m.detailCmd(ctx))}
+	if switched {
+		cmds = append(cmds,
+			m.track(getResources(ctx, m.client, m.currentNS, m.fieldSelector, m.workload)),
+			getPermissions(m.ctx, m.client, m.currentNS),
+		)
+	}
+
+	// Sample the pod's usage like when it is opened from the list
+	if e.Kind == resources.KindPod && e.customType == nil {
+		m.metricsGen++
+		cmds = append(cmds, getPodMetrics(m.ctx, m.client, e.Namespace, e.Name, m.metricsGen))
+	}
+	return m, tea.Batch(cmds...)
+}
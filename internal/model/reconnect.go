@@ -0,0 +1,135 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zvelocity/k8s-cli/internal/client"
+	"github.com/zvelocity/k8s-cli/internal/ui"
+)
+
+// Reconnection attempts back off from the first delay, doubling up to the
+// maximum
+const (
+	reconnectFirstDelay = time.Second
+	reconnectMaxDelay   = 30 * time.Second
+)
+
+// probeConnection checks whether the API server still answers after a
+// request failed, starting to reconnect when it doesn't. Errors the server
+// answered with, like not found or forbidden, are left as they are.
+func (m Model) probeConnection() tea.Cmd {
+	if m.client == nil || m.reconnecting {
+		return nil
+	}
+	c := m.client
+	ctx := m.ctx
+	return func() tea.Msg {
+		return connectionProbeMsg{c.Ping(ctx)}
+	}
+}
+
+// updateReconnect handles the connection probe and the reconnection
+// attempts
+func (m Model) updateReconnect(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case connectionProbeMsg:
+		if m.reconnecting || !client.IsConnectionError(msg.err) {
+			return m, nil
+		}
+		m.reconnecting = true
+		m.reconnectAttempt = 0
+		m.reconnectErr = msg.err.Error()
+		return m, m.scheduleReconnect()
+
+	case reconnectTickMsg:
+		if !m.reconnecting || msg.gen != m.reconnectGen {
+			return m, nil
+		}
+		m.reconnectAttempt++
+		return m, reconnect(m.ctx, m.client, m.reconnectGen)
+
+	case reconnectedMsg:
+		if !m.reconnecting || msg.gen != m.reconnectGen {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.reconnectErr = msg.err.Error()
+			return m, m.scheduleReconnect()
+		}
+
+		// Resume where the connection was lost
+		m.client = msg.client
+		m.reconnecting = false
+		m.error = ""
+		model, cmd := m.refresh()
+		model, status := model.(Model).setStatus(ui.SuccessStyle.Render("Reconnected"))
+		return model, tea.Batch(cmd, status)
+	}
+
+	return m, nil
+}
+
+// updateReconnectKeys handles keys while reconnecting: refresh retries at
+// once, esc gives up and shows the error
+func (m Model) updateReconnectKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keys.action(msg.String(), m.currentView) {
+	case actionQuit:
+		return m.quit()
+
+	case actionRefresh:
+		m.reconnectGen++
+		m.reconnectAttempt++
+		m.reconnectRetry = time.Time{}
+		return m, reconnect(m.ctx, m.client, m.reconnectGen)
+
+	case actionBack:
+		m.reconnecting = false
+		m.reconnectGen++
+		if m.error == "" {
+			m.error = "Lost the connection to the API server: " + m.reconnectErr
+		}
+	}
+	return m, nil
+}
+
+// scheduleReconnect waits before the next attempt, longer after each one
+func (m *Model) scheduleReconnect() tea.Cmd {
+	delay := reconnectMaxDelay
+	if m.reconnectAttempt < 5 {
+		delay = min(reconnectFirstDelay<<m.reconnectAttempt, reconnectMaxDelay)
+	}
+	m.reconnectGen++
+	m.reconnectRetry = time.Now().Add(delay)
+
+	gen := m.reconnectGen
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return reconnectTickMsg{gen}
+	})
+}
+
+// connectionProbeMsg carries whether the API server answered after a
+// failed request
+type connectionProbeMsg struct {
+	err error
+}
+
+// reconnectTickMsg triggers a reconnection attempt
+type reconnectTickMsg struct {
+	gen int
+}
+
+type reconnectedMsg struct {
+	gen    int
+	client *client.K8sClient
+	err    error
+}
+
+func reconnect(ctx context.Context, c *client.K8sClient, gen int) tea.Cmd {
+	return func() tea.Msg {
+		nc, err := c.Reconnect(ctx)
+		return reconnectedMsg{gen, nc, err}
+	}
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/zvelocity/k8s-cli/internal/client"
+)
+
+func TestGetResourcesForbiddenPods(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "default"}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+	)
+	clientset.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", nil)
+	})
+
+	ctx := context.Background()
+	k8s := &client.K8sClient{Clientset: clientset}
+	msg := getResources(ctx, k8s, "default", "", nil)()
+
+	res, ok := msg.(resourcesMsg)
+	if !ok {
+		t.Fatalf("got %T, want resourcesMsg", msg)
+	}
+	if res.err != nil {
+		t.Fatalf("err = %v, want the pods in failed only", res.err)
+	}
+
+	m := New(ctx, Options{})
+	m.client = k8s
+	next, _ := m.Update(res)
+	m = next.(Model)
+
+	if !m.forbidden["pods"] {
+		t.Errorf("forbidden[pods] = false, want true")
+	}
+	if m.forbidden["services"] {
+		t.Errorf("forbidden[services] = true, want false")
+	}
+	if len(m.resourceData.Services) != 1 || m.resourceData.Services[0].Name != "web" {
+		t.Errorf("services = %+v, want web", m.resourceData.Services)
+	}
+	if len(m.resourceData.Pods) != 0 {
+		t.Errorf("pods = %+v, want none", m.resourceData.Pods)
+	}
+	if m.error != "" {
+		t.Errorf("error = %q, want the services listed", m.error)
+	}
+}
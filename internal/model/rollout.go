@@ -0,0 +1,181 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zvelocity/k8s-cli/internal/client"
+	"github.com/zvelocity/k8s-cli/internal/resources"
+	"github.com/zvelocity/k8s-cli/internal/ui"
+)
+
+// showRolloutHistory switches to the revisions of a Deployment: the one the
+// pod list is scoped to, or the one controlling the pod of the detail
+func (m Model) showRolloutHistory() (tea.Model, tea.Cmd) {
+	switch {
+	case m.currentView == resources.PodView && m.workload != nil:
+		if m.workload.Kind != "Deployment" {
+			return m.setStatus(ui.WarningStyle.Render(fmt.Sprintf("%s is not a deployment, only deployments have a rollout history", m.workload)))
+		}
+		return m.openRolloutHistory(*m.workload)
+
+	case m.currentView == resources.PodView:
+		return m.setStatus(ui.StatusStyle.Render(fmt.Sprintf("Press %s to show the pods of a deployment first", m.keyHelp(actionWorkload))))
+
+	case m.currentView == resources.DetailView && m.detailKind == resources.KindPod && !m.detailCustom:
+		ctx := m.beginLoad(fmt.Sprintf("Finding the deployment of pod %s...", m.detailName))
+		return m, m.loadCmd(getPodRolloutHistory(ctx, m.client, m.detailNamespace, m.detailName))
+	}
+	return m, nil
+}
+
+// openRolloutHistory switches to the revisions of a Deployment
+func (m Model) openRolloutHistory(deployment resources.Workload) (tea.Model, tea.Cmd) {
+	ctx := m.beginLoad(fmt.Sprintf("Fetching rollout history of %s...", deployment))
+	m.navigate(resources.HistoryView)
+	m.rolloutDeployment = deployment
+	m.revisions = nil
+	m.selectedItem = 0
+	return m, m.loadCmd(getRolloutHistory(ctx, m.client, deployment))
+}
+
+// updateRolloutHistory shows the revisions fetched, switching to the history
+// view when they were looked up from a pod
+func (m Model) updateRolloutHistory(msg rolloutHistoryMsg) (tea.Model, tea.Cmd) {
+	m.loading = false
+	if msg.err != nil {
+		m.error = fmt.Sprintf("Error fetching rollout history: %v", msg.err)
+		return m, nil
+	}
+	if msg.deployment.Kind != "Deployment" {
+		if msg.deployment.Kind == "" {
+			return m.setStatus(ui.WarningStyle.Render("The pod has no owner, it is not part of a deployment"))
+		}
+		return m.setStatus(ui.WarningStyle.Render(fmt.Sprintf("The pod belongs to %s, only deployments have a rollout history", msg.deployment)))
+	}
+
+	if m.currentView != resources.HistoryView {
+		m.navigate(resources.HistoryView)
+		m.selectedItem = 0
+	}
+	m.rolloutDeployment = msg.deployment
+	m.revisions = msg.revisions
+	return m, nil
+}
+
+// confirmRollback asks before rolling the Deployment back to the selected
+// revision, which starts a new rollout. On protected contexts the
+// Deployment's name has to be typed.
+func (m Model) confirmRollback() (tea.Model, tea.Cmd) {
+	if len(m.revisions) == 0 {
+		return m, nil
+	}
+	if !m.can("patch", "deployments") {
+		return m.setStatus(ui.WarningStyle.Render("Not allowed to roll back deployments here"))
+	}
+	r := m.revisions[m.selectedItem]
+	if r.Current {
+		return m.setStatus(ui.StatusStyle.Render(fmt.Sprintf("%s already runs revision %d", m.rolloutDeployment, r.Revision)))
+	}
+
+	deployment := m.rolloutDeployment
+	title := fmt.Sprintf("Roll back %s to revision %d?", deployment, r.Revision)
+	body := fmt.Sprintf("This starts a new rollout of %s.", strings.Join(r.Images, ", "))
+	rollback := func(m Model, _ string) (tea.Model, tea.Cmd) {
+		ctx := m.beginLoad(fmt.Sprintf("Rolling back %s to revision %d...", deployment, r.Revision))
+		m.loadMutates = true
+		return m, m.loadCmd(rollbackDeployment(ctx, m.client, deployment, r.Revision))
+	}
+
+	if !m.protected() {
+		modal := ui.NewConfirmModal(title, body)
+		modal.Danger = true
+		return m.openModal(modal, rollback)
+	}
+
+	instruction := fmt.Sprintf("Protected context %s: type the deployment name to roll it back.", m.context)
+	modal := ui.NewPromptModal(title, body+"\n\n"+instruction, deployment.Name)
+	modal.Danger = true
+	return m.openModal(modal, func(m Model, value string) (tea.Model, tea.Cmd) {
+		if value != deployment.Name {
+			return m.setStatus(ui.WarningStyle.Render("Confirmation does not match, nothing rolled back"))
+		}
+		return rollback(m, value)
+	})
+}
+
+// rolledBack follows the rollout started by a rollback through the pods of
+// the Deployment, which are replaced as it progresses
+func (m Model) rolledBack(msg deploymentRolledBackMsg) (tea.Model, tea.Cmd) {
+	m.loading = false
+	m.loadMutates = false
+	if denial, ok := resources.ParseAdmissionDenial(msg.err); ok {
+		action := fmt.Sprintf("rolling back %s to revision %d", msg.deployment, msg.revision)
+		return m.openModal(ui.NewDenialModal(denial, action, "Try rolling back again?"), func(m Model, _ string) (tea.Model, tea.Cmd) {
+			ctx := m.beginLoad(fmt.Sprintf("Rolling back %s to revision %d...", msg.deployment, msg.revision))
+			m.loadMutates = true
+			return m, m.loadCmd(rollbackDeployment(ctx, m.client, msg.deployment, msg.revision))
+		})
+	}
+	if msg.err != nil {
+		return m.setStatus(ui.ErrorStyle.Render(msg.err.Error()))
+	}
+
+	model, cmd := m.scopeToWorkload(&msg.deployment)
+	m = model.(Model)
+	m.status = ui.SuccessStyle.Render(fmt.Sprintf("Rolled back %s to revision %d, its pods are being replaced", msg.deployment, msg.revision))
+	m.statusID++
+	return m, tea.Batch(cmd, clearStatusAfter(m.statusID, statusTimeout))
+}
+
+// showRevisionPods shows the pods of the ReplicaSet of the selected
+// revision
+func (m Model) showRevisionPods() (tea.Model, tea.Cmd) {
+	if len(m.revisions) == 0 {
+		return m, nil
+	}
+	r := m.revisions[m.selectedItem]
+	return m.scopeToWorkload(&resources.Workload{Kind: "ReplicaSet", Namespace: r.Namespace, Name: r.ReplicaSet})
+}
+
+type rolloutHistoryMsg struct {
+	deployment resources.Workload
+	revisions  []resources.RevisionInfo
+	err        error
+}
+
+type deploymentRolledBackMsg struct {
+	deployment resources.Workload
+	revision   int64
+	err        error
+}
+
+func rollbackDeployment(ctx context.Context, client *client.K8sClient, deployment resources.Workload, revision int64) tea.Cmd {
+	return func() tea.Msg {
+		err := client.RollbackDeployment(ctx, deployment.Namespace, deployment.Name, revision)
+		return deploymentRolledBackMsg{deployment, revision, err}
+	}
+}
+
+func getRolloutHistory(ctx context.Context, client *client.K8sClient, deployment resources.Workload) tea.Cmd {
+	return func() tea.Msg {
+		revisions, err := client.GetRolloutHistory(ctx, deployment.Namespace, deployment.Name)
+		return rolloutHistoryMsg{deployment, revisions, err}
+	}
+}
+
+// getPodRolloutHistory fetches the revisions of the Deployment controlling
+// a pod. The workload found is returned alone when it is not a Deployment.
+func getPodRolloutHistory(ctx context.Context, client *client.K8sClient, namespace, pod string) tea.Cmd {
+	return func() tea.Msg {
+		workload, found, err := client.GetPodWorkload(ctx, namespace, pod)
+		if err != nil || !found || workload.Kind != "Deployment" {
+			return rolloutHistoryMsg{deployment: workload, err: err}
+		}
+		revisions, err := client.GetRolloutHistory(ctx, workload.Namespace, workload.Name)
+		return rolloutHistoryMsg{workload, revisions, err}
+	}
+}
@@ -0,0 +1,142 @@
+package model
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zvelocity/k8s-cli/internal/resources"
+	"github.com/zvelocity/k8s-cli/internal/ui"
+)
+
+// openSearch starts an incremental search of the names of the current list
+func (m Model) openSearch() (tea.Model, tea.Cmd) {
+	m.searchInput.SetValue(m.listSearch)
+	m.searchInput.CursorEnd()
+	return m, m.searchInput.Focus()
+}
+
+// updateSearchInput handles key presses while the search input is focused,
+// selecting the best match as the user types
+func (m Model) updateSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+
+	case "esc":
+		// Escape discards the search entirely
+		m.searchInput.Blur()
+		m.listSearch = ""
+		return m, nil
+
+	case "enter":
+		m.searchInput.Blur()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.searchInput, cmd = m.searchInput.Update(msg)
+	m.listSearch = m.searchInput.Value()
+	m.selectSearchMatch()
+	// The env view only lists the matches, it may have shrunk
+	m.clampSelection()
+
+	return m, cmd
+}
+
+// selectSearchMatch selects the row whose name best matches the search, the
+// first one on ties. The selection stays when nothing matches.
+func (m *Model) selectSearchMatch() {
+	if m.listSearch == "" {
+		return
+	}
+
+	best, bestScore := -1, 0
+	for i, name := range m.listNames() {
+		if score, ok := ui.FuzzyMatch(m.listSearch, name); ok && (best == -1 || score > bestScore) {
+			best, bestScore = i, score
+		}
+	}
+	if best >= 0 {
+		m.selectedItem = best
+	}
+}
+
+// listNames returns the names of the rows of the current list, as shown in
+// its first column
+func (m Model) listNames() []string {
+	var names []string
+	switch m.currentView {
+	case resources.PodView:
+		for _, pod := range m.resourceData.Pods {
+			names = append(names, pod.Name)
+		}
+	case resources.ServiceView:
+		for _, svc := range m.resourceData.Services {
+			names = append(names, svc.Name)
+		}
+	case resources.SecretView:
+		for _, secret := range m.resourceData.Secrets {
+			names = append(names, secret.Name)
+		}
+	case resources.NamespaceView:
+		for _, ns := range m.namespaces {
+			names = append(names, ns.Name)
+		}
+	case resources.CustomTypeView:
+		for _, t := range m.customTypes {
+			names = append(names, t.Name)
+		}
+	case resources.CustomResourceView:
+		for _, item := range m.customResources {
+			names = append(names, item.Name)
+		}
+	case resources.TopView:
+		for _, pm := range m.topMetrics {
+			names = append(names, pm.Name)
+		}
+	case resources.ServiceAccountView:
+		for _, sa := range m.serviceAccounts {
+			names = append(names, sa.Name)
+		}
+	case resources.RoleView:
+		for _, role := range m.roles {
+			names = append(names, role.Name)
+		}
+	case resources.RoleBindingView:
+		for _, b := range m.roleBindings {
+			names = append(names, b.Name)
+		}
+	case resources.WhoCanView:
+		for _, s := range m.whoCanSubjects {
+			name := s.Name
+			if s.Namespace != "" {
+				name = s.Namespace + "/" + s.Name
+			}
+			names = append(names, name)
+		}
+	case resources.RouteView:
+		for _, route := range m.routes {
+			names = append(names, route.Name)
+		}
+	case resources.RecentView:
+		for _, e := range m.recentEntries() {
+			names = append(names, e.Name)
+		}
+	case resources.FleetView:
+		for _, c := range m.fleet {
+			names = append(names, c.Context)
+		}
+	case resources.HistoryView:
+		for _, r := range m.revisions {
+			names = append(names, r.ReplicaSet)
+		}
+	case resources.CertificateView:
+		for _, c := range m.certs {
+			names = append(names, c.Secret)
+		}
+	case resources.EnvView:
+		for _, v := range m.envRows() {
+			names = append(names, v.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,95 @@
+package model
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zvelocity/k8s-cli/internal/client"
+	"github.com/zvelocity/k8s-cli/internal/resources"
+	"github.com/zvelocity/k8s-cli/internal/ui"
+)
+
+// showWorkloadPods pivots to the pods of the workload of the selected pod or
+// custom resource. On a pod list already scoped to a workload it shows all
+// pods again.
+func (m Model) showWorkloadPods() (tea.Model, tea.Cmd) {
+	switch m.currentView {
+	case resources.PodView:
+		if m.workload != nil {
+			return m.scopeToWorkload(nil)
+		}
+		if len(m.resourceData.Pods) == 0 {
+			return m, nil
+		}
+		pod := m.resourceData.Pods[m.selectedItem]
+		return m.findWorkload(pod.Namespace, pod.Name)
+
+	case resources.CustomResourceView:
+		if len(m.customResources) == 0 {
+			return m, nil
+		}
+		item := m.customResources[m.selectedItem]
+		return m.scopeToWorkload(&resources.Workload{Kind: m.customType.Kind, Namespace: item.Namespace, Name: item.Name})
+
+	case resources.DetailView:
+		if m.detailCustom {
+			return m.scopeToWorkload(&resources.Workload{Kind: m.customType.Kind, Namespace: m.detailNamespace, Name: m.detailName})
+		}
+		if m.detailKind == resources.KindPod {
+			return m.findWorkload(m.detailNamespace, m.detailName)
+		}
+	}
+	return m, nil
+}
+
+// findWorkload looks up the workload controlling a pod, the pods of which
+// are shown once it is found
+func (m Model) findWorkload(namespace, name string) (tea.Model, tea.Cmd) {
+	ctx := m.beginLoad(fmt.Sprintf("Finding the workload of pod %s...", name))
+	return m, m.loadCmd(getPodWorkload(ctx, m.client, namespace, name))
+}
+
+// scopeToWorkload switches to the pod list showing only the pods of
+// workload, or every pod of the namespace when it is nil
+func (m Model) scopeToWorkload(workload *resources.Workload) (tea.Model, tea.Cmd) {
+	m.workload = workload
+	message := "Fetching resources..."
+	if workload != nil {
+		message = fmt.Sprintf("Fetching pods of %s...", workload)
+	}
+	ctx := m.beginLoad(message)
+	if m.currentView != resources.PodView {
+		m.navigate(resources.PodView)
+	}
+	m.selectedItem = 0
+	return m, m.loadCmd(getResources(ctx, m.client, m.currentNS, m.fieldSelector, m.workload))
+}
+
+// openPodWorkload shows the pods of the workload found for a pod, staying
+// on the current view for bare pods
+func (m Model) openPodWorkload(msg podWorkloadMsg) (tea.Model, tea.Cmd) {
+	m.loading = false
+	if msg.err != nil {
+		return m.setStatus(ui.ErrorStyle.Render(msg.err.Error()))
+	}
+	if !msg.found {
+		return m.setStatus(ui.WarningStyle.Render(fmt.Sprintf("Pod %s has no owner, it is not part of a workload", msg.pod)))
+	}
+	return m.scopeToWorkload(&msg.workload)
+}
+
+type podWorkloadMsg struct {
+	pod      string
+	workload resources.Workload
+	found    bool
+	err      error
+}
+
+func getPodWorkload(ctx context.Context, client *client.K8sClient, namespace, name string) tea.Cmd {
+	return func() tea.Msg {
+		workload, found, err := client.GetPodWorkload(ctx, namespace, name)
+		return podWorkloadMsg{name, workload, found, err}
+	}
+}
@@ -0,0 +1,55 @@
+package resources
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// AdmissionDenial is a change refused by an admission webhook, such as the
+// ones of Gatekeeper or Kyverno, or by a ValidatingAdmissionPolicy
+type AdmissionDenial struct {
+	// Source is what denied the change, e.g. admission webhook
+	// "validation.gatekeeper.sh"
+	Source string
+
+	// Reasons are the lines of the denial message, one per violated
+	// policy for most policy engines. It is empty when no reason was given.
+	Reasons []string
+}
+
+var (
+	webhookDenialPattern = regexp.MustCompile(`admission webhook "([^"]+)" denied the request(?::\s*)?`)
+	policyDenialPattern  = regexp.MustCompile(`ValidatingAdmissionPolicy '([^']+)' with binding '([^']+)' denied request(?::\s*)?`)
+)
+
+// ParseAdmissionDenial reports whether an API error, possibly formatted
+// into another error, is an admission webhook or policy denying a change,
+// and extracts what denied it and why from its message
+func ParseAdmissionDenial(err error) (AdmissionDenial, bool) {
+	if err == nil {
+		return AdmissionDenial{}, false
+	}
+	msg := err.Error()
+
+	var denial AdmissionDenial
+	var rest string
+	if match := webhookDenialPattern.FindStringSubmatchIndex(msg); match != nil {
+		denial.Source = fmt.Sprintf("admission webhook %q", msg[match[2]:match[3]])
+		rest = msg[match[1]:]
+	} else if match := policyDenialPattern.FindStringSubmatchIndex(msg); match != nil {
+		denial.Source = fmt.Sprintf("admission policy %q (binding %q)", msg[match[2]:match[3]], msg[match[4]:match[5]])
+		rest = msg[match[1]:]
+	} else {
+		return AdmissionDenial{}, false
+	}
+
+	// Kyverno spreads its report over indented lines with blank ones in
+	// between, Gatekeeper puts each violation on a line of its own
+	for _, line := range strings.Split(rest, "\n") {
+		if line = strings.TrimSpace(line); line != "" && line != "without explanation" {
+			denial.Reasons = append(denial.Reasons, line)
+		}
+	}
+	return denial, true
+}
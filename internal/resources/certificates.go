@@ -0,0 +1,44 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ScanTLSSecrets parses the certificate of every TLS secret of a namespace
+// and returns them by expiry, the first to expire first. Only the leaf
+// certificate of a chain is kept, the one the secret serves. A secret whose
+// certificate can't be parsed has the error in its entry, listed first.
+func ScanTLSSecrets(ctx context.Context, clientset kubernetes.Interface, namespace string) ([]CertInfo, error) {
+	secrets, err := clientset.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: "type=" + string(corev1.SecretTypeTLS),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching secrets: %v", err)
+	}
+
+	certs := make([]CertInfo, 0, len(secrets.Items))
+	for _, secret := range secrets.Items {
+		parsed, err := ParseCertificates(secret.Data[corev1.TLSCertKey])
+		cert := CertInfo{Err: err}
+		if err == nil {
+			cert = parsed[0]
+		}
+		cert.Namespace = secret.Namespace
+		cert.Secret = secret.Name
+		certs = append(certs, cert)
+	}
+
+	sort.SliceStable(certs, func(i, j int) bool {
+		if (certs[i].Err != nil) != (certs[j].Err != nil) {
+			return certs[i].Err != nil
+		}
+		return certs[i].NotAfter.Before(certs[j].NotAfter)
+	})
+	return certs, nil
+}
@@ -0,0 +1,71 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// maxJobPrefix keeps the names of the Jobs triggered by hand, with the five
+// random characters the API server appends, within the 63 characters of
+// the job-name label of their pods
+const maxJobPrefix = 58
+
+// GetCronJob returns the schedule and state of a CronJob
+func GetCronJob(ctx context.Context, clientset kubernetes.Interface, namespace, name string) (CronJobInfo, error) {
+	cronJob, err := clientset.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return CronJobInfo{}, fmt.Errorf("error fetching cron job: %v", err)
+	}
+	info := CronJobInfo{
+		Name:      cronJob.Name,
+		Namespace: cronJob.Namespace,
+		Schedule:  cronJob.Spec.Schedule,
+		Suspended: cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend,
+	}
+	if cronJob.Status.LastScheduleTime != nil {
+		info.LastSchedule = cronJob.Status.LastScheduleTime.Time
+	}
+	return info, nil
+}
+
+// TriggerCronJob creates a Job from the template of a CronJob right away,
+// like kubectl create job --from=cronjob/<name>, and returns its name. The
+// Job is owned by the CronJob like the scheduled ones, suspending the
+// CronJob doesn't prevent it.
+func TriggerCronJob(ctx context.Context, clientset kubernetes.Interface, namespace, name string) (string, error) {
+	cronJob, err := clientset.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error fetching cron job: %v", err)
+	}
+
+	prefix := name + "-manual-"
+	if len(prefix) > maxJobPrefix {
+		prefix = prefix[:maxJobPrefix]
+	}
+	annotations := map[string]string{"cronjob.kubernetes.io/instantiate": "manual"}
+	for k, v := range cronJob.Spec.JobTemplate.Annotations {
+		annotations[k] = v
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: prefix,
+			Namespace:    namespace,
+			Labels:       cronJob.Spec.JobTemplate.Labels,
+			Annotations:  annotations,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(cronJob, batchv1.SchemeGroupVersion.WithKind("CronJob")),
+			},
+		},
+		Spec: cronJob.Spec.JobTemplate.Spec,
+	}
+	created, err := clientset.BatchV1().Jobs(namespace).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error creating job: %v", err)
+	}
+	return created.Name, nil
+}
@@ -0,0 +1,267 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
+)
+
+// crdResource is the resource of CustomResourceDefinitions
+var crdResource = schema.GroupVersionResource{
+	Group:    "apiextensions.k8s.io",
+	Version:  "v1",
+	Resource: "customresourcedefinitions",
+}
+
+// maxCustomColumns is the number of printer columns shown for custom resources
+const maxCustomColumns = 2
+
+// defaultCustomColumns are shown for custom resources whose definition
+// declares no printer columns
+var defaultCustomColumns = []PrinterColumn{
+	{Name: "Ready", JSONPath: `.status.conditions[?(@.type=="Ready")].status`},
+	{Name: "Phase", JSONPath: ".status.phase"},
+}
+
+// builtinGroups are the API groups of Kubernetes itself, whose types are
+// not custom resources
+var builtinGroups = map[string]bool{
+	"":                             true,
+	"apps":                         true,
+	"batch":                        true,
+	"autoscaling":                  true,
+	"policy":                       true,
+	"extensions":                   true,
+	"admissionregistration.k8s.io": true,
+	"apiextensions.k8s.io":         true,
+	"apiregistration.k8s.io":       true,
+	"authentication.k8s.io":        true,
+	"authorization.k8s.io":         true,
+	"certificates.k8s.io":          true,
+	"coordination.k8s.io":          true,
+	"discovery.k8s.io":             true,
+	"events.k8s.io":                true,
+	"flowcontrol.apiserver.k8s.io": true,
+	"internal.apiserver.k8s.io":    true,
+	"metrics.k8s.io":               true,
+	"networking.k8s.io":            true,
+	"node.k8s.io":                  true,
+	"rbac.authorization.k8s.io":    true,
+	"resource.k8s.io":              true,
+	"scheduling.k8s.io":            true,
+	"storage.k8s.io":               true,
+	"storagemigration.k8s.io":      true,
+}
+
+// GetCustomResourceTypes returns the custom resource types served by the
+// cluster, sorted by name. They are found through discovery, which anyone
+// may read, and the CustomResourceDefinitions are only read for their
+// printer columns: without the right to read them, which is cluster-wide,
+// every type gets defaultCustomColumns.
+func GetCustomResourceTypes(ctx context.Context, client dynamic.Interface, disc discovery.DiscoveryInterface) ([]CustomResourceType, error) {
+	// A group failing discovery, e.g. an aggregated API down, leaves the
+	// others listed
+	lists, err := disc.ServerPreferredResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, fmt.Errorf("error discovering custom resource types: %v", err)
+	}
+
+	columns, err := customColumns(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	var types []CustomResourceType
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil || builtinGroups[gv.Group] {
+			continue
+		}
+		for _, r := range list.APIResources {
+			// Subresources like status, and types that can't be listed
+			if strings.Contains(r.Name, "/") || !slices.Contains(r.Verbs, "list") {
+				continue
+			}
+			t := CustomResourceType{
+				Name:       r.Name + "." + gv.Group,
+				Group:      gv.Group,
+				Version:    gv.Version,
+				Resource:   r.Name,
+				Kind:       r.Kind,
+				Namespaced: r.Namespaced,
+				Columns:    defaultCustomColumns,
+			}
+			if c, ok := columns[t.Name+"/"+t.Version]; ok {
+				t.Columns = c
+			}
+			types = append(types, t)
+		}
+	}
+
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].Name < types[j].Name
+	})
+
+	return types, nil
+}
+
+// customColumns returns the printer columns declared by the
+// CustomResourceDefinitions, by CRD name and version, e.g.
+// "certificates.cert-manager.io/v1". None are returned when reading the
+// definitions is forbidden.
+func customColumns(ctx context.Context, client dynamic.Interface) (map[string][]PrinterColumn, error) {
+	crdList, err := client.Resource(crdResource).List(ctx, metav1.ListOptions{})
+	if IsForbidden(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error fetching custom resource definitions: %v", err)
+	}
+
+	columns := make(map[string][]PrinterColumn)
+	for _, crd := range crdList.Items {
+		versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+		for _, v := range versions {
+			version, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _, _ := unstructured.NestedString(version, "name")
+			columns[crd.GetName()+"/"+name] = printerColumns(version)
+		}
+	}
+	return columns, nil
+}
+
+// printerColumns returns the printer columns a version of a CRD declares,
+// defaultCustomColumns when it declares none
+func printerColumns(version map[string]interface{}) []PrinterColumn {
+	var columns []PrinterColumn
+	declared, _, _ := unstructured.NestedSlice(version, "additionalPrinterColumns")
+	for _, c := range declared {
+		col, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		path, _, _ := unstructured.NestedString(col, "jsonPath")
+		priority, _, _ := unstructured.NestedInt64(col, "priority")
+
+		// The age column is always shown, and kubectl hides priority columns
+		// unless asked for the wide output
+		if path == ".metadata.creationTimestamp" || priority > 0 {
+			continue
+		}
+
+		colName, _, _ := unstructured.NestedString(col, "name")
+		colType, _, _ := unstructured.NestedString(col, "type")
+		columns = append(columns, PrinterColumn{Name: colName, JSONPath: path, Type: colType})
+		if len(columns) == maxCustomColumns {
+			break
+		}
+	}
+	if len(columns) == 0 {
+		columns = defaultCustomColumns
+	}
+	return columns
+}
+
+// GetCustomResources lists the instances of a custom resource type in the
+// namespace, or in the whole cluster for cluster-scoped types
+func GetCustomResources(ctx context.Context, client dynamic.Interface, crType CustomResourceType, namespace string) ([]CustomResourceInfo, error) {
+	list, err := customResourceClient(client, crType, namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching %s: %v", crType.Resource, err)
+	}
+
+	items := make([]CustomResourceInfo, 0, len(list.Items))
+	for _, obj := range list.Items {
+		created := obj.GetCreationTimestamp().Time
+
+		fields := make([]string, 0, len(crType.Columns))
+		for _, col := range crType.Columns {
+			fields = append(fields, columnValue(obj, col))
+		}
+
+		items = append(items, CustomResourceInfo{
+			Name:      obj.GetName(),
+			Namespace: obj.GetNamespace(),
+			Fields:    fields,
+			Age:       FormatDuration(time.Since(created).Round(time.Second)),
+			Created:   created,
+		})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Name < items[j].Name
+	})
+
+	return items, nil
+}
+
+// GetCustomResourceYAML returns the YAML manifest of a custom resource,
+// without managed fields
+func GetCustomResourceYAML(ctx context.Context, client dynamic.Interface, crType CustomResourceType, namespace, name string) (string, error) {
+	obj, err := customResourceClient(client, crType, namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error fetching %s: %v", strings.ToLower(crType.Kind), err)
+	}
+	obj.SetManagedFields(nil)
+
+	out, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return "", fmt.Errorf("error encoding %s: %v", strings.ToLower(crType.Kind), err)
+	}
+
+	return string(out), nil
+}
+
+// customResourceClient returns the dynamic client for a custom resource type
+func customResourceClient(client dynamic.Interface, crType CustomResourceType, namespace string) dynamic.ResourceInterface {
+	resource := client.Resource(crType.GVR())
+	if !crType.Namespaced {
+		return resource
+	}
+	return resource.Namespace(namespace)
+}
+
+// columnValue evaluates a printer column against an object. Missing fields
+// and unsupported expressions yield an empty value.
+func columnValue(obj unstructured.Unstructured, col PrinterColumn) string {
+	jp := jsonpath.New(col.Name).AllowMissingKeys(true)
+	if err := jp.Parse(fmt.Sprintf("{%s}", col.JSONPath)); err != nil {
+		return ""
+	}
+
+	results, err := jp.FindResults(obj.Object)
+	if err != nil {
+		return ""
+	}
+
+	var values []string
+	for _, result := range results {
+		for _, v := range result {
+			values = append(values, fmt.Sprintf("%v", v.Interface()))
+		}
+	}
+	value := strings.Join(values, ",")
+
+	// Dates read better as ages, like the AGE column
+	if col.Type == "date" {
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return FormatDuration(time.Since(t).Round(time.Second))
+		}
+	}
+
+	return value
+}
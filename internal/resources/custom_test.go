@@ -0,0 +1,90 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// preferredDiscovery serves a fixed list of preferred resources, which the
+// fake discovery client doesn't
+type preferredDiscovery struct {
+	*fakediscovery.FakeDiscovery
+	lists []*metav1.APIResourceList
+}
+
+func (d preferredDiscovery) ServerPreferredResources() ([]*metav1.APIResourceList, error) {
+	return d.lists, nil
+}
+
+func TestGetCustomResourceTypes(t *testing.T) {
+	disc := preferredDiscovery{lists: []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{{Name: "pods", Kind: "Pod", Namespaced: true, Verbs: []string{"list"}}}},
+		{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{{Name: "deployments", Kind: "Deployment", Namespaced: true, Verbs: []string{"list"}}}},
+		{GroupVersion: "cert-manager.io/v1", APIResources: []metav1.APIResource{
+			{Name: "certificates", Kind: "Certificate", Namespaced: true, Verbs: []string{"get", "list"}},
+			{Name: "certificates/status", Kind: "Certificate", Namespaced: true, Verbs: []string{"get"}},
+			{Name: "clusterissuers", Kind: "ClusterIssuer", Verbs: []string{"list"}},
+		}},
+	}}
+
+	crd := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]interface{}{"name": "certificates.cert-manager.io"},
+		"spec": map[string]interface{}{
+			"versions": []interface{}{map[string]interface{}{
+				"name": "v1",
+				"additionalPrinterColumns": []interface{}{
+					map[string]interface{}{"name": "Secret", "jsonPath": ".spec.secretName", "type": "string"},
+				},
+			}},
+		},
+	}}
+
+	tests := []struct {
+		name      string
+		forbidden bool
+		want      string
+	}{
+		{"definitions readable", false, "Secret"},
+		{"definitions forbidden", true, defaultCustomColumns[0].Name},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+				map[schema.GroupVersionResource]string{crdResource: "CustomResourceDefinitionList"}, crd)
+			if tt.forbidden {
+				client.PrependReactor("list", "customresourcedefinitions", func(k8stesting.Action) (bool, runtime.Object, error) {
+					return true, nil, apierrors.NewForbidden(crdResource.GroupResource(), "", nil)
+				})
+			}
+
+			types, err := GetCustomResourceTypes(context.Background(), client, disc)
+			if err != nil {
+				t.Fatalf("GetCustomResourceTypes: %v", err)
+			}
+			var names []string
+			for _, ct := range types {
+				names = append(names, ct.Name)
+			}
+			if len(types) != 2 || names[0] != "certificates.cert-manager.io" || names[1] != "clusterissuers.cert-manager.io" {
+				t.Fatalf("types = %q, want the certificates and clusterissuers of cert-manager.io", names)
+			}
+			if got := types[0]; got.Version != "v1" || got.Kind != "Certificate" || !got.Namespaced || got.Columns[0].Name != tt.want {
+				t.Errorf("certificates = %+v, want namespaced v1 Certificate with column %s", got, tt.want)
+			}
+			if types[1].Namespaced || types[1].Columns[0].Name != defaultCustomColumns[0].Name {
+				t.Errorf("clusterissuers = %+v, want cluster-scoped with the default columns", types[1])
+			}
+		})
+	}
+}
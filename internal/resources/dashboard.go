@@ -0,0 +1,190 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+	metricsclient "k8s.io/metrics/pkg/client/clientset/versioned"
+)
+
+// maxDashboardWarnings is how many recent warning events the dashboard shows
+const maxDashboardWarnings = 5
+
+// GetDashboard fetches the parts of the namespace overview that are not
+// listed with the pods and services: deployments, node capacity and
+// conditions, pod usage, recent warning events and resource quotas. The
+// parts are fetched concurrently and a part that fails only records its
+// error.
+func GetDashboard(ctx context.Context, clientset kubernetes.Interface, metrics *metricsclient.Clientset, namespace string) DashboardInfo {
+	var info DashboardInfo
+
+	var wg sync.WaitGroup
+	wg.Add(5)
+	go func() {
+		defer wg.Done()
+		info.Deployments, info.DeploymentsReady, info.DeploymentsErr = countDeployments(ctx, clientset, namespace)
+	}()
+	go func() {
+		defer wg.Done()
+		info.NodesErr = summarizeNodes(ctx, clientset, &info)
+	}()
+	go func() {
+		defer wg.Done()
+		usage, err := GetPodMetricsList(ctx, metrics, namespace)
+		for _, pm := range usage {
+			info.CPUUsageMilli += pm.CPUMilli
+			info.MemoryUsageBytes += pm.MemoryBytes
+		}
+		info.UsageErr = err
+	}()
+	go func() {
+		defer wg.Done()
+		info.Warnings, info.WarningsErr = recentWarnings(ctx, clientset, namespace)
+	}()
+	go func() {
+		defer wg.Done()
+		info.Quotas, info.QuotasErr = GetResourceQuotas(ctx, clientset, namespace)
+	}()
+	wg.Wait()
+
+	return info
+}
+
+// countDeployments returns the number of deployments in the namespace and
+// how many of them have all their replicas ready
+func countDeployments(ctx context.Context, clientset kubernetes.Interface, namespace string) (int, int, error) {
+	list, err := clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return 0, 0, fmt.Errorf("error fetching deployments: %v", err)
+	}
+
+	ready := 0
+	for _, d := range list.Items {
+		desired := int32(1)
+		if d.Spec.Replicas != nil {
+			desired = *d.Spec.Replicas
+		}
+		if d.Status.ReadyReplicas >= desired {
+			ready++
+		}
+	}
+
+	return len(list.Items), ready, nil
+}
+
+// pressureConditions are the node conditions that lead to evictions when
+// true
+var pressureConditions = []corev1.NodeConditionType{
+	corev1.NodeMemoryPressure,
+	corev1.NodeDiskPressure,
+	corev1.NodePIDPressure,
+}
+
+// summarizeNodes fills the node part of info: the number of nodes and of
+// ready ones, the nodes under pressure and the allocatable CPU, in
+// millicores, and memory of every node
+func summarizeNodes(ctx context.Context, clientset kubernetes.Interface, info *DashboardInfo) error {
+	list, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error fetching nodes: %v", err)
+	}
+
+	info.Nodes = len(list.Items)
+	for _, node := range list.Items {
+		if q, ok := node.Status.Allocatable[corev1.ResourceCPU]; ok {
+			info.CPUAllocatableMilli += q.MilliValue()
+		}
+		if q, ok := node.Status.Allocatable[corev1.ResourceMemory]; ok {
+			info.MemoryAllocatableBytes += q.Value()
+		}
+
+		var pressures []string
+		for _, cond := range node.Status.Conditions {
+			switch {
+			case cond.Type == corev1.NodeReady && cond.Status == corev1.ConditionTrue:
+				info.NodesReady++
+			case slices.Contains(pressureConditions, cond.Type) && cond.Status == corev1.ConditionTrue:
+				pressures = append(pressures, string(cond.Type))
+			}
+		}
+		if len(pressures) > 0 {
+			info.NodePressure = append(info.NodePressure, NodePressure{Node: node.Name, Conditions: pressures})
+		}
+	}
+	sort.Slice(info.NodePressure, func(i, j int) bool { return info.NodePressure[i].Node < info.NodePressure[j].Node })
+
+	return nil
+}
+
+// recentWarnings returns the latest warning events of the namespace, most
+// recent first
+func recentWarnings(ctx context.Context, clientset kubernetes.Interface, namespace string) ([]EventInfo, error) {
+	list, err := clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("type", corev1.EventTypeWarning).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching events: %v", err)
+	}
+
+	events := make([]EventInfo, 0, len(list.Items))
+	for _, event := range list.Items {
+		events = append(events, NewEventInfo(event))
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].LastSeen.After(events[j].LastSeen)
+	})
+
+	return events[:min(len(events), maxDashboardWarnings)], nil
+}
+
+// PodRequests sums the CPU, in millicores, and memory requests of the pods
+// that still hold their resources, i.e. that have not succeeded or failed
+func PodRequests(pods []PodInfo) (int64, int64) {
+	var cpu, mem int64
+	for _, pod := range pods {
+		if pod.Phase == string(corev1.PodSucceeded) || pod.Phase == string(corev1.PodFailed) {
+			continue
+		}
+		for _, c := range pod.Containers {
+			// Init containers have finished by the time the pod runs
+			if c.IsInit {
+				continue
+			}
+			if q, err := resource.ParseQuantity(c.CPURequest); err == nil {
+				cpu += q.MilliValue()
+			}
+			if q, err := resource.ParseQuantity(c.MemoryRequest); err == nil {
+				mem += q.Value()
+			}
+		}
+	}
+	return cpu, mem
+}
+
+// ShortError shortens an API error to its cause, e.g. "forbidden"
+func ShortError(err error) string {
+	msg := err.Error()
+	switch {
+	case IsForbidden(err):
+		return "forbidden"
+	case strings.Contains(msg, "the server could not find the requested resource"):
+		return "not found"
+	}
+	return msg
+}
+
+// IsForbidden reports whether an API error, possibly formatted into another
+// error, is the server refusing the request to the user
+func IsForbidden(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "is forbidden")
+}
@@ -0,0 +1,112 @@
+package resources
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// detailWriter writes a detail text and records the fields written in it,
+// for the details whose fields are known as they are written
+type detailWriter struct {
+	sb     strings.Builder
+	lines  int
+	fields []DetailField
+	flags  []DetailFlag
+
+	// last is the last line written
+	last string
+
+	// container is the container of a pod the lines written are about
+	container  string
+	containers []string
+}
+
+// line writes text holding no field, e.g. a heading or a note, ending with
+// a newline
+func (w *detailWriter) line(text string) {
+	w.sb.WriteString(text + "\n")
+	w.lines += strings.Count(text, "\n") + 1
+	w.last = text[strings.LastIndex(text, "\n")+1:]
+}
+
+// flag calls out text in the line last written, its last occurrence there,
+// as an error when severe and a warning otherwise
+func (w *detailWriter) flag(text string, severe bool) {
+	if start := strings.LastIndex(w.last, text); start >= 0 && text != "" {
+		w.flags = append(w.flags, DetailFlag{Line: w.lines - 1, Start: start, End: start + len(text), Severe: severe})
+	}
+}
+
+// field writes a "key: value" line after indent, a field unless the value is
+// empty
+func (w *detailWriter) field(indent, key, value string) {
+	if value != "" {
+		w.fields = append(w.fields, DetailField{Key: key, Value: value, Line: w.lines, Container: w.container})
+	}
+	w.line(indent + key + ": " + value)
+}
+
+// item writes a list item naming its subject with a field in parentheses,
+// e.g. "  - app (Image: nginx)"
+func (w *detailWriter) item(indent, subject, key, value string) {
+	w.fields = append(w.fields, DetailField{Key: key, Value: value, Line: w.lines, Container: w.container})
+	w.line(fmt.Sprintf("%s- %s (%s: %s)", indent, subject, key, value))
+}
+
+// detail returns the detail written
+func (w *detailWriter) detail() Detail {
+	return Detail{Text: w.sb.String(), Fields: w.fields, Flags: w.flags, Containers: w.containers}
+}
+
+// DetailFields extracts the fields of a detail text written without them.
+// Every detail is written as "Key: value" lines, indented under section
+// headings and sometimes as list items, so a field is any such line with a
+// value. A list item naming its subject holds its field in parentheses,
+// e.g. "- app (Image: nginx)".
+func DetailFields(detail string) []DetailField {
+	var fields []DetailField
+	for i, line := range strings.Split(detail, "\n") {
+		line = strings.TrimPrefix(strings.TrimSpace(line), "- ")
+		if subject, inner, ok := strings.Cut(line, " ("); ok && !strings.Contains(subject, ": ") && strings.HasSuffix(inner, ")") {
+			line = strings.TrimSuffix(inner, ")")
+		}
+
+		key, value, ok := strings.Cut(line, ": ")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || key == "" || value == "" || strings.ContainsAny(key, "()") {
+			continue
+		}
+		fields = append(fields, DetailField{Key: key, Value: value, Line: i})
+	}
+	return fields
+}
+
+// maxDetailValue is the length beyond which a value is cut in a detail, e.g.
+// the last-applied-configuration annotation holding a whole manifest
+const maxDetailValue = 1024
+
+// capValue cuts a value longer than maxDetailValue bytes, on a character
+// boundary, noting how much was left out
+func capValue(value string) string {
+	if len(value) <= maxDetailValue {
+		return value
+	}
+	cut := maxDetailValue
+	for cut > 0 && !utf8.RuneStart(value[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s... [%d more bytes not shown]", value[:cut], len(value)-cut)
+}
+
+// sortedKeys returns the keys of a map, sorted, so that labels and the like
+// are written in the same order on every refresh
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,282 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Diagnosis is a likely cause of a pod not starting
+type Diagnosis struct {
+	// Likelihood ranks causes, higher values are more likely to be the root cause
+	Likelihood int
+	Cause      string
+	Detail     string
+}
+
+// Likelihoods of the causes found by DiagnosePod. Missing dependencies are
+// ranked first since they usually explain the scheduler and kubelet errors.
+const (
+	likelihoodMissingDependency = 40
+	likelihoodUnschedulable     = 30
+	likelihoodContainerWaiting  = 20
+	likelihoodEvent             = 10
+)
+
+// DiagnosePod gathers the usual reasons for a pod being stuck in Pending:
+// the PodScheduled condition, FailedScheduling events, unbound or missing
+// PVCs and missing ConfigMaps or Secrets. The causes are returned most
+// likely first.
+func DiagnosePod(ctx context.Context, clientset kubernetes.Interface, namespace, name string) ([]Diagnosis, error) {
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching pod: %v", err)
+	}
+
+	var diagnoses []Diagnosis
+
+	if reason, message, ok := unscheduledReason(pod); ok {
+		diagnoses = append(diagnoses, Diagnosis{
+			Likelihood: likelihoodUnschedulable,
+			Cause:      fmt.Sprintf("Pod cannot be scheduled (%s)", reason),
+			Detail:     message,
+		})
+	}
+
+	pvcs, err := diagnoseClaims(ctx, clientset, pod)
+	if err != nil {
+		return nil, err
+	}
+	diagnoses = append(diagnoses, pvcs...)
+
+	refs, err := diagnoseReferences(ctx, clientset, pod)
+	if err != nil {
+		return nil, err
+	}
+	diagnoses = append(diagnoses, refs...)
+
+	// Containers that were scheduled but can't start, e.g. image pull errors
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		waiting := status.State.Waiting
+		if waiting == nil || waiting.Reason == "" || waiting.Reason == "ContainerCreating" || waiting.Reason == "PodInitializing" {
+			continue
+		}
+		diagnoses = append(diagnoses, Diagnosis{
+			Likelihood: likelihoodContainerWaiting,
+			Cause:      fmt.Sprintf("Container %s is waiting: %s", status.Name, waiting.Reason),
+			Detail:     waiting.Message,
+		})
+	}
+
+	events, err := GetEvents(ctx, clientset, KindPod, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		if event.Reason != "FailedScheduling" && event.Reason != "FailedMount" && event.Reason != "FailedAttachVolume" {
+			continue
+		}
+		diagnoses = append(diagnoses, Diagnosis{
+			Likelihood: likelihoodEvent,
+			Cause:      fmt.Sprintf("%s event (%dx, %s ago)", event.Reason, event.Count, event.Age),
+			Detail:     event.Message,
+		})
+	}
+
+	sort.SliceStable(diagnoses, func(i, j int) bool {
+		return diagnoses[i].Likelihood > diagnoses[j].Likelihood
+	})
+
+	return diagnoses, nil
+}
+
+// diagnoseClaims reports PVCs used by the pod that are missing or not bound
+func diagnoseClaims(ctx context.Context, clientset kubernetes.Interface, pod *corev1.Pod) ([]Diagnosis, error) {
+	var diagnoses []Diagnosis
+
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+		claimName := volume.PersistentVolumeClaim.ClaimName
+
+		pvc, err := clientset.CoreV1().PersistentVolumeClaims(pod.Namespace).Get(ctx, claimName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			diagnoses = append(diagnoses, Diagnosis{
+				Likelihood: likelihoodMissingDependency,
+				Cause:      fmt.Sprintf("PersistentVolumeClaim %s does not exist", claimName),
+				Detail:     fmt.Sprintf("Referenced by volume %s", volume.Name),
+			})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error fetching persistent volume claim %s: %v", claimName, err)
+		}
+
+		if pvc.Status.Phase != corev1.ClaimBound {
+			storageClass := "<default>"
+			if pvc.Spec.StorageClassName != nil {
+				storageClass = *pvc.Spec.StorageClassName
+			}
+			diagnoses = append(diagnoses, Diagnosis{
+				Likelihood: likelihoodMissingDependency,
+				Cause:      fmt.Sprintf("PersistentVolumeClaim %s is %s, not Bound", claimName, pvc.Status.Phase),
+				Detail:     fmt.Sprintf("Storage class %s, check that it exists and can provision volumes", storageClass),
+			})
+		}
+	}
+
+	return diagnoses, nil
+}
+
+// objectRef is a ConfigMap or Secret the pod depends on
+type objectRef struct {
+	kind     ResourceKind
+	name     string
+	key      string
+	optional bool
+	usedBy   string
+}
+
+// diagnoseReferences reports ConfigMaps and Secrets, or keys in them, that
+// the pod needs but that don't exist
+func diagnoseReferences(ctx context.Context, clientset kubernetes.Interface, pod *corev1.Pod) ([]Diagnosis, error) {
+	var diagnoses []Diagnosis
+
+	// Fetch every object once however many times it is referenced
+	type fetched struct {
+		found bool
+		keys  map[string]bool
+	}
+	cache := make(map[string]fetched)
+
+	for _, ref := range podReferences(pod) {
+		cacheKey := string(ref.kind) + "/" + ref.name
+		obj, ok := cache[cacheKey]
+		if !ok {
+			keys, err := objectKeys(ctx, clientset, ref.kind, pod.Namespace, ref.name)
+			if err != nil && !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("error fetching %s %s: %v", ref.kind, ref.name, err)
+			}
+			obj = fetched{found: err == nil, keys: keys}
+			cache[cacheKey] = obj
+		}
+
+		if ref.optional {
+			continue
+		}
+
+		switch {
+		case !obj.found:
+			diagnoses = append(diagnoses, Diagnosis{
+				Likelihood: likelihoodMissingDependency,
+				Cause:      fmt.Sprintf("%s %s does not exist", ref.kind, ref.name),
+				Detail:     fmt.Sprintf("Referenced by %s", ref.usedBy),
+			})
+		case ref.key != "" && !obj.keys[ref.key]:
+			diagnoses = append(diagnoses, Diagnosis{
+				Likelihood: likelihoodMissingDependency,
+				Cause:      fmt.Sprintf("%s %s has no key %s", ref.kind, ref.name, ref.key),
+				Detail:     fmt.Sprintf("Referenced by %s", ref.usedBy),
+			})
+		}
+	}
+
+	return diagnoses, nil
+}
+
+// podReferences lists the ConfigMaps and Secrets used by the volumes and the
+// environment of a pod's containers
+func podReferences(pod *corev1.Pod) []objectRef {
+	var refs []objectRef
+
+	for _, volume := range pod.Spec.Volumes {
+		usedBy := "volume " + volume.Name
+		if cm := volume.ConfigMap; cm != nil {
+			refs = append(refs, objectRef{kindConfigMap, cm.Name, "", isOptional(cm.Optional), usedBy})
+		}
+		if s := volume.Secret; s != nil {
+			refs = append(refs, objectRef{KindSecret, s.SecretName, "", isOptional(s.Optional), usedBy})
+		}
+		if p := volume.Projected; p != nil {
+			for _, source := range p.Sources {
+				if cm := source.ConfigMap; cm != nil {
+					refs = append(refs, objectRef{kindConfigMap, cm.Name, "", isOptional(cm.Optional), usedBy})
+				}
+				if s := source.Secret; s != nil {
+					refs = append(refs, objectRef{KindSecret, s.Name, "", isOptional(s.Optional), usedBy})
+				}
+			}
+		}
+	}
+
+	containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	for _, container := range containers {
+		for _, envFrom := range container.EnvFrom {
+			usedBy := fmt.Sprintf("envFrom of container %s", container.Name)
+			if cm := envFrom.ConfigMapRef; cm != nil {
+				refs = append(refs, objectRef{kindConfigMap, cm.Name, "", isOptional(cm.Optional), usedBy})
+			}
+			if s := envFrom.SecretRef; s != nil {
+				refs = append(refs, objectRef{KindSecret, s.Name, "", isOptional(s.Optional), usedBy})
+			}
+		}
+
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			usedBy := fmt.Sprintf("env %s of container %s", env.Name, container.Name)
+			if cm := env.ValueFrom.ConfigMapKeyRef; cm != nil {
+				refs = append(refs, objectRef{kindConfigMap, cm.Name, cm.Key, isOptional(cm.Optional), usedBy})
+			}
+			if s := env.ValueFrom.SecretKeyRef; s != nil {
+				refs = append(refs, objectRef{KindSecret, s.Name, s.Key, isOptional(s.Optional), usedBy})
+			}
+		}
+	}
+
+	return refs
+}
+
+// kindConfigMap is only used to describe references, ConfigMaps have no view
+const kindConfigMap ResourceKind = "ConfigMap"
+
+// objectKeys returns the data keys of a ConfigMap or Secret
+func objectKeys(ctx context.Context, clientset kubernetes.Interface, kind ResourceKind, namespace, name string) (map[string]bool, error) {
+	keys := make(map[string]bool)
+
+	if kind == kindConfigMap {
+		cm, err := clientset.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for k := range cm.Data {
+			keys[k] = true
+		}
+		for k := range cm.BinaryData {
+			keys[k] = true
+		}
+		return keys, nil
+	}
+
+	secret, err := clientset.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for k := range secret.Data {
+		keys[k] = true
+	}
+	return keys, nil
+}
+
+// isOptional dereferences an optional flag of a reference
+func isOptional(optional *bool) bool {
+	return optional != nil && *optional
+}
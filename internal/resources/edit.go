@@ -0,0 +1,99 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+// GetResourceYAML returns the YAML manifest of a pod or service, without
+// managed fields
+func GetResourceYAML(ctx context.Context, clientset kubernetes.Interface, kind ResourceKind, namespace, name string) (string, error) {
+	var obj interface{}
+
+	switch kind {
+	case KindPod:
+		pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("error fetching pod: %v", err)
+		}
+		pod.ManagedFields = nil
+		pod.APIVersion, pod.Kind = "v1", "Pod"
+		obj = pod
+	case KindService:
+		svc, err := clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("error fetching service: %v", err)
+		}
+		svc.ManagedFields = nil
+		svc.APIVersion, svc.Kind = "v1", "Service"
+		obj = svc
+	default:
+		return "", fmt.Errorf("editing %s is not supported", kind)
+	}
+
+	out, err := yaml.Marshal(obj)
+	if err != nil {
+		return "", fmt.Errorf("error encoding %s: %v", kind, err)
+	}
+
+	return string(out), nil
+}
+
+// UpdateFromYAML applies the changes between the original and edited YAML of
+// a pod or service as a strategic merge patch. It returns false without
+// calling the API when the edit did not change anything.
+func UpdateFromYAML(ctx context.Context, clientset kubernetes.Interface, kind ResourceKind, namespace, name, original, edited string) (bool, error) {
+	originalJSON, err := yaml.YAMLToJSON([]byte(original))
+	if err != nil {
+		return false, fmt.Errorf("error parsing original YAML: %v", err)
+	}
+	editedJSON, err := yaml.YAMLToJSON([]byte(edited))
+	if err != nil {
+		return false, fmt.Errorf("invalid YAML: %v", err)
+	}
+
+	// Compare the parsed documents so comments and formatting don't count
+	var before, after interface{}
+	if err := yaml.Unmarshal(originalJSON, &before); err != nil {
+		return false, fmt.Errorf("error parsing original YAML: %v", err)
+	}
+	if err := yaml.Unmarshal(editedJSON, &after); err != nil {
+		return false, fmt.Errorf("invalid YAML: %v", err)
+	}
+	if reflect.DeepEqual(before, after) {
+		return false, nil
+	}
+
+	switch kind {
+	case KindPod:
+		patch, err := strategicpatch.CreateTwoWayMergePatch(originalJSON, editedJSON, corev1.Pod{})
+		if err != nil {
+			return false, fmt.Errorf("error creating patch: %v", err)
+		}
+		_, err = clientset.CoreV1().Pods(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return false, fmt.Errorf("error updating pod: %v", err)
+		}
+	case KindService:
+		patch, err := strategicpatch.CreateTwoWayMergePatch(originalJSON, editedJSON, corev1.Service{})
+		if err != nil {
+			return false, fmt.Errorf("error creating patch: %v", err)
+		}
+		_, err = clientset.CoreV1().Services(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return false, fmt.Errorf("error updating service: %v", err)
+		}
+	default:
+		return false, fmt.Errorf("editing %s is not supported", kind)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,351 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// EnvMode is how the pod detail shows environment variables set from
+// ConfigMaps and Secrets
+type EnvMode int
+
+const (
+	// EnvSources names the ConfigMap or Secret key a variable comes from
+	EnvSources EnvMode = iota
+	// EnvResolved fetches the ConfigMaps and Secrets to show the values,
+	// masking the ones from Secrets
+	EnvResolved
+	// EnvRevealed shows the values from Secrets too
+	EnvRevealed
+)
+
+// Values shown for variables whose ConfigMap or Secret, or its key, doesn't
+// exist. The container can't start until they are created.
+const (
+	EnvSourceMissing = "[source missing]"
+	EnvKeyMissing    = "[key missing]"
+)
+
+// EnvKubeletValue is the value shown for variables set from a field of the
+// pod the kubelet fills in and that isn't known, or from its resources
+const EnvKubeletValue = "[set by the kubelet]"
+
+// EnvVar is an environment variable of a container with its effective value
+type EnvVar struct {
+	Name  string
+	Value string
+
+	// Source describes the ConfigMap or Secret the value comes from, e.g.
+	// "ConfigMap app (key: mode)", empty for other values
+	Source string
+}
+
+// ResolveEnvVars returns the environment of a container the way the kubelet
+// builds it: envFrom sources first, then env entries, later ones winning.
+// Values from Secrets are masked unless reveal is true. Variables whose
+// source is missing are set to EnvSourceMissing or EnvKeyMissing, those
+// with an optional missing source are left out like the kubelet does.
+func ResolveEnvVars(ctx context.Context, clientset kubernetes.Interface, namespace string, container corev1.Container, reveal bool) (map[string]string, error) {
+	vars, err := resolveEnv(ctx, clientset, namespace, container, reveal)
+	if err != nil {
+		return nil, err
+	}
+
+	env := make(map[string]string, len(vars))
+	for _, v := range vars {
+		env[v.Name] = v.Value
+	}
+	return env, nil
+}
+
+// GetContainerEnv returns the environment of a container of a pod, init
+// containers included, like ResolveEnvVars in the order of the spec.
+// Variables set from fields of the pod get their values from it, and every
+// variable set from a valueFrom entry names it as its source.
+func GetContainerEnv(ctx context.Context, clientset kubernetes.Interface, namespace, podName, containerName string, reveal bool) ([]EnvVar, error) {
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching pod: %v", err)
+	}
+	containers := slices.Concat(pod.Spec.InitContainers, pod.Spec.Containers)
+	i := slices.IndexFunc(containers, func(c corev1.Container) bool { return c.Name == containerName })
+	if i < 0 {
+		return nil, fmt.Errorf("pod %s has no container %s", podName, containerName)
+	}
+	container := containers[i]
+
+	vars, err := resolveEnv(ctx, clientset, namespace, container, reveal)
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]int, len(vars))
+	for i, v := range vars {
+		index[v.Name] = i
+	}
+	for _, env := range container.Env {
+		i, ok := index[env.Name]
+		if !ok || env.ValueFrom == nil || vars[i].Source != "" {
+			continue
+		}
+		vars[i].Source = envValueSource(env.ValueFrom)
+		vars[i].Value = EnvKubeletValue
+		if env.ValueFrom.FieldRef != nil {
+			if value, ok := podFieldValue(pod, env.ValueFrom.FieldRef.FieldPath); ok {
+				vars[i].Value = value
+			}
+		}
+	}
+	return vars, nil
+}
+
+// podFieldValue returns the value of a field of a pod the downward API
+// exposes, false for the fields not known or not set yet, like the IP of a
+// pod not scheduled
+func podFieldValue(pod *corev1.Pod, path string) (string, bool) {
+	if key, ok := strings.CutPrefix(path, "metadata.labels['"); ok {
+		value, found := pod.Labels[strings.TrimSuffix(key, "']")]
+		return value, found
+	}
+	if key, ok := strings.CutPrefix(path, "metadata.annotations['"); ok {
+		value, found := pod.Annotations[strings.TrimSuffix(key, "']")]
+		return value, found
+	}
+
+	var value string
+	switch path {
+	case "metadata.name":
+		value = pod.Name
+	case "metadata.namespace":
+		value = pod.Namespace
+	case "metadata.uid":
+		value = string(pod.UID)
+	case "spec.nodeName":
+		value = pod.Spec.NodeName
+	case "spec.serviceAccountName":
+		value = pod.Spec.ServiceAccountName
+	case "status.hostIP":
+		value = pod.Status.HostIP
+	case "status.podIP":
+		value = pod.Status.PodIP
+	}
+	return value, value != ""
+}
+
+// resolveEnv returns the environment of a container in the order it is
+// listed in the spec, envFrom variables sorted by name
+func resolveEnv(ctx context.Context, clientset kubernetes.Interface, namespace string, container corev1.Container, reveal bool) ([]EnvVar, error) {
+	sources := envSources{
+		ctx:        ctx,
+		clientset:  clientset,
+		namespace:  namespace,
+		configMaps: make(map[string]map[string]string),
+		secrets:    make(map[string]map[string][]byte),
+	}
+
+	var vars []EnvVar
+	index := make(map[string]int)
+	set := func(v EnvVar) {
+		if i, ok := index[v.Name]; ok {
+			vars[i] = v
+			return
+		}
+		index[v.Name] = len(vars)
+		vars = append(vars, v)
+	}
+
+	for _, envFrom := range container.EnvFrom {
+		var source string
+		var values map[string]string
+		var found, optional bool
+
+		switch {
+		case envFrom.ConfigMapRef != nil:
+			source = "ConfigMap " + envFrom.ConfigMapRef.Name
+			optional = isOptional(envFrom.ConfigMapRef.Optional)
+			data, ok, err := sources.configMap(envFrom.ConfigMapRef.Name)
+			if err != nil {
+				return nil, err
+			}
+			values, found = data, ok
+		case envFrom.SecretRef != nil:
+			source = "Secret " + envFrom.SecretRef.Name
+			optional = isOptional(envFrom.SecretRef.Optional)
+			data, ok, err := sources.secret(envFrom.SecretRef.Name)
+			if err != nil {
+				return nil, err
+			}
+			values = make(map[string]string, len(data))
+			for key, value := range data {
+				values[key] = secretValue(value, reveal)
+			}
+			found = ok
+		default:
+			continue
+		}
+
+		if !found && optional {
+			continue
+		}
+		// A missing source has no keys to list, say so once
+		if !found {
+			set(EnvVar{Name: envFrom.Prefix + "*", Value: EnvSourceMissing, Source: source})
+			continue
+		}
+
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			set(EnvVar{Name: envFrom.Prefix + key, Value: values[key], Source: source})
+		}
+	}
+
+	for _, env := range container.Env {
+		if env.ValueFrom == nil {
+			set(EnvVar{Name: env.Name, Value: env.Value})
+			continue
+		}
+
+		switch {
+		case env.ValueFrom.ConfigMapKeyRef != nil:
+			ref := env.ValueFrom.ConfigMapKeyRef
+			data, found, err := sources.configMap(ref.Name)
+			if err != nil {
+				return nil, err
+			}
+			value, ok := data[ref.Key]
+			if (!found || !ok) && isOptional(ref.Optional) {
+				continue
+			}
+			switch {
+			case !found:
+				value = EnvSourceMissing
+			case !ok:
+				value = EnvKeyMissing
+			}
+			set(EnvVar{Name: env.Name, Value: value, Source: fmt.Sprintf("ConfigMap %s (key: %s)", ref.Name, ref.Key)})
+
+		case env.ValueFrom.SecretKeyRef != nil:
+			ref := env.ValueFrom.SecretKeyRef
+			data, found, err := sources.secret(ref.Name)
+			if err != nil {
+				return nil, err
+			}
+			raw, ok := data[ref.Key]
+			if (!found || !ok) && isOptional(ref.Optional) {
+				continue
+			}
+			var value string
+			switch {
+			case !found:
+				value = EnvSourceMissing
+			case !ok:
+				value = EnvKeyMissing
+			default:
+				value = secretValue(raw, reveal)
+			}
+			set(EnvVar{Name: env.Name, Value: value, Source: fmt.Sprintf("Secret %s (key: %s)", ref.Name, ref.Key)})
+
+		default:
+			// Field and resource references are filled in by the kubelet
+			set(EnvVar{Name: env.Name, Value: "[from " + envValueSource(env.ValueFrom) + "]"})
+		}
+	}
+
+	return vars, nil
+}
+
+// envValueSource describes where an env entry takes its value from
+func envValueSource(from *corev1.EnvVarSource) string {
+	switch {
+	case from.ConfigMapKeyRef != nil:
+		return fmt.Sprintf("ConfigMap %s (key: %s)", from.ConfigMapKeyRef.Name, from.ConfigMapKeyRef.Key)
+	case from.SecretKeyRef != nil:
+		return fmt.Sprintf("Secret %s (key: %s)", from.SecretKeyRef.Name, from.SecretKeyRef.Key)
+	case from.FieldRef != nil:
+		return fmt.Sprintf("Field %s", from.FieldRef.FieldPath)
+	case from.ResourceFieldRef != nil:
+		return fmt.Sprintf("Resource %s", from.ResourceFieldRef.Resource)
+	}
+	return "Unknown source"
+}
+
+// secretValue returns a value read from a Secret, masked unless reveal is
+// true
+func secretValue(value []byte, reveal bool) string {
+	if reveal {
+		return string(value)
+	}
+	return fmt.Sprintf("[hidden, %d bytes]", len(value))
+}
+
+// envSources fetches the ConfigMaps and Secrets referenced by a container,
+// each only once
+type envSources struct {
+	ctx       context.Context
+	clientset kubernetes.Interface
+	namespace string
+
+	// Fetched data by name, nil for the ones that don't exist
+	configMaps map[string]map[string]string
+	secrets    map[string]map[string][]byte
+}
+
+// configMap returns the data of a ConfigMap, found is false when it doesn't
+// exist
+func (s envSources) configMap(name string) (data map[string]string, found bool, err error) {
+	if data, ok := s.configMaps[name]; ok {
+		return data, data != nil, nil
+	}
+
+	cm, err := s.clientset.CoreV1().ConfigMaps(s.namespace).Get(s.ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		s.configMaps[name] = nil
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("error fetching ConfigMap %s: %v", name, err)
+	}
+
+	// Only string data can be used in the environment, not binaryData
+	data = cm.Data
+	if data == nil {
+		data = make(map[string]string)
+	}
+	s.configMaps[name] = data
+	return data, true, nil
+}
+
+// secret returns the data of a Secret, found is false when it doesn't exist
+func (s envSources) secret(name string) (data map[string][]byte, found bool, err error) {
+	if data, ok := s.secrets[name]; ok {
+		return data, data != nil, nil
+	}
+
+	secret, err := s.clientset.CoreV1().Secrets(s.namespace).Get(s.ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		s.secrets[name] = nil
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("error fetching Secret %s: %v", name, err)
+	}
+
+	data = secret.Data
+	if data == nil {
+		data = make(map[string][]byte)
+	}
+	s.secrets[name] = data
+	return data, true, nil
+}
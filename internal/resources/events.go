@@ -0,0 +1,71 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+)
+
+// GetEvents returns the events of a resource, most recent first. An empty
+// namespace is used for cluster-scoped resources such as nodes.
+func GetEvents(ctx context.Context, clientset kubernetes.Interface, kind ResourceKind, namespace, name string) ([]EventInfo, error) {
+	selector := fields.Set{
+		"involvedObject.kind": string(kind),
+		"involvedObject.name": name,
+	}.AsSelector().String()
+
+	eventList, err := clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: selector,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching events: %v", err)
+	}
+
+	events := make([]EventInfo, 0, len(eventList.Items))
+	for _, event := range eventList.Items {
+		events = append(events, NewEventInfo(event))
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].LastSeen.After(events[j].LastSeen)
+	})
+
+	return events, nil
+}
+
+// NewEventInfo builds the summary of an event
+func NewEventInfo(event corev1.Event) EventInfo {
+	lastSeen := eventTime(event)
+
+	return EventInfo{
+		Type:      event.Type,
+		Reason:    event.Reason,
+		Count:     max(event.Count, 1),
+		Age:       FormatDuration(time.Since(lastSeen).Round(time.Second)),
+		Message:   event.Message,
+		LastSeen:  lastSeen,
+		Namespace: event.Namespace,
+		Object:    event.InvolvedObject.Kind + "/" + event.InvolvedObject.Name,
+	}
+}
+
+// eventTime returns when an event was last seen, falling back through the
+// timestamps set by the different event producers
+func eventTime(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
@@ -0,0 +1,113 @@
+package resources
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// EvictionRisk is a pod on a node under memory pressure that the kubelet
+// evicts before the pods that stay within their memory requests
+type EvictionRisk struct {
+	Pod      string
+	Node     string
+	QOSClass string
+
+	// Reason tells what exposes the pod, e.g. "no memory limit"
+	Reason string
+
+	// excess is the memory the pod may use beyond its requests, in bytes,
+	// math.MaxInt64 when nothing bounds it
+	excess int64
+}
+
+// AssessEvictionRisk lists the pods running on the nodes under memory
+// pressure that are first in line for eviction, in the order they are at
+// risk: BestEffort pods, which request nothing, then the Burstable pods
+// that may use more memory than they request, the least bounded first.
+// The kubelet ranks pods by their actual usage above requests, which the
+// requests and limits approximate before usage gets there.
+func AssessEvictionRisk(nodes []NodePressure, pods []PodInfo) []EvictionRisk {
+	pressured := make(map[string]bool)
+	for _, n := range nodes {
+		if slices.Contains(n.Conditions, string(corev1.NodeMemoryPressure)) {
+			pressured[n.Node] = true
+		}
+	}
+	if len(pressured) == 0 {
+		return nil
+	}
+
+	var risks []EvictionRisk
+	for _, pod := range pods {
+		if !pressured[pod.Node] || pod.Phase == string(corev1.PodSucceeded) || pod.Phase == string(corev1.PodFailed) {
+			continue
+		}
+		risk := EvictionRisk{Pod: pod.Name, Node: pod.Node, QOSClass: pod.QOSClass}
+
+		switch corev1.PodQOSClass(pod.QOSClass) {
+		case corev1.PodQOSBestEffort:
+			risk.Reason = "no requests or limits"
+			risk.excess = math.MaxInt64
+		case corev1.PodQOSBurstable:
+			request, limit, bounded := podMemory(pod)
+			switch {
+			case !bounded && request == 0:
+				risk.Reason = "no memory request or limit"
+				risk.excess = math.MaxInt64
+			case !bounded:
+				risk.Reason = fmt.Sprintf("no memory limit, %dMi requested", request/(1024*1024))
+				risk.excess = math.MaxInt64
+			case limit > request:
+				risk.Reason = fmt.Sprintf("memory limit %dMi over %dMi requested", limit/(1024*1024), request/(1024*1024))
+				risk.excess = limit - request
+			default:
+				continue
+			}
+		default:
+			continue
+		}
+		risks = append(risks, risk)
+	}
+
+	sort.SliceStable(risks, func(i, j int) bool {
+		a, b := risks[i], risks[j]
+		if (a.QOSClass == string(corev1.PodQOSBestEffort)) != (b.QOSClass == string(corev1.PodQOSBestEffort)) {
+			return a.QOSClass == string(corev1.PodQOSBestEffort)
+		}
+		if a.excess != b.excess {
+			return a.excess > b.excess
+		}
+		if a.Node != b.Node {
+			return a.Node < b.Node
+		}
+		return a.Pod < b.Pod
+	})
+	return risks
+}
+
+// podMemory sums the memory requests and limits of the containers of a
+// pod, init containers aside since they have finished. bounded is false
+// when a container has no memory limit.
+func podMemory(pod PodInfo) (request, limit int64, bounded bool) {
+	bounded = true
+	for _, c := range pod.Containers {
+		if c.IsInit {
+			continue
+		}
+		if q, err := resource.ParseQuantity(c.MemoryRequest); err == nil {
+			request += q.Value()
+		}
+		q, err := resource.ParseQuantity(c.MemoryLimit)
+		if err != nil {
+			bounded = false
+			continue
+		}
+		limit += q.Value()
+	}
+	return request, limit, bounded
+}
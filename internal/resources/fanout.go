@@ -0,0 +1,30 @@
+package resources
+
+import "sync"
+
+// ForEach calls fn for every item concurrently, with at most concurrency
+// calls running at once so a long list doesn't flood the API server. It
+// returns once every call did, with the error of each item at its index,
+// nil for the items that succeeded. fn gets the index of its item to store
+// results in a slot of its own.
+func ForEach[T any](items []T, concurrency int, fn func(i int, item T) error) []error {
+	errs := make([]error, len(items))
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i, item := range items {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = fn(i, item)
+		}()
+	}
+	wg.Wait()
+
+	return errs
+}
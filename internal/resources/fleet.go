@@ -0,0 +1,61 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/kubernetes"
+)
+
+// GetClusterSummary summarizes the cluster of a context: its version and
+// how many of its nodes and pods are ready. A cluster whose version can't be
+// fetched is unreachable and nothing else is asked. The pods are listed from
+// the API server cache, the counts don't need to be exact.
+func GetClusterSummary(ctx context.Context, clientset kubernetes.Interface, contextName string) ClusterSummary {
+	summary := ClusterSummary{Context: contextName}
+
+	// Discovery's ServerVersion takes no context, it could outlast ctx
+	raw, err := clientset.Discovery().RESTClient().Get().AbsPath("/version").Do(ctx).Raw()
+	if err != nil {
+		summary.Err = fmt.Errorf("error fetching server version: %v", err)
+		return summary
+	}
+	var info version.Info
+	if err := json.Unmarshal(raw, &info); err != nil {
+		summary.Err = fmt.Errorf("error decoding server version: %v", err)
+		return summary
+	}
+	summary.Version = info.GitVersion
+
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{ResourceVersion: "0"})
+	if err != nil {
+		summary.NodesErr = fmt.Errorf("error fetching nodes: %v", err)
+	} else {
+		summary.Nodes = len(nodes.Items)
+		for _, node := range nodes.Items {
+			for _, cond := range node.Status.Conditions {
+				if cond.Type == corev1.NodeReady && cond.Status == corev1.ConditionTrue {
+					summary.NodesReady++
+				}
+			}
+		}
+	}
+
+	pods, err := clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{ResourceVersion: "0"})
+	if err != nil {
+		summary.PodsErr = fmt.Errorf("error fetching pods: %v", err)
+	} else {
+		summary.Pods = len(pods.Items)
+		for i := range pods.Items {
+			if podReady(&pods.Items[i]) {
+				summary.PodsReady++
+			}
+		}
+	}
+
+	return summary
+}
@@ -0,0 +1,40 @@
+package resources
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// IsPodHealthy reports whether a pod is doing its job: running with all its
+// containers and readiness gates ready, or completed successfully. A pod
+// still passing its startup probes is given the time to.
+func IsPodHealthy(pod PodInfo) bool {
+	if pod.Status == PodStarting {
+		return true
+	}
+	switch pod.Phase {
+	case string(corev1.PodSucceeded):
+		return true
+	case string(corev1.PodRunning):
+	default:
+		return false
+	}
+
+	for _, c := range pod.Containers {
+		if !c.IsInit && !c.Ready {
+			return false
+		}
+	}
+	if passed, total, ok := strings.Cut(pod.ReadinessGates, "/"); ok && passed != total {
+		return false
+	}
+	return true
+}
+
+// IsServiceHealthy reports whether a service has ready endpoints to send
+// traffic to. ExternalName services have none by design, and services whose
+// endpoints could not be listed are given the benefit of the doubt.
+func IsServiceHealthy(svc ServiceInfo) bool {
+	return svc.IsExternalName() || svc.Endpoints != 0
+}
@@ -0,0 +1,221 @@
+package resources
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DefaultLogTailLines is the number of log lines fetched when opening logs,
+// bounded so chatty containers don't send gigabytes
+const DefaultLogTailLines int64 = 1000
+
+// LogRange bounds the logs fetched: the last TailLines lines, of the ones
+// written within Since when it is set
+type LogRange struct {
+	TailLines int64
+	Since     time.Duration
+}
+
+// String describes the range, e.g. "last 1000 lines of 15m"
+func (r LogRange) String() string {
+	s := fmt.Sprintf("last %d lines", r.TailLines)
+	if r.Since > 0 {
+		s += " of " + FormatDuration(r.Since)
+	}
+	return s
+}
+
+// apply bounds the log options to the range
+func (r LogRange) apply(opts *corev1.PodLogOptions) {
+	if r.TailLines > 0 {
+		tailLines := r.TailLines
+		opts.TailLines = &tailLines
+	}
+	if r.Since > 0 {
+		since := int64(r.Since.Seconds())
+		opts.SinceSeconds = &since
+	}
+}
+
+// ErrNoPreviousLogs is returned when previous logs are requested for a
+// container that has not been restarted
+var ErrNoPreviousLogs = errors.New("no previous logs")
+
+// GetPodLogs returns the logs of a container in the specified pod, within
+// r. When previous is true the logs of the previously terminated instance
+// are returned.
+func GetPodLogs(ctx context.Context, clientset kubernetes.Interface, namespace, podName, container string, previous bool, r LogRange) (string, error) {
+	opts := &corev1.PodLogOptions{
+		Container: container,
+		Previous:  previous,
+	}
+	r.apply(opts)
+
+	raw, err := clientset.CoreV1().Pods(namespace).GetLogs(podName, opts).DoRaw(ctx)
+	if err != nil {
+		// The API answers with a bad request when there is no terminated instance
+		if previous && apierrors.IsBadRequest(err) {
+			return "", ErrNoPreviousLogs
+		}
+		return "", fmt.Errorf("error fetching pod logs: %v", err)
+	}
+
+	return string(raw), nil
+}
+
+// LogLine is a log line of a container with the time it was written
+type LogLine struct {
+	Container string
+	Time      time.Time
+	Text      string
+}
+
+// maxConcurrentLogFetches bounds the containers GetPodLogsAllContainers
+// fetches at once
+const maxConcurrentLogFetches = 4
+
+// GetPodLogsAllContainers returns the logs within r of every container of a
+// pod, init containers included, merged in the order they were written.
+// Containers that haven't started yet or logged nothing are skipped.
+func GetPodLogsAllContainers(ctx context.Context, clientset kubernetes.Interface, namespace, podName string, r LogRange) ([]LogLine, error) {
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching pod: %v", err)
+	}
+
+	var names []string
+	for _, c := range pod.Spec.InitContainers {
+		names = append(names, c.Name)
+	}
+	for _, c := range pod.Spec.Containers {
+		names = append(names, c.Name)
+	}
+
+	// Fetch the containers concurrently, each into its own slot
+	logs := make([][]LogLine, len(names))
+	errs := ForEach(names, maxConcurrentLogFetches, func(i int, name string) error {
+		opts := &corev1.PodLogOptions{
+			Container:  name,
+			Timestamps: true,
+		}
+		r.apply(opts)
+		raw, err := clientset.CoreV1().Pods(namespace).GetLogs(podName, opts).DoRaw(ctx)
+		// The API answers with a bad request for containers that haven't
+		// started yet, they have no logs
+		if apierrors.IsBadRequest(err) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error fetching logs of container %s: %v", name, err)
+		}
+		logs[i] = parseLogLines(name, raw)
+		return nil
+	})
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	var lines []LogLine
+	for _, l := range logs {
+		lines = append(lines, l...)
+	}
+	// A stable sort keeps the order of lines logged at the same time
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].Time.Before(lines[j].Time)
+	})
+
+	return lines, nil
+}
+
+// parseLogLines splits logs fetched with timestamps into lines. A line
+// without a valid timestamp keeps the time of the line before it.
+func parseLogLines(container string, raw []byte) []LogLine {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var lines []LogLine
+	var last time.Time
+	for _, text := range strings.Split(strings.TrimSuffix(string(raw), "\n"), "\n") {
+		if stamp, rest, ok := strings.Cut(text, " "); ok {
+			if t, err := time.Parse(time.RFC3339Nano, stamp); err == nil {
+				last, text = t, rest
+			}
+		}
+		lines = append(lines, LogLine{Container: container, Time: last, Text: text})
+	}
+
+	return lines
+}
+
+// FollowPodLogs streams the logs a container writes after since, calling
+// line for each of them, until ctx is cancelled or the stream ends, which
+// happens when the container stops or the connection drops. The log API
+// only takes since to the second, the earlier lines of that second are
+// skipped here.
+func FollowPodLogs(ctx context.Context, clientset kubernetes.Interface, namespace, podName, container string, since time.Time, line func(LogLine)) error {
+	opts := &corev1.PodLogOptions{
+		Container:  container,
+		Follow:     true,
+		Timestamps: true,
+	}
+	if !since.IsZero() {
+		sinceTime := metav1.NewTime(since)
+		opts.SinceTime = &sinceTime
+	}
+
+	stream, err := clientset.CoreV1().Pods(namespace).GetLogs(podName, opts).Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("error following pod logs: %v", err)
+	}
+	defer stream.Close()
+
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineBytes)
+	for scanner.Scan() {
+		for _, l := range parseLogLines(container, scanner.Bytes()) {
+			if l.Time.After(since) {
+				line(l)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("error following pod logs: %v", err)
+	}
+	return nil
+}
+
+// maxLogLineBytes is the longest log line FollowPodLogs reads
+const maxLogLineBytes = 1024 * 1024
+
+// StreamPodLogs copies the full logs of a container to w without holding them
+// in memory, returning the number of bytes written
+func StreamPodLogs(ctx context.Context, clientset kubernetes.Interface, namespace, podName, container string, previous bool, w io.Writer) (int64, error) {
+	opts := &corev1.PodLogOptions{
+		Container: container,
+		Previous:  previous,
+	}
+
+	stream, err := clientset.CoreV1().Pods(namespace).GetLogs(podName, opts).Stream(ctx)
+	if err != nil {
+		if previous && apierrors.IsBadRequest(err) {
+			return 0, ErrNoPreviousLogs
+		}
+		return 0, fmt.Errorf("error fetching pod logs: %v", err)
+	}
+	defer stream.Close()
+
+	return io.Copy(w, stream)
+}
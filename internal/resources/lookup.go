@@ -0,0 +1,33 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ResourceExists gets a pod or service by name, reporting false without an
+// error when there is none
+func ResourceExists(ctx context.Context, clientset kubernetes.Interface, kind ResourceKind, namespace, name string) (bool, error) {
+	var err error
+	switch kind {
+	case KindPod:
+		_, err = clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	case KindService:
+		_, err = clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	default:
+		return false, fmt.Errorf("unsupported kind %s", kind)
+	}
+
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error fetching %s: %v", strings.ToLower(string(kind)), err)
+	}
+	return true, nil
+}
@@ -0,0 +1,83 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsclient "k8s.io/metrics/pkg/client/clientset/versioned"
+)
+
+// MetricsServerURL is where to find installation instructions for metrics-server
+const MetricsServerURL = "https://github.com/kubernetes-sigs/metrics-server#installation"
+
+// ErrMetricsUnavailable is returned when the metrics API is not served,
+// usually because metrics-server is not installed
+var ErrMetricsUnavailable = errors.New("metrics API not available")
+
+// GetPodMetrics returns the current CPU and memory usage of a pod, summed
+// over its containers. It requires metrics-server to be installed.
+func GetPodMetrics(ctx context.Context, metrics *metricsclient.Clientset, namespace, podName string) (PodMetricsInfo, error) {
+	podMetrics, err := metrics.MetricsV1beta1().PodMetricses(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return PodMetricsInfo{}, fmt.Errorf("error fetching pod metrics: %v", err)
+	}
+
+	return podMetricsInfo(*podMetrics), nil
+}
+
+// GetPodMetricsList returns the current usage of every pod in the namespace,
+// sorted by name
+func GetPodMetricsList(ctx context.Context, metrics *metricsclient.Clientset, namespace string) ([]PodMetricsInfo, error) {
+	list, err := metrics.MetricsV1beta1().PodMetricses(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		// The aggregated API is missing altogether without metrics-server
+		if apierrors.IsNotFound(err) || apierrors.IsServiceUnavailable(err) {
+			return nil, ErrMetricsUnavailable
+		}
+		return nil, fmt.Errorf("error fetching pod metrics: %v", err)
+	}
+
+	infos := make([]PodMetricsInfo, 0, len(list.Items))
+	for _, podMetrics := range list.Items {
+		infos = append(infos, podMetricsInfo(podMetrics))
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+
+	return infos, nil
+}
+
+// SortPodMetrics orders pod metrics by CPU, or by memory when byMemory is
+// true, highest usage first
+func SortPodMetrics(infos []PodMetricsInfo, byMemory bool) error {
+	key := SortCPU
+	if byMemory {
+		key = SortMemory
+	}
+	return SortBy(infos, key, "", false)
+}
+
+// podMetricsInfo sums the usage of a pod over its containers
+func podMetricsInfo(podMetrics metricsv1beta1.PodMetrics) PodMetricsInfo {
+	info := PodMetricsInfo{
+		Name:      podMetrics.Name,
+		Namespace: podMetrics.Namespace,
+	}
+	for _, container := range podMetrics.Containers {
+		if cpu, ok := container.Usage[corev1.ResourceCPU]; ok {
+			info.CPUMilli += cpu.MilliValue()
+		}
+		if mem, ok := container.Usage[corev1.ResourceMemory]; ok {
+			info.MemoryBytes += mem.Value()
+		}
+	}
+	return info
+}
@@ -0,0 +1,91 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// GetNamespaces retrieves all namespaces with their phase and age
+func GetNamespaces(ctx context.Context, clientset kubernetes.Interface) ([]NamespaceInfo, error) {
+	var namespaces []NamespaceInfo
+
+	// Get namespace list from K8s API
+	nsList, err := clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching namespaces: %v", err)
+	}
+
+	for _, ns := range nsList.Items {
+		age := time.Since(ns.CreationTimestamp.Time).Round(time.Second)
+
+		namespaces = append(namespaces, NamespaceInfo{
+			Name:    ns.Name,
+			Status:  string(ns.Status.Phase),
+			Age:     FormatDuration(age),
+			Created: ns.CreationTimestamp.Time,
+		})
+	}
+
+	return namespaces, nil
+}
+
+// maxConcurrentPodCounts bounds the pod lists CountNamespacePods runs at once
+const maxConcurrentPodCounts = 8
+
+// CountNamespacePods counts the pods of each namespace by phase, listing the
+// namespaces concurrently. The lists are served from the API server cache,
+// the counts don't need to be exact. A namespace whose pods can't be listed
+// is left out.
+func CountNamespacePods(ctx context.Context, clientset kubernetes.Interface, namespaces []string) map[string]PodCounts {
+	var mu sync.Mutex
+	counts := make(map[string]PodCounts)
+
+	ForEach(namespaces, maxConcurrentPodCounts, func(_ int, namespace string) error {
+		list, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{ResourceVersion: "0"})
+		if err != nil {
+			return err
+		}
+		var c PodCounts
+		for _, pod := range list.Items {
+			c.Total++
+			switch pod.Status.Phase {
+			case corev1.PodPending:
+				c.Pending++
+			case corev1.PodFailed:
+				c.Failed++
+			}
+		}
+		mu.Lock()
+		counts[namespace] = c
+		mu.Unlock()
+		return nil
+	})
+
+	return counts
+}
+
+// Troubled reports whether some pods are pending or failed
+func (c PodCounts) Troubled() bool {
+	return c.Pending > 0 || c.Failed > 0
+}
+
+// String summarizes the counts, e.g. "24 pods, 2 pending"
+func (c PodCounts) String() string {
+	s := fmt.Sprintf("%d pods", c.Total)
+	if c.Total == 1 {
+		s = "1 pod"
+	}
+	if c.Pending > 0 {
+		s += fmt.Sprintf(", %d pending", c.Pending)
+	}
+	if c.Failed > 0 {
+		s += fmt.Sprintf(", %d failed", c.Failed)
+	}
+	return s
+}
@@ -0,0 +1,191 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// OpenShift resources, only served on OpenShift clusters. They are read with
+// the dynamic client so the OpenShift client libraries are not needed.
+var (
+	RouteResource = schema.GroupVersionResource{
+		Group:    "route.openshift.io",
+		Version:  "v1",
+		Resource: "routes",
+	}
+
+	ProjectResource = schema.GroupVersionResource{
+		Group:    "project.openshift.io",
+		Version:  "v1",
+		Resource: "projects",
+	}
+)
+
+// GetRoutes returns the OpenShift routes in the namespace, sorted by name
+func GetRoutes(ctx context.Context, client dynamic.Interface, namespace string) ([]RouteInfo, error) {
+	list, err := client.Resource(RouteResource).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching routes: %v", err)
+	}
+
+	routes := make([]RouteInfo, 0, len(list.Items))
+	for _, obj := range list.Items {
+		created := obj.GetCreationTimestamp().Time
+		host, _, _ := unstructured.NestedString(obj.Object, "spec", "host")
+		path, _, _ := unstructured.NestedString(obj.Object, "spec", "path")
+		termination, _, _ := unstructured.NestedString(obj.Object, "spec", "tls", "termination")
+
+		routes = append(routes, RouteInfo{
+			Name:      obj.GetName(),
+			Namespace: obj.GetNamespace(),
+			Host:      host,
+			Path:      path,
+			Service:   routeTarget(obj),
+			TLS:       termination,
+			Age:       FormatDuration(time.Since(created).Round(time.Second)),
+			Created:   created,
+		})
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		return routes[i].Name < routes[j].Name
+	})
+
+	return routes, nil
+}
+
+// GetRouteDetail returns the details of an OpenShift route: where it is
+// exposed, the services it sends traffic to and whether routers admitted it
+func GetRouteDetail(ctx context.Context, client dynamic.Interface, namespace, name string) (string, error) {
+	obj, err := client.Resource(RouteResource).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error fetching route details: %v", err)
+	}
+
+	host, _, _ := unstructured.NestedString(obj.Object, "spec", "host")
+	path, _, _ := unstructured.NestedString(obj.Object, "spec", "path")
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Route: %s\n", obj.GetName()))
+	sb.WriteString(fmt.Sprintf("Namespace: %s\n", obj.GetNamespace()))
+	sb.WriteString(fmt.Sprintf("Host: %s\n", valueOrNone(host)))
+	sb.WriteString(fmt.Sprintf("Path: %s\n", valueOrNone(path)))
+	sb.WriteString(fmt.Sprintf("Service: %s\n", valueOrNone(routeTarget(*obj))))
+	sb.WriteString(fmt.Sprintf("Created: %s\n", obj.GetCreationTimestamp().Format(time.RFC3339)))
+
+	// Traffic split between the main and the alternate backends
+	backends, _, _ := unstructured.NestedSlice(obj.Object, "spec", "alternateBackends")
+	if len(backends) > 0 {
+		sb.WriteString("\nAlternate Backends:\n")
+		for _, b := range backends {
+			backend, ok := b.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			svc, _, _ := unstructured.NestedString(backend, "name")
+			weight, found, _ := unstructured.NestedInt64(backend, "weight")
+			if found {
+				sb.WriteString(fmt.Sprintf("  - %s (weight %d)\n", svc, weight))
+			} else {
+				sb.WriteString(fmt.Sprintf("  - %s\n", svc))
+			}
+		}
+	}
+
+	sb.WriteString("\nTLS:\n")
+	if termination, _, _ := unstructured.NestedString(obj.Object, "spec", "tls", "termination"); termination == "" {
+		sb.WriteString("  Not secured\n")
+	} else {
+		insecure, _, _ := unstructured.NestedString(obj.Object, "spec", "tls", "insecureEdgeTerminationPolicy")
+		sb.WriteString(fmt.Sprintf("  Termination: %s\n", termination))
+		sb.WriteString(fmt.Sprintf("  Insecure Traffic: %s\n", valueOrNone(insecure)))
+	}
+
+	sb.WriteString("\nAdmission:\n")
+	ingresses, _, _ := unstructured.NestedSlice(obj.Object, "status", "ingress")
+	if len(ingresses) == 0 {
+		sb.WriteString("  Not admitted by any router\n")
+	}
+	for _, i := range ingresses {
+		ingress, ok := i.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		router, _, _ := unstructured.NestedString(ingress, "routerName")
+		conditions, _, _ := unstructured.NestedSlice(ingress, "conditions")
+		for _, c := range conditions {
+			condition, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if condType, _, _ := unstructured.NestedString(condition, "type"); condType != "Admitted" {
+				continue
+			}
+			status, _, _ := unstructured.NestedString(condition, "status")
+			sb.WriteString(fmt.Sprintf("  - %s: Admitted=%s", router, status))
+			if message, _, _ := unstructured.NestedString(condition, "message"); message != "" {
+				sb.WriteString(fmt.Sprintf(" (%s)", message))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// GetProjects returns the OpenShift projects the user can access. Unlike
+// namespaces, projects can be listed without cluster-wide permissions.
+func GetProjects(ctx context.Context, client dynamic.Interface) ([]NamespaceInfo, error) {
+	list, err := client.Resource(ProjectResource).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching projects: %v", err)
+	}
+
+	projects := make([]NamespaceInfo, 0, len(list.Items))
+	for _, obj := range list.Items {
+		created := obj.GetCreationTimestamp().Time
+		phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+
+		projects = append(projects, NamespaceInfo{
+			Name:    obj.GetName(),
+			Status:  phase,
+			Age:     FormatDuration(time.Since(created).Round(time.Second)),
+			Created: created,
+		})
+	}
+
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].Name < projects[j].Name
+	})
+
+	return projects, nil
+}
+
+// routeTarget returns the service a route sends traffic to, with the target
+// port when one is set, e.g. "frontend:8080"
+func routeTarget(obj unstructured.Unstructured) string {
+	service, _, _ := unstructured.NestedString(obj.Object, "spec", "to", "name")
+
+	// The port is either a name or a number
+	port, found, _ := unstructured.NestedFieldNoCopy(obj.Object, "spec", "port", "targetPort")
+	if found && port != nil {
+		return fmt.Sprintf("%s:%v", service, port)
+	}
+	return service
+}
+
+// valueOrNone returns value, or "<none>" when it is empty
+func valueOrNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
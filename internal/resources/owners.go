@@ -0,0 +1,126 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// maxOwnerDepth bounds the owner chains followed, a cycle of owner
+// references is invalid but nothing prevents creating one
+const maxOwnerDepth = 8
+
+// ownerIndex maps the ReplicaSets and Jobs of a namespace to their
+// controllers. They are the links between pods and the Deployments,
+// CronJobs or custom workloads users think in.
+type ownerIndex map[Workload]Workload
+
+// buildOwnerIndex lists the ReplicaSets and Jobs of the namespace, of every
+// namespace when it is empty
+func buildOwnerIndex(ctx context.Context, clientset kubernetes.Interface, namespace string) (ownerIndex, error) {
+	idx := make(ownerIndex)
+
+	replicaSets, err := clientset.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching replica sets: %v", err)
+	}
+	for _, rs := range replicaSets.Items {
+		idx.add(Workload{Kind: "ReplicaSet", Namespace: rs.Namespace, Name: rs.Name}, &rs.ObjectMeta)
+	}
+
+	jobs, err := clientset.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching jobs: %v", err)
+	}
+	for _, job := range jobs.Items {
+		idx.add(Workload{Kind: "Job", Namespace: job.Namespace, Name: job.Name}, &job.ObjectMeta)
+	}
+
+	return idx, nil
+}
+
+// add records the controller of an object, if it has one
+func (idx ownerIndex) add(w Workload, obj metav1.Object) {
+	if owner := metav1.GetControllerOf(obj); owner != nil {
+		idx[w] = Workload{Kind: owner.Kind, Namespace: w.Namespace, Name: owner.Name}
+	}
+}
+
+// chain returns the controllers of a pod from its direct owner up to the
+// top-level workload, empty for bare pods
+func (idx ownerIndex) chain(pod *corev1.Pod) []Workload {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return nil
+	}
+
+	w := Workload{Kind: owner.Kind, Namespace: pod.Namespace, Name: owner.Name}
+	chain := []Workload{w}
+	for len(chain) < maxOwnerDepth {
+		next, ok := idx[w]
+		if !ok {
+			break
+		}
+		chain = append(chain, next)
+		w = next
+	}
+	return chain
+}
+
+// GetPodWorkload returns the workload that ultimately controls a pod,
+// following its owner references through ReplicaSets and Jobs. The boolean
+// is false for bare and static pods.
+func GetPodWorkload(ctx context.Context, clientset kubernetes.Interface, namespace, name string) (Workload, bool, error) {
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return Workload{}, false, fmt.Errorf("error fetching pod: %v", err)
+	}
+	idx, err := buildOwnerIndex(ctx, clientset, namespace)
+	if err != nil {
+		return Workload{}, false, err
+	}
+
+	chain := idx.chain(pod)
+	if len(chain) == 0 {
+		return Workload{}, false, nil
+	}
+	return chain[len(chain)-1], true, nil
+}
+
+// GetWorkloadPods retrieves the pods of the namespace controlled by the
+// workload, directly or through the ReplicaSets and Jobs it owns. Pods
+// without an owner never belong to a workload.
+func GetWorkloadPods(ctx context.Context, clientset kubernetes.Interface, namespace, fieldSelector string, workload Workload) ([]PodInfo, error) {
+	podList, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching pods: %v", err)
+	}
+	idx, err := buildOwnerIndex(ctx, clientset, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	owned := podList.Items[:0]
+	for _, pod := range podList.Items {
+		for _, w := range idx.chain(&pod) {
+			if w == workload {
+				owned = append(owned, pod)
+				break
+			}
+		}
+	}
+	podList.Items = owned
+
+	return podInfos(podList), nil
+}
+
+// String returns the workload as kind/name, e.g. "deployment/web"
+func (w Workload) String() string {
+	return fmt.Sprintf("%s/%s", strings.ToLower(w.Kind), w.Name)
+}
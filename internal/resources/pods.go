@@ -8,253 +8,680 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/client-go/kubernetes"
 )
 
-// GetPods retrieves pods from the specified namespace
-func GetPods(clientset *kubernetes.Clientset, namespace string) ([]PodInfo, error) {
-	var pods []PodInfo
-
+// GetPods retrieves pods from the specified namespace. A non-empty
+// fieldSelector (e.g. "status.phase=Pending") is evaluated server-side.
+func GetPods(ctx context.Context, clientset kubernetes.Interface, namespace, fieldSelector string) ([]PodInfo, error) {
 	// Get pod list from K8s API
-	podList, err := clientset.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{})
+	podList, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("error fetching pods: %v", err)
 	}
 
+	return podInfos(podList), nil
+}
+
+// podInfos builds the PodInfo of every pod of a list
+func podInfos(podList *corev1.PodList) []PodInfo {
+	var pods []PodInfo
+
 	// Process each pod
 	for _, pod := range podList.Items {
 		// Calculate pod age
 		age := time.Since(pod.CreationTimestamp.Time).Round(time.Second)
 		ageStr := FormatDuration(age)
 
-		// Process container information
-		containers := make([]ContainerInfo, 0, len(pod.Spec.Containers))
+		// Process container information, init containers first like the API lists them
+		containers := make([]ContainerInfo, 0, len(pod.Spec.InitContainers)+len(pod.Spec.Containers))
+		for _, container := range pod.Spec.InitContainers {
+			containers = append(containers, containerInfo(container, pod.Status.InitContainerStatuses, true))
+		}
 		for _, container := range pod.Spec.Containers {
-			// Get container status
-			var ready bool
-			var state string
-			var restartCount int32
-
-			for _, status := range pod.Status.ContainerStatuses {
-				if status.Name == container.Name {
-					ready = status.Ready
-					restartCount = status.RestartCount
-
-					if status.State.Running != nil {
-						state = string(ContainerRunning)
-					} else if status.State.Waiting != nil {
-						state = string(ContainerWaiting)
-					} else if status.State.Terminated != nil {
-						state = string(ContainerTerminated)
-					}
-
-					break
-				}
-			}
-
-			// Process resource requests and limits
-			cpuRequest := ""
-			memRequest := ""
-			cpuLimit := ""
-			memLimit := ""
-
-			if container.Resources.Requests != nil {
-				if cpu, ok := container.Resources.Requests[corev1.ResourceCPU]; ok {
-					cpuRequest = cpu.String()
-				}
-				if mem, ok := container.Resources.Requests[corev1.ResourceMemory]; ok {
-					memRequest = mem.String()
-				}
-			}
-
-			if container.Resources.Limits != nil {
-				if cpu, ok := container.Resources.Limits[corev1.ResourceCPU]; ok {
-					cpuLimit = cpu.String()
-				}
-				if mem, ok := container.Resources.Limits[corev1.ResourceMemory]; ok {
-					memLimit = mem.String()
-				}
-			}
-
-			// Process environment variables
-			envVars := make(map[string]string)
-			for _, env := range container.Env {
-				if env.Value != "" {
-					envVars[env.Name] = env.Value
-				} else if env.ValueFrom != nil {
-					envVars[env.Name] = "[from source]"
-				}
-			}
+			containers = append(containers, containerInfo(container, pod.Status.ContainerStatuses, false))
+		}
 
-			// Create container info
-			containers = append(containers, ContainerInfo{
-				Name:            container.Name,
-				Image:           container.Image,
-				Ready:           ready,
-				RestartCount:    int(restartCount),
-				State:           state,
-				CPURequest:      cpuRequest,
-				MemoryRequest:   memRequest,
-				CPULimit:        cpuLimit,
-				MemoryLimit:     memLimit,
-				EnvironmentVars: envVars,
-			})
+		// A pod the scheduler can't place is stuck in Pending, say why
+		status := string(pod.Status.Phase)
+		statusMessage := ""
+		if reason, message, ok := unscheduledReason(&pod); ok {
+			status = reason
+			statusMessage = message
+		} else if init, ok := initStatus(&pod); ok {
+			status = init
+		} else if startingUp(&pod) {
+			status = PodStarting
 		}
 
 		// Create pod info
 		podInfo := PodInfo{
+			ResourceVersion: pod.ResourceVersion,
+
 			Name:       pod.Name,
 			Namespace:  pod.Namespace,
-			Status:     string(pod.Status.Phase),
+			Phase:      string(pod.Status.Phase),
+			Status:     status,
 			Age:        ageStr,
 			IP:         pod.Status.PodIP,
 			Node:       pod.Spec.NodeName,
 			Created:    pod.CreationTimestamp.Time,
 			Labels:     pod.Labels,
 			Containers: containers,
+			QOSClass:   string(podQOSClass(&pod)),
+
+			NominatedNode:  pod.Status.NominatedNodeName,
+			ReadinessGates: readinessGates(&pod),
+			OwnerKind:      ownerKind(&pod),
+
+			StatusMessage: statusMessage,
 		}
 
 		pods = append(pods, podInfo)
 	}
 
-	return pods, nil
+	return pods
+}
+
+// containerInfo builds the ContainerInfo of a container from its spec and the
+// matching entry in statuses
+func containerInfo(container corev1.Container, statuses []corev1.ContainerStatus, isInit bool) ContainerInfo {
+	// Get container status
+	var ready bool
+	var state, reason string
+	var restartCount int32
+
+	for _, status := range statuses {
+		if status.Name == container.Name {
+			ready = status.Ready
+			restartCount = status.RestartCount
+
+			if status.State.Running != nil {
+				state = string(ContainerRunning)
+			} else if status.State.Waiting != nil {
+				state = string(ContainerWaiting)
+				reason = status.State.Waiting.Reason
+			} else if status.State.Terminated != nil {
+				state = string(ContainerTerminated)
+			}
+
+			break
+		}
+	}
+
+	// Process resource requests and limits
+	cpuRequest := ""
+	memRequest := ""
+	cpuLimit := ""
+	memLimit := ""
+
+	if container.Resources.Requests != nil {
+		if cpu, ok := container.Resources.Requests[corev1.ResourceCPU]; ok {
+			cpuRequest = cpu.String()
+		}
+		if mem, ok := container.Resources.Requests[corev1.ResourceMemory]; ok {
+			memRequest = mem.String()
+		}
+	}
+
+	if container.Resources.Limits != nil {
+		if cpu, ok := container.Resources.Limits[corev1.ResourceCPU]; ok {
+			cpuLimit = cpu.String()
+		}
+		if mem, ok := container.Resources.Limits[corev1.ResourceMemory]; ok {
+			memLimit = mem.String()
+		}
+	}
+
+	// Process environment variables
+	envVars := make(map[string]string)
+	for _, env := range container.Env {
+		if env.Value != "" {
+			envVars[env.Name] = env.Value
+		} else if env.ValueFrom != nil {
+			envVars[env.Name] = "[from source]"
+		}
+	}
+
+	return ContainerInfo{
+		Name:            container.Name,
+		Image:           container.Image,
+		Ready:           ready,
+		RestartCount:    int(restartCount),
+		State:           state,
+		CPURequest:      cpuRequest,
+		MemoryRequest:   memRequest,
+		CPULimit:        cpuLimit,
+		MemoryLimit:     memLimit,
+		EnvironmentVars: envVars,
+		Reason:          reason,
+		IsInit:          isInit,
+	}
+}
+
+// initStatus returns the list status of a pod still running its init
+// containers, e.g. "Init:0/2" or "Init:CrashLoopBackOff", the way kubectl
+// reports it. ok is false once all init containers completed. A sidecar,
+// an init container restarted always, never completes: it is done once it
+// started.
+func initStatus(pod *corev1.Pod) (status string, ok bool) {
+	total := len(pod.Spec.InitContainers)
+	sidecars := make(map[string]bool)
+	for _, c := range pod.Spec.InitContainers {
+		if c.RestartPolicy != nil && *c.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			sidecars[c.Name] = true
+		}
+	}
+	for i, cs := range pod.Status.InitContainerStatuses {
+		switch {
+		case cs.State.Terminated != nil && cs.State.Terminated.ExitCode == 0:
+			continue
+		case sidecars[cs.Name] && (cs.Started != nil && *cs.Started || cs.State.Running != nil):
+			continue
+		case cs.State.Terminated != nil:
+			if cs.State.Terminated.Reason != "" {
+				return "Init:" + cs.State.Terminated.Reason, true
+			}
+			return fmt.Sprintf("Init:ExitCode:%d", cs.State.Terminated.ExitCode), true
+		case cs.State.Waiting != nil && cs.State.Waiting.Reason != "" && cs.State.Waiting.Reason != "PodInitializing":
+			return "Init:" + cs.State.Waiting.Reason, true
+		default:
+			return fmt.Sprintf("Init:%d/%d", i, total), true
+		}
+	}
+	return "", false
+}
+
+// PodStarting is the list status of a running pod whose startup probes
+// haven't passed yet, a slow start rather than a failure
+const PodStarting = "Starting"
+
+// startingUp reports whether a running pod has a container still gated by
+// its startup probe. A container waiting to restart, e.g. in
+// CrashLoopBackOff, is not starting up.
+func startingUp(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+	for _, container := range pod.Spec.Containers {
+		if container.StartupProbe == nil {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name == container.Name && status.State.Running != nil && status.Started != nil && !*status.Started {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// readinessGates returns how many of the pod's readiness gates passed, like
+// kubectl's READINESS GATES column, or "" when it has none
+func readinessGates(pod *corev1.Pod) string {
+	if len(pod.Spec.ReadinessGates) == 0 {
+		return ""
+	}
+
+	passed := 0
+	for _, gate := range pod.Spec.ReadinessGates {
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == gate.ConditionType && condition.Status == corev1.ConditionTrue {
+				passed++
+				break
+			}
+		}
+	}
+	return fmt.Sprintf("%d/%d", passed, len(pod.Spec.ReadinessGates))
+}
+
+// ownerKind returns the kind of the pod's controller, or "" when it has none
+func ownerKind(pod *corev1.Pod) string {
+	if owner := metav1.GetControllerOf(pod); owner != nil {
+		return owner.Kind
+	}
+	return ""
+}
+
+// IsCompletedJob reports whether the pod belongs to a Job and ran to
+// completion
+func (p PodInfo) IsCompletedJob() bool {
+	return p.Phase == string(corev1.PodSucceeded) && p.OwnerKind == "Job"
+}
+
+// podQOSClass returns the QoS class of a pod. The class reported in the status
+// is used when set, otherwise it is derived from the container resources.
+func podQOSClass(pod *corev1.Pod) corev1.PodQOSClass {
+	if pod.Status.QOSClass != "" {
+		return pod.Status.QOSClass
+	}
+
+	containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	return qosClass(containers)
+}
+
+// qosClass derives the QoS class of a set of containers. A pod is Guaranteed
+// when every container sets CPU and memory limits equal to its requests,
+// BestEffort when none sets any CPU or memory request or limit and Burstable
+// otherwise.
+func qosClass(containers []corev1.Container) corev1.PodQOSClass {
+	bestEffort, guaranteed := true, true
+	for _, container := range containers {
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			request, hasRequest := container.Resources.Requests[name]
+			limit, hasLimit := container.Resources.Limits[name]
+
+			if hasRequest || hasLimit {
+				bestEffort = false
+			}
+
+			// Requests default to the limit when only the limit is set
+			if !hasLimit || (hasRequest && request.Cmp(limit) != 0) {
+				guaranteed = false
+			}
+		}
+	}
+
+	switch {
+	case bestEffort:
+		return corev1.PodQOSBestEffort
+	case guaranteed:
+		return corev1.PodQOSGuaranteed
+	default:
+		return corev1.PodQOSBurstable
+	}
+}
+
+// unscheduledReason reports why the scheduler couldn't place a pod, based on
+// a False PodScheduled condition. ok is false when the pod was scheduled or
+// the scheduler hasn't looked at it yet.
+func unscheduledReason(pod *corev1.Pod) (reason, message string, ok bool) {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodScheduled && cond.Status == corev1.ConditionFalse {
+			reason = cond.Reason
+			if reason == "" {
+				reason = corev1.PodReasonUnschedulable
+			}
+			return reason, cond.Message, true
+		}
+	}
+	return "", "", false
+}
+
+// ValidateFieldSelector checks that a field selector is well formed
+func ValidateFieldSelector(selector string) error {
+	if _, err := fields.ParseSelector(selector); err != nil {
+		return fmt.Errorf("invalid field selector: %v", err)
+	}
+	return nil
 }
 
-// GetPodDetail returns detailed information about a specific pod
-func GetPodDetail(clientset *kubernetes.Clientset, namespace, podName string) (string, error) {
+// GetPodDetail returns detailed information about a specific pod. env tells
+// whether the values of environment variables set from ConfigMaps and Secrets
+// are fetched.
+func GetPodDetail(ctx context.Context, clientset kubernetes.Interface, namespace, podName string, env EnvMode) (Detail, error) {
 	// Get the pod from the API
-	pod, err := clientset.CoreV1().Pods(namespace).Get(context.TODO(), podName, metav1.GetOptions{})
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
 	if err != nil {
-		return "", fmt.Errorf("error fetching pod details: %v", err)
+		return Detail{}, fmt.Errorf("error fetching pod details: %v", err)
 	}
 
-	// Build the detail string
-	var sb strings.Builder
+	// Build the detail along with its fields
+	w := &detailWriter{}
 
 	// Basic pod information
-	sb.WriteString(fmt.Sprintf("Pod: %s\n", pod.Name))
-	sb.WriteString(fmt.Sprintf("Namespace: %s\n", pod.Namespace))
-	sb.WriteString(fmt.Sprintf("Status: %s\n", pod.Status.Phase))
-	sb.WriteString(fmt.Sprintf("IP: %s\n", pod.Status.PodIP))
-	sb.WriteString(fmt.Sprintf("Node: %s\n", pod.Spec.NodeName))
-	sb.WriteString(fmt.Sprintf("Created: %s\n", pod.CreationTimestamp.Format(time.RFC3339)))
+	w.field("", "Pod", pod.Name)
+	w.field("", "Namespace", pod.Namespace)
+	w.field("", "Status", string(pod.Status.Phase))
+	if reason, message, ok := unscheduledReason(pod); ok {
+		w.field("", "Scheduling", fmt.Sprintf("NOT SCHEDULED (%s)", reason))
+		w.flag("NOT SCHEDULED", false)
+		if message != "" {
+			w.field("", "Scheduler Message", message)
+		}
+	}
+	qos := podQOSClass(pod)
+	w.field("", "QoS Class", string(qos))
+	if qos == corev1.PodQOSBestEffort {
+		// The first pods evicted when the node runs short
+		w.flag(string(qos), false)
+	}
+	w.field("", "IP", pod.Status.PodIP)
+	w.field("", "Node", pod.Spec.NodeName)
+	w.field("", "Created", pod.CreationTimestamp.Format(time.RFC3339))
 
 	// Labels
 	if len(pod.Labels) > 0 {
-		sb.WriteString("\nLabels:\n")
-		for key, value := range pod.Labels {
-			sb.WriteString(fmt.Sprintf("  %s: %s\n", key, value))
+		w.line("\nLabels:")
+		for _, key := range sortedKeys(pod.Labels) {
+			w.field("  ", key, pod.Labels[key])
+		}
+	}
+
+	writeScheduling(w, pod.Spec)
+
+	// Init containers run to completion before the regular containers start
+	if len(pod.Spec.InitContainers) > 0 {
+		w.line("\nInit Containers:")
+		for _, container := range pod.Spec.InitContainers {
+			w.container = container.Name
+			w.item("  ", container.Name, "Image", container.Image)
+			writeContainerImage(w, container, pod.Status.InitContainerStatuses)
+			writeContainerStatus(w, pod.Status.InitContainerStatuses, container.Name)
 		}
+		w.container = ""
 	}
 
 	// Container details
-	sb.WriteString("\nContainers:\n")
+	w.line("\nContainers:")
 	for _, container := range pod.Spec.Containers {
-		sb.WriteString(fmt.Sprintf("  - %s (Image: %s)\n", container.Name, container.Image))
+		w.container = container.Name
+		w.containers = append(w.containers, container.Name)
+		w.item("  ", container.Name, "Image", container.Image)
+		writeContainerImage(w, container, pod.Status.ContainerStatuses)
 
 		// Resource requests and limits
 		if container.Resources.Requests != nil || container.Resources.Limits != nil {
-			sb.WriteString("    Resources:\n")
+			w.line("    Resources:")
 			if cpu, ok := container.Resources.Requests[corev1.ResourceCPU]; ok {
-				sb.WriteString(fmt.Sprintf("      CPU Request: %s\n", cpu.String()))
+				w.field("      ", "CPU Request", cpu.String())
 			}
 			if mem, ok := container.Resources.Requests[corev1.ResourceMemory]; ok {
-				sb.WriteString(fmt.Sprintf("      Memory Request: %s\n", mem.String()))
+				w.field("      ", "Memory Request", mem.String())
 			}
 			if cpu, ok := container.Resources.Limits[corev1.ResourceCPU]; ok {
-				sb.WriteString(fmt.Sprintf("      CPU Limit: %s\n", cpu.String()))
+				w.field("      ", "CPU Limit", cpu.String())
 			}
 			if mem, ok := container.Resources.Limits[corev1.ResourceMemory]; ok {
-				sb.WriteString(fmt.Sprintf("      Memory Limit: %s\n", mem.String()))
+				w.field("      ", "Memory Limit", mem.String())
 			}
 		}
 
-		// Container status
-		for _, status := range pod.Status.ContainerStatuses {
-			if status.Name == container.Name {
-				sb.WriteString(fmt.Sprintf("    Status:\n"))
-				sb.WriteString(fmt.Sprintf("      Ready: %v\n", status.Ready))
-				sb.WriteString(fmt.Sprintf("      Restart Count: %d\n", status.RestartCount))
-
-				if status.State.Running != nil {
-					sb.WriteString(fmt.Sprintf("      State: Running (started at %s)\n",
-						status.State.Running.StartedAt.Format(time.RFC3339)))
-				} else if status.State.Waiting != nil {
-					sb.WriteString(fmt.Sprintf("      State: Waiting (reason: %s)\n",
-						status.State.Waiting.Reason))
-					if status.State.Waiting.Message != "" {
-						sb.WriteString(fmt.Sprintf("      Message: %s\n", status.State.Waiting.Message))
-					}
-				} else if status.State.Terminated != nil {
-					sb.WriteString(fmt.Sprintf("      State: Terminated (reason: %s)\n",
-						status.State.Terminated.Reason))
-					if status.State.Terminated.Message != "" {
-						sb.WriteString(fmt.Sprintf("      Message: %s\n", status.State.Terminated.Message))
-					}
-				}
+		writeContainerStatus(w, pod.Status.ContainerStatuses, container.Name)
+	}
+	w.container = ""
 
-				break
-			}
-		}
+	// Probes, whose misconfiguration causes restarts and dropped traffic
+	w.line("\nProbes:")
+	for _, container := range pod.Spec.Containers {
+		w.container = container.Name
+		writeContainerProbes(w, container)
 	}
+	w.container = ""
 
 	// Environment variables
-	sb.WriteString("\nEnvironment Variables:\n")
+	w.line("\nEnvironment Variables:")
 	for _, container := range pod.Spec.Containers {
-		sb.WriteString(fmt.Sprintf("  %s:\n", container.Name))
-		if len(container.Env) == 0 {
-			sb.WriteString("    No environment variables defined\n")
-		} else {
-			for _, env := range container.Env {
-				if env.Value != "" {
-					sb.WriteString(fmt.Sprintf("    - %s: %s\n", env.Name, env.Value))
-				} else if env.ValueFrom != nil {
-					var source string
-					if env.ValueFrom.ConfigMapKeyRef != nil {
-						source = fmt.Sprintf("ConfigMap %s (key: %s)",
-							env.ValueFrom.ConfigMapKeyRef.Name, env.ValueFrom.ConfigMapKeyRef.Key)
-					} else if env.ValueFrom.SecretKeyRef != nil {
-						source = fmt.Sprintf("Secret %s (key: %s)",
-							env.ValueFrom.SecretKeyRef.Name, env.ValueFrom.SecretKeyRef.Key)
-					} else if env.ValueFrom.FieldRef != nil {
-						source = fmt.Sprintf("Field %s", env.ValueFrom.FieldRef.FieldPath)
-					} else {
-						source = "Unknown source"
-					}
-					sb.WriteString(fmt.Sprintf("    - %s: [from %s]\n", env.Name, source))
-				}
-			}
-		}
+		w.container = container.Name
+		w.line(fmt.Sprintf("  %s:", container.Name))
+		writeContainerEnv(ctx, w, clientset, pod.Namespace, container, env)
 	}
+	w.container = ""
 
 	// Volumes
 	if len(pod.Spec.Volumes) > 0 {
-		sb.WriteString("\nVolumes:\n")
+		w.line("\nVolumes:")
 		for _, volume := range pod.Spec.Volumes {
-			sb.WriteString(fmt.Sprintf("  - %s:\n", volume.Name))
+			w.line(fmt.Sprintf("  - %s:", volume.Name))
 
 			if volume.PersistentVolumeClaim != nil {
-				sb.WriteString(fmt.Sprintf("    Type: PersistentVolumeClaim\n"))
-				sb.WriteString(fmt.Sprintf("    Claim Name: %s\n", volume.PersistentVolumeClaim.ClaimName))
+				w.field("    ", "Type", "PersistentVolumeClaim")
+				w.field("    ", "Claim Name", volume.PersistentVolumeClaim.ClaimName)
 			} else if volume.ConfigMap != nil {
-				sb.WriteString(fmt.Sprintf("    Type: ConfigMap\n"))
-				sb.WriteString(fmt.Sprintf("    Name: %s\n", volume.ConfigMap.Name))
+				w.field("    ", "Type", "ConfigMap")
+				w.field("    ", "Name", volume.ConfigMap.Name)
 			} else if volume.Secret != nil {
-				sb.WriteString(fmt.Sprintf("    Type: Secret\n"))
-				sb.WriteString(fmt.Sprintf("    Secret Name: %s\n", volume.Secret.SecretName))
+				w.field("    ", "Type", "Secret")
+				w.field("    ", "Secret Name", volume.Secret.SecretName)
 			} else if volume.EmptyDir != nil {
-				sb.WriteString(fmt.Sprintf("    Type: EmptyDir\n"))
+				w.field("    ", "Type", "EmptyDir")
 			} else if volume.HostPath != nil {
-				sb.WriteString(fmt.Sprintf("    Type: HostPath\n"))
-				sb.WriteString(fmt.Sprintf("    Path: %s\n", volume.HostPath.Path))
+				w.field("    ", "Type", "HostPath")
+				w.field("    ", "Path", volume.HostPath.Path)
 			} else {
-				sb.WriteString(fmt.Sprintf("    Type: Other\n"))
+				w.field("    ", "Type", "Other")
 			}
 		}
 	}
 
 	// Events (would require additional API calls, simplified version here)
-	sb.WriteString("\nUse 'kubectl describe pod' for events and additional information\n")
+	w.line("\nUse 'kubectl describe pod' for events and additional information")
+
+	return w.detail(), nil
+}
+
+// maxDetailEnv is how many environment variables of a container the detail
+// lists. ConfigMaps with thousands of keys would drown the rest of it.
+const maxDetailEnv = 100
+
+// writeContainerEnv writes the environment variables of a container. Unless
+// mode is EnvSources the ConfigMaps and Secrets they come from are fetched to
+// show their values, falling back to naming the sources when that fails.
+// Only the first maxDetailEnv variables are listed and long values are cut.
+func writeContainerEnv(ctx context.Context, w *detailWriter, clientset kubernetes.Interface, namespace string, container corev1.Container, mode EnvMode) {
+	if mode != EnvSources {
+		vars, err := resolveEnv(ctx, clientset, namespace, container, mode == EnvRevealed)
+		if err == nil {
+			if len(vars) == 0 {
+				w.line("    No environment variables defined")
+			}
+			for i, v := range vars {
+				if i == maxDetailEnv {
+					writeEnvOmitted(w, len(vars)-i)
+					break
+				}
+				if v.Source != "" {
+					w.field("    - ", v.Name, fmt.Sprintf("%s [from %s]", capValue(v.Value), v.Source))
+					if v.Value == EnvSourceMissing || v.Value == EnvKeyMissing {
+						w.flag(v.Value, true)
+					}
+				} else {
+					w.field("    - ", v.Name, capValue(v.Value))
+				}
+			}
+			return
+		}
+		w.field("    ", "Could not resolve values", err.Error())
+	}
+
+	if len(container.Env) == 0 {
+		w.line("    No environment variables defined")
+		return
+	}
+	for i, env := range container.Env {
+		if i == maxDetailEnv {
+			writeEnvOmitted(w, len(container.Env)-i)
+			break
+		}
+		if env.Value != "" {
+			w.field("    - ", env.Name, capValue(env.Value))
+		} else if env.ValueFrom != nil {
+			w.field("    - ", env.Name, fmt.Sprintf("[from %s]", envValueSource(env.ValueFrom)))
+		}
+	}
+}
+
+// writeEnvOmitted notes the environment variables left out of the detail,
+// all of them are in the env list the help line gives the key of
+func writeEnvOmitted(w *detailWriter, n int) {
+	w.line(fmt.Sprintf("    ... %d more not shown, see the env list for all of them", n))
+}
+
+// Notes flagging images that may not be what was tested, highlighted in the
+// detail view
+const (
+	MutableTagNote = "[mutable tag]"
+	PullAlwaysNote = "[pulled on every start]"
+)
 
-	return sb.String(), nil
+// writeContainerImage writes the tag, pull policy and running digest of a
+// container's image, flagging the latest tag and the Always pull policy
+func writeContainerImage(w *detailWriter, container corev1.Container, statuses []corev1.ContainerStatus) {
+	tag, digest := ImageTag(container.Image)
+	switch {
+	case digest != "":
+		w.field("    ", "Tag", fmt.Sprintf("%s (pinned to %s)", tag, digest))
+	case tag == "latest":
+		w.field("    ", "Tag", tag+" "+MutableTagNote)
+		w.flag(MutableTagNote, false)
+	default:
+		w.field("    ", "Tag", tag)
+	}
+
+	if container.ImagePullPolicy == corev1.PullAlways {
+		w.field("    ", "Pull Policy", fmt.Sprintf("%s %s", container.ImagePullPolicy, PullAlwaysNote))
+		w.flag(PullAlwaysNote, false)
+	} else if container.ImagePullPolicy != "" {
+		w.field("    ", "Pull Policy", string(container.ImagePullPolicy))
+	}
+
+	// The image ID tells what is actually running, e.g.
+	// "docker.io/library/nginx@sha256:..."
+	for _, status := range statuses {
+		if status.Name != container.Name || status.ImageID == "" {
+			continue
+		}
+		running := status.ImageID
+		if i := strings.LastIndex(running, "@"); i >= 0 {
+			running = running[i+1:]
+		}
+		w.field("    ", "Running Digest", running)
+	}
+}
+
+// ImageTag returns the tag of an image reference, "latest" when it has none,
+// and its digest when it is pinned to one
+func ImageTag(image string) (tag, digest string) {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image, digest = image[:i], image[i+1:]
+	}
+
+	// A colon before the last slash belongs to a registry port
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[i+1:], digest
+	}
+	return "latest", digest
+}
+
+// writeContainerStatus writes the status of the named container, if reported
+func writeContainerStatus(w *detailWriter, statuses []corev1.ContainerStatus, name string) {
+	for _, status := range statuses {
+		if status.Name != name {
+			continue
+		}
+
+		w.line("    Status:")
+		w.field("      ", "Ready", fmt.Sprintf("%v", status.Ready))
+		w.field("      ", "Restart Count", fmt.Sprintf("%d", status.RestartCount))
+
+		if status.State.Running != nil {
+			w.field("      ", "State", fmt.Sprintf("Running (started at %s)",
+				status.State.Running.StartedAt.Format(time.RFC3339)))
+		} else if status.State.Waiting != nil {
+			w.field("      ", "State", fmt.Sprintf("Waiting (reason: %s)",
+				status.State.Waiting.Reason))
+			if status.State.Waiting.Message != "" {
+				w.field("      ", "Message", status.State.Waiting.Message)
+			}
+		} else if status.State.Terminated != nil {
+			writeTermination(w, "State", status.State.Terminated)
+		}
+
+		// Why the previous instance of a restarted container died
+		if last := status.LastTerminationState.Terminated; last != nil {
+			writeTermination(w, "Last State", last)
+		}
+
+		return
+	}
+}
+
+// OOMKilledNote flags a container killed for exceeding its memory limit,
+// highlighted in the detail view
+const OOMKilledNote = "[out of memory]"
+
+// writeTermination writes how a container terminated: its reason, exit code
+// and the signal that killed it, if any
+func writeTermination(w *detailWriter, label string, t *corev1.ContainerStateTerminated) {
+	reason := t.Reason
+	if reason == "" {
+		reason = "none"
+	}
+	state := fmt.Sprintf("Terminated (reason: %s, exit code: %d", reason, t.ExitCode)
+	if t.Signal != 0 {
+		state += fmt.Sprintf(", signal: %d", t.Signal)
+	}
+	state += ")"
+	if t.Reason == "OOMKilled" {
+		state += " " + OOMKilledNote
+	}
+	w.field("      ", label, state)
+	if t.Reason == "OOMKilled" {
+		w.flag(OOMKilledNote, true)
+	}
+
+	if meaning := exitCodeMeaning(t.ExitCode); meaning != "" {
+		w.field("        ", "Exit Code Meaning", meaning)
+	}
+	if !t.FinishedAt.IsZero() {
+		w.field("        ", "Finished", t.FinishedAt.Format(time.RFC3339))
+	}
+	if t.Message != "" {
+		w.field("        ", "Message", t.Message)
+	}
+}
+
+// exitCodeMeaning explains the common exit codes of containers, empty for
+// the others. Codes above 128 are the signal that killed the process plus
+// 128.
+func exitCodeMeaning(code int32) string {
+	switch code {
+	case 0:
+		return ""
+	case 1:
+		return "application error"
+	case 126:
+		return "command not executable"
+	case 127:
+		return "command not found"
+	case 128 + 9:
+		return "killed by SIGKILL, often for running out of memory"
+	case 128 + 15:
+		return "terminated by SIGTERM"
+	}
+	if code > 128 && code < 128+65 {
+		return fmt.Sprintf("killed by signal %d", code-128)
+	}
+	return ""
+}
+
+// DeletePod deletes the specified pod
+func DeletePod(ctx context.Context, clientset kubernetes.Interface, namespace, podName string) error {
+	err := clientset.CoreV1().Pods(namespace).Delete(ctx, podName, metav1.DeleteOptions{})
+	if err != nil {
+		return fmt.Errorf("error deleting pod: %v", err)
+	}
+	return nil
+}
+
+// maxConcurrentDeletes bounds the deletions DeletePods runs at once
+const maxConcurrentDeletes = 5
+
+// DeletePods deletes the named pods concurrently and returns the error of
+// every pod that could not be deleted, keyed by name
+func DeletePods(ctx context.Context, clientset kubernetes.Interface, namespace string, names []string) map[string]error {
+	errs := ForEach(names, maxConcurrentDeletes, func(_ int, name string) error {
+		return DeletePod(ctx, clientset, namespace, name)
+	})
+
+	failed := make(map[string]error)
+	for i, err := range errs {
+		if err != nil {
+			failed[names[i]] = err
+		}
+	}
+	return failed
 }
@@ -0,0 +1,354 @@
+package resources
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestWriteTermination(t *testing.T) {
+	finished := metav1.NewTime(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name     string
+		state    corev1.ContainerStateTerminated
+		want     []string
+		dontWant []string
+	}{
+		{
+			name: "killed by a signal",
+			state: corev1.ContainerStateTerminated{
+				Reason:     "Error",
+				ExitCode:   137,
+				Signal:     9,
+				FinishedAt: finished,
+				Message:    "stopped",
+			},
+			want: []string{
+				"      State: Terminated (reason: Error, exit code: 137, signal: 9)\n",
+				"        Exit Code Meaning: killed by SIGKILL, often for running out of memory\n",
+				"        Finished: 2024-05-01T12:00:00Z\n",
+				"        Message: stopped\n",
+			},
+			dontWant: []string{OOMKilledNote},
+		},
+		{
+			name:  "out of memory",
+			state: corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137},
+			want: []string{
+				"      State: Terminated (reason: OOMKilled, exit code: 137) " + OOMKilledNote + "\n",
+			},
+			dontWant: []string{"signal:", "Finished:", "Message:"},
+		},
+		{
+			name:     "completed",
+			state:    corev1.ContainerStateTerminated{Reason: "Completed"},
+			want:     []string{"      State: Terminated (reason: Completed, exit code: 0)\n"},
+			dontWant: []string{"Exit Code Meaning", OOMKilledNote},
+		},
+		{
+			name:  "no reason",
+			state: corev1.ContainerStateTerminated{ExitCode: 1},
+			want: []string{
+				"(reason: none, exit code: 1)",
+				"Exit Code Meaning: application error",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &detailWriter{}
+			writeTermination(w, "State", &tt.state)
+			got := w.sb.String()
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("missing %q in:\n%s", want, got)
+				}
+			}
+			for _, dontWant := range tt.dontWant {
+				if strings.Contains(got, dontWant) {
+					t.Errorf("unexpected %q in:\n%s", dontWant, got)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteContainerStatusLastState(t *testing.T) {
+	statuses := []corev1.ContainerStatus{{
+		Name:         "app",
+		Ready:        true,
+		RestartCount: 3,
+		State: corev1.ContainerState{
+			Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(time.Date(2024, 5, 1, 12, 5, 0, 0, time.UTC))},
+		},
+		LastTerminationState: corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137},
+		},
+	}}
+
+	w := &detailWriter{}
+	writeContainerStatus(w, statuses, "app")
+	got := w.sb.String()
+
+	for _, want := range []string{
+		"      Restart Count: 3\n",
+		"      State: Running (started at 2024-05-01T12:05:00Z)\n",
+		"      Last State: Terminated (reason: OOMKilled, exit code: 137) " + OOMKilledNote + "\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, "Last State") < strings.Index(got, "State: Running") {
+		t.Errorf("last state written before the current one:\n%s", got)
+	}
+}
+
+// resourceList parses cpu and memory quantities, leaving out the empty ones
+func resourceList(cpu, memory string) corev1.ResourceList {
+	list := corev1.ResourceList{}
+	if cpu != "" {
+		list[corev1.ResourceCPU] = resource.MustParse(cpu)
+	}
+	if memory != "" {
+		list[corev1.ResourceMemory] = resource.MustParse(memory)
+	}
+	return list
+}
+
+func TestPodQOSClass(t *testing.T) {
+	guaranteed := corev1.Container{Name: "app", Resources: corev1.ResourceRequirements{
+		Requests: resourceList("100m", "128Mi"),
+		Limits:   resourceList("100m", "128Mi"),
+	}}
+	limitsOnly := corev1.Container{Name: "app", Resources: corev1.ResourceRequirements{
+		Limits: resourceList("100m", "128Mi"),
+	}}
+	requestsOnly := corev1.Container{Name: "app", Resources: corev1.ResourceRequirements{
+		Requests: resourceList("100m", "128Mi"),
+	}}
+	lowerRequest := corev1.Container{Name: "app", Resources: corev1.ResourceRequirements{
+		Requests: resourceList("50m", "128Mi"),
+		Limits:   resourceList("100m", "128Mi"),
+	}}
+	memoryOnly := corev1.Container{Name: "app", Resources: corev1.ResourceRequirements{
+		Requests: resourceList("", "128Mi"),
+		Limits:   resourceList("", "128Mi"),
+	}}
+	none := corev1.Container{Name: "app"}
+
+	tests := []struct {
+		name           string
+		init           []corev1.Container
+		containers     []corev1.Container
+		reported, want corev1.PodQOSClass
+	}{
+		{name: "limits equal to requests", containers: []corev1.Container{guaranteed}, want: corev1.PodQOSGuaranteed},
+		{name: "requests default to limits", containers: []corev1.Container{limitsOnly}, want: corev1.PodQOSGuaranteed},
+		{name: "request below limit", containers: []corev1.Container{lowerRequest}, want: corev1.PodQOSBurstable},
+		{name: "requests without limits", containers: []corev1.Container{requestsOnly}, want: corev1.PodQOSBurstable},
+		{name: "memory only", containers: []corev1.Container{memoryOnly}, want: corev1.PodQOSBurstable},
+		{name: "one container without resources", containers: []corev1.Container{guaranteed, none}, want: corev1.PodQOSBurstable},
+		{name: "no resources", containers: []corev1.Container{none, none}, want: corev1.PodQOSBestEffort},
+		{name: "init container with resources", init: []corev1.Container{requestsOnly}, containers: []corev1.Container{none}, want: corev1.PodQOSBurstable},
+		{name: "init container without resources", init: []corev1.Container{none}, containers: []corev1.Container{guaranteed}, want: corev1.PodQOSBurstable},
+		{name: "guaranteed init container", init: []corev1.Container{guaranteed}, containers: []corev1.Container{guaranteed}, want: corev1.PodQOSGuaranteed},
+		{name: "reported by the status", containers: []corev1.Container{none}, reported: corev1.PodQOSGuaranteed, want: corev1.PodQOSGuaranteed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				Spec:   corev1.PodSpec{InitContainers: tt.init, Containers: tt.containers},
+				Status: corev1.PodStatus{QOSClass: tt.reported},
+			}
+			if got := podQOSClass(pod); got != tt.want {
+				t.Errorf("podQOSClass() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInitStatus(t *testing.T) {
+	done := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Completed"}}
+	running := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	waiting := func(reason string) corev1.ContainerState {
+		return corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason}}
+	}
+
+	tests := []struct {
+		name  string
+		init  []corev1.ContainerState
+		phase corev1.PodPhase
+		want  string
+
+		// sidecar restarts the first init container always
+		sidecar bool
+	}{
+		{"failed with a reason", []corev1.ContainerState{
+			{Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1}}, waiting("PodInitializing"),
+		}, corev1.PodPending, "Init:Error", false},
+		{"failed without a reason", []corev1.ContainerState{
+			done, {Terminated: &corev1.ContainerStateTerminated{ExitCode: 2}},
+		}, corev1.PodPending, "Init:ExitCode:2", false},
+		{"crash looping", []corev1.ContainerState{waiting("CrashLoopBackOff"), waiting("PodInitializing")}, corev1.PodPending, "Init:CrashLoopBackOff", false},
+		{"first running", []corev1.ContainerState{running, waiting("PodInitializing")}, corev1.PodPending, "Init:0/2", false},
+		{"second running", []corev1.ContainerState{done, running}, corev1.PodPending, "Init:1/2", false},
+		{"initializing", []corev1.ContainerState{waiting("PodInitializing"), waiting("PodInitializing")}, corev1.PodPending, "Init:0/2", false},
+		{"all completed", []corev1.ContainerState{done, done}, corev1.PodRunning, "Running", false},
+		{"sidecar running", []corev1.ContainerState{running, done}, corev1.PodRunning, "Running", true},
+		{"sidecar running, second running", []corev1.ContainerState{running, running}, corev1.PodPending, "Init:1/2", true},
+		{"sidecar crash looping", []corev1.ContainerState{waiting("CrashLoopBackOff"), waiting("PodInitializing")}, corev1.PodPending, "Init:CrashLoopBackOff", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "shop"},
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{{Name: "migrate"}, {Name: "seed"}},
+					Containers:     []corev1.Container{{Name: "app"}},
+				},
+				Status: corev1.PodStatus{Phase: tt.phase},
+			}
+			if tt.sidecar {
+				always := corev1.ContainerRestartPolicyAlways
+				pod.Spec.InitContainers[0].RestartPolicy = &always
+			}
+			for i, state := range tt.init {
+				pod.Status.InitContainerStatuses = append(pod.Status.InitContainerStatuses,
+					corev1.ContainerStatus{Name: pod.Spec.InitContainers[i].Name, State: state})
+			}
+
+			pods := podInfos(&corev1.PodList{Items: []corev1.Pod{pod}})
+			if len(pods) != 1 {
+				t.Fatalf("got %d pods, want 1", len(pods))
+			}
+			if pods[0].Status != tt.want {
+				t.Errorf("status = %q, want %q", pods[0].Status, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPodDetailFields(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "shop", Labels: map[string]string{"app": "web"}},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "migrate", Image: "migrate:1.0"}},
+			Containers: []corev1.Container{
+				{Name: "app", Image: "web:1.2", Env: []corev1.EnvVar{{Name: "MODE", Value: "prod"}}},
+				{Name: "proxy", Image: "envoy:1.30", Env: []corev1.EnvVar{{Name: "PORT", Value: "8080"}}},
+			},
+			Volumes: []corev1.Volume{{Name: "cache", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.7"},
+	}
+	clientset := fake.NewClientset(pod)
+
+	detail, err := GetPodDetail(context.Background(), clientset, "shop", "web-0", EnvSources)
+	if err != nil {
+		t.Fatalf("GetPodDetail: %v", err)
+	}
+
+	if want := []string{"app", "proxy"}; !slices.Equal(detail.Containers, want) {
+		t.Errorf("containers = %q, want %q", detail.Containers, want)
+	}
+
+	// The fields written are the ones read back from the text, now along
+	// with their container
+	lines := strings.Split(detail.Text, "\n")
+	parsed := DetailFields(detail.Text)
+	if len(detail.Fields) != len(parsed) {
+		t.Fatalf("got %d fields, %d in the text", len(detail.Fields), len(parsed))
+	}
+	for i, field := range detail.Fields {
+		if p := parsed[i]; field.Key != p.Key || field.Value != p.Value || field.Line != p.Line {
+			t.Errorf("field %d = %s: %s on line %d, the text has %s: %s on line %d", i, field.Key, field.Value, field.Line, p.Key, p.Value, p.Line)
+		}
+		if !strings.Contains(lines[field.Line], field.Key+": "+field.Value) {
+			t.Errorf("line %d = %q, want field %s: %s", field.Line, lines[field.Line], field.Key, field.Value)
+		}
+	}
+
+	containers := make(map[string]string)
+	for _, field := range detail.Fields {
+		containers[field.Key+"="+field.Value] = field.Container
+	}
+	for field, want := range map[string]string{
+		"Pod=web-0":         "",
+		"app=web":           "",
+		"Image=migrate:1.0": "migrate",
+		"Image=web:1.2":     "app",
+		"MODE=prod":         "app",
+		"PORT=8080":         "proxy",
+		"Type=EmptyDir":     "",
+	} {
+		if got, ok := containers[field]; !ok || got != want {
+			t.Errorf("container of %s = %q (found %v), want %q", field, got, ok, want)
+		}
+	}
+}
+
+func TestGetPodDetailLabelOrder(t *testing.T) {
+	labels := map[string]string{"tier": "web", "app": "shop", "zone": "b", "release": "blue", "team": "core"}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "shop", Labels: labels}}
+	clientset := fake.NewClientset(pod)
+
+	// Maps range in a random order, the labels must not move between
+	// refreshes
+	var keys []string
+	for range 10 {
+		detail, err := GetPodDetail(context.Background(), clientset, "shop", "web-0", EnvSources)
+		if err != nil {
+			t.Fatalf("GetPodDetail: %v", err)
+		}
+		keys = keys[:0]
+		for _, field := range detail.Fields {
+			if _, ok := labels[field.Key]; ok {
+				keys = append(keys, field.Key)
+			}
+		}
+		if want := []string{"app", "release", "team", "tier", "zone"}; !slices.Equal(keys, want) {
+			t.Fatalf("labels = %q, want %q", keys, want)
+		}
+	}
+}
+
+func TestGetPodDetailFlags(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "shop", Labels: map[string]string{"note": "BestEffort"}},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app", Image: "web:latest", ImagePullPolicy: corev1.PullAlways}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning, QOSClass: corev1.PodQOSBestEffort},
+	}
+	clientset := fake.NewClientset(pod)
+
+	detail, err := GetPodDetail(context.Background(), clientset, "shop", "web-0", EnvSources)
+	if err != nil {
+		t.Fatalf("GetPodDetail: %v", err)
+	}
+
+	// Only the spans written as flags, not the label holding BestEffort
+	lines := strings.Split(detail.Text, "\n")
+	var flagged []string
+	for _, f := range detail.Flags {
+		flagged = append(flagged, lines[f.Line][f.Start:f.End])
+		if strings.HasPrefix(strings.TrimSpace(lines[f.Line]), "note:") {
+			t.Errorf("label line flagged: %q", lines[f.Line])
+		}
+	}
+	if want := []string{"BestEffort", MutableTagNote, PullAlwaysNote, NoProbesNote}; !slices.Equal(flagged, want) {
+		t.Errorf("flagged %q, want %q", flagged, want)
+	}
+}
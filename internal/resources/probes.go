@@ -0,0 +1,65 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// NoProbesNote flags a container without any probe, whose failures go
+// unnoticed and which gets traffic as soon as it starts. Highlighted in the
+// detail view.
+const NoProbesNote = "[no probes]"
+
+// writeContainerProbes writes the liveness, readiness and startup probes of
+// a container, noting the ones it lacks
+func writeContainerProbes(w *detailWriter, container corev1.Container) {
+	w.line(fmt.Sprintf("  %s:", container.Name))
+	if container.LivenessProbe == nil && container.ReadinessProbe == nil && container.StartupProbe == nil {
+		w.line("    None " + NoProbesNote)
+		w.flag(NoProbesNote, false)
+		return
+	}
+
+	probes := []struct {
+		name  string
+		probe *corev1.Probe
+	}{
+		{"Liveness", container.LivenessProbe},
+		{"Readiness", container.ReadinessProbe},
+		{"Startup", container.StartupProbe},
+	}
+	for _, p := range probes {
+		if p.probe == nil {
+			w.field("    ", p.name, "none")
+			continue
+		}
+		w.field("    ", p.name, fmt.Sprintf("%s (delay %ds, period %ds, timeout %ds, failure threshold %d)",
+			probeAction(p.probe.ProbeHandler),
+			p.probe.InitialDelaySeconds, p.probe.PeriodSeconds, p.probe.TimeoutSeconds, p.probe.FailureThreshold))
+	}
+}
+
+// probeAction describes what a probe checks, e.g. "http GET :8080/healthz"
+func probeAction(h corev1.ProbeHandler) string {
+	switch {
+	case h.HTTPGet != nil:
+		scheme := "http"
+		if h.HTTPGet.Scheme == corev1.URISchemeHTTPS {
+			scheme = "https"
+		}
+		return fmt.Sprintf("%s GET %s:%s%s", scheme, h.HTTPGet.Host, h.HTTPGet.Port.String(), h.HTTPGet.Path)
+	case h.TCPSocket != nil:
+		return fmt.Sprintf("tcp %s:%s", h.TCPSocket.Host, h.TCPSocket.Port.String())
+	case h.GRPC != nil:
+		action := fmt.Sprintf("grpc :%d", h.GRPC.Port)
+		if h.GRPC.Service != nil && *h.GRPC.Service != "" {
+			action += " service " + *h.GRPC.Service
+		}
+		return action
+	case h.Exec != nil:
+		return "exec " + strings.Join(h.Exec.Command, " ")
+	}
+	return "unknown"
+}
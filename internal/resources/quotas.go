@@ -0,0 +1,86 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// QuotaPressure is the share of a quota dimension above which it is
+// reported, new objects are refused once it reaches 1
+const QuotaPressure = 0.9
+
+// GetResourceQuotas retrieves the resource quotas of a namespace with their
+// hard limits and current usage, sorted by name
+func GetResourceQuotas(ctx context.Context, clientset kubernetes.Interface, namespace string) ([]ResourceQuotaInfo, error) {
+	list, err := clientset.CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching resource quotas: %v", err)
+	}
+
+	quotas := make([]ResourceQuotaInfo, 0, len(list.Items))
+	for _, quota := range list.Items {
+		quotas = append(quotas, ResourceQuotaInfo{
+			Name:       quota.Name,
+			Namespace:  quota.Namespace,
+			Dimensions: quotaDimensions(quota.Status),
+			Age:        FormatDuration(time.Since(quota.CreationTimestamp.Time).Round(time.Second)),
+			Created:    quota.CreationTimestamp.Time,
+		})
+	}
+	sort.Slice(quotas, func(i, j int) bool { return quotas[i].Name < quotas[j].Name })
+
+	return quotas, nil
+}
+
+// quotaDimensions returns the usage of every hard limit of a quota, sorted
+// by resource name
+func quotaDimensions(status corev1.ResourceQuotaStatus) []QuotaDimension {
+	dims := make([]QuotaDimension, 0, len(status.Hard))
+	for name, hard := range status.Hard {
+		used := status.Used[name]
+
+		// A zero limit forbids the resource on purpose, it is only worth
+		// reporting when something still uses it
+		var ratio float64
+		switch {
+		case !hard.IsZero():
+			ratio = float64(used.MilliValue()) / float64(hard.MilliValue())
+		case !used.IsZero():
+			ratio = 1
+		}
+
+		dims = append(dims, QuotaDimension{
+			Resource: string(name),
+			Used:     used.String(),
+			Hard:     hard.String(),
+			Ratio:    ratio,
+		})
+	}
+	sort.Slice(dims, func(i, j int) bool { return dims[i].Resource < dims[j].Resource })
+	return dims
+}
+
+// UnderPressure reports whether the dimension is used above QuotaPressure
+func (d QuotaDimension) UnderPressure() bool {
+	return d.Ratio >= QuotaPressure
+}
+
+// QuotasUnderPressure describes the dimensions of the quotas used above
+// QuotaPressure, e.g. "compute/pods 10/10"
+func QuotasUnderPressure(quotas []ResourceQuotaInfo) []string {
+	var pressured []string
+	for _, quota := range quotas {
+		for _, d := range quota.Dimensions {
+			if d.UnderPressure() {
+				pressured = append(pressured, fmt.Sprintf("%s/%s %s/%s", quota.Name, d.Resource, d.Used, d.Hard))
+			}
+		}
+	}
+	return pressured
+}
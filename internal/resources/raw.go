@@ -0,0 +1,178 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+	"sigs.k8s.io/yaml"
+)
+
+// MaxRawItems bounds the objects a raw list fetches, it is meant for a look
+// at resources without a view, not for dumping a cluster
+const MaxRawItems = 500
+
+// RawQuery is a kubectl get style request for any resource the API server
+// serves, see ParseRawQuery
+type RawQuery struct {
+	// Resource is the type as typed: plural, singular, short name or kind,
+	// optionally qualified by its group, e.g. "deploy" or "ingresses.networking.k8s.io"
+	Resource string
+
+	// Name is the object to get, the whole list when empty
+	Name string
+
+	// JSON asks for JSON instead of YAML
+	JSON bool
+}
+
+// ParseRawQuery reads "get <type>[/<name>]", "get <type> <name>" with an
+// optional "-o yaml" or "-o json", the leading get being optional
+func ParseRawQuery(s string) (RawQuery, error) {
+	fields := strings.Fields(s)
+	if len(fields) > 0 && fields[0] == "get" {
+		fields = fields[1:]
+	}
+
+	var q RawQuery
+	var args []string
+	for i := 0; i < len(fields); i++ {
+		switch f := fields[i]; {
+		case f == "-o" && i+1 < len(fields):
+			i++
+			if err := q.setOutput(fields[i]); err != nil {
+				return RawQuery{}, err
+			}
+		case strings.HasPrefix(f, "-o"):
+			if err := q.setOutput(strings.TrimPrefix(strings.TrimPrefix(f, "-o"), "=")); err != nil {
+				return RawQuery{}, err
+			}
+		default:
+			args = append(args, f)
+		}
+	}
+
+	switch len(args) {
+	case 0:
+		return RawQuery{}, fmt.Errorf("no resource type, e.g. get configmaps or get deploy/web")
+	case 1:
+		q.Resource, q.Name, _ = strings.Cut(args[0], "/")
+	case 2:
+		if strings.Contains(args[0], "/") {
+			return RawQuery{}, fmt.Errorf("expected <type>/<name> or <type> <name>, not both")
+		}
+		q.Resource, q.Name = args[0], args[1]
+	default:
+		return RawQuery{}, fmt.Errorf("only one resource can be fetched at a time")
+	}
+	if q.Resource == "" {
+		return RawQuery{}, fmt.Errorf("no resource type before %q", "/"+q.Name)
+	}
+	return q, nil
+}
+
+// setOutput sets the format of the query from the value of -o
+func (q *RawQuery) setOutput(format string) error {
+	switch format {
+	case "yaml":
+		q.JSON = false
+	case "json":
+		q.JSON = true
+	default:
+		return fmt.Errorf("unsupported output %q, expected yaml or json", format)
+	}
+	return nil
+}
+
+// String returns the query in the kubectl form it was read from
+func (q RawQuery) String() string {
+	s := "get " + q.Resource
+	if q.Name != "" {
+		s += "/" + q.Name
+	}
+	if q.JSON {
+		s += " -o json"
+	}
+	return s
+}
+
+// GetRaw resolves the resource type of a query through discovery and
+// fetches the object or the list, without managed fields, as YAML or JSON.
+// Namespaced types are read from namespace. Secrets are refused when
+// hideSecrets is set.
+func GetRaw(ctx context.Context, client dynamic.Interface, disc discovery.CachedDiscoveryInterface, namespace string, q RawQuery, hideSecrets bool) (RawResult, error) {
+	mapper := restmapper.NewShortcutExpander(restmapper.NewDeferredDiscoveryRESTMapper(disc), disc, nil)
+
+	gvk, err := resolveKind(mapper, strings.ToLower(q.Resource))
+	if meta.IsNoMatchError(err) {
+		return RawResult{}, fmt.Errorf("the server doesn't have a resource type %q", q.Resource)
+	}
+	if err != nil {
+		return RawResult{}, fmt.Errorf("error discovering resource types: %v", err)
+	}
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return RawResult{}, fmt.Errorf("error discovering resource types: %v", err)
+	}
+	result := RawResult{Kind: ResourceKind(gvk.Kind)}
+	if hideSecrets && gvk.Group == "" && gvk.Kind == string(KindSecret) {
+		return result, fmt.Errorf("secrets are hidden by --hide-secrets")
+	}
+
+	resource := dynamic.ResourceInterface(client.Resource(mapping.Resource))
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		resource = client.Resource(mapping.Resource).Namespace(namespace)
+	}
+
+	var content any
+	if q.Name != "" {
+		obj, err := resource.Get(ctx, q.Name, metav1.GetOptions{})
+		if err != nil {
+			return result, fmt.Errorf("error fetching %s %s: %v", mapping.Resource.Resource, q.Name, err)
+		}
+		obj.SetManagedFields(nil)
+		content = obj.Object
+	} else {
+		list, err := resource.List(ctx, metav1.ListOptions{Limit: MaxRawItems})
+		if err != nil {
+			return result, fmt.Errorf("error fetching %s: %v", mapping.Resource.Resource, err)
+		}
+		for i := range list.Items {
+			list.Items[i].SetManagedFields(nil)
+		}
+		result.Truncated = list.GetContinue() != ""
+		content = list.UnstructuredContent()
+	}
+
+	var out []byte
+	if q.JSON {
+		out, err = json.MarshalIndent(content, "", "  ")
+		out = append(out, '\n')
+	} else {
+		out, err = yaml.Marshal(content)
+	}
+	if err != nil {
+		return result, fmt.Errorf("error encoding %s: %v", mapping.Resource.Resource, err)
+	}
+	result.Content = string(out)
+	return result, nil
+}
+
+// resolveKind finds the kind of a resource type as typed, which may name
+// its version and group, e.g. "deployments.v1.apps", or only its group
+func resolveKind(mapper meta.RESTMapper, resource string) (schema.GroupVersionKind, error) {
+	fullySpecified, groupResource := schema.ParseResourceArg(resource)
+	if fullySpecified != nil {
+		if gvk, err := mapper.KindFor(*fullySpecified); err == nil {
+			return gvk, nil
+		}
+	}
+	return mapper.KindFor(groupResource.WithVersion(""))
+}
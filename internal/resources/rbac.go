@@ -0,0 +1,464 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// GetServiceAccounts retrieves the service accounts of a namespace
+func GetServiceAccounts(ctx context.Context, clientset kubernetes.Interface, namespace string) ([]ServiceAccountInfo, error) {
+	saList, err := clientset.CoreV1().ServiceAccounts(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching service accounts: %v", err)
+	}
+
+	accounts := make([]ServiceAccountInfo, 0, len(saList.Items))
+	for _, sa := range saList.Items {
+		accounts = append(accounts, ServiceAccountInfo{
+			Name:      sa.Name,
+			Namespace: sa.Namespace,
+			Secrets:   len(sa.Secrets),
+			Age:       FormatDuration(time.Since(sa.CreationTimestamp.Time).Round(time.Second)),
+			Created:   sa.CreationTimestamp.Time,
+		})
+	}
+
+	return accounts, nil
+}
+
+// GetServiceAccountDetail returns detailed information about a service
+// account, including the bindings that grant it permissions and their rules
+func GetServiceAccountDetail(ctx context.Context, clientset kubernetes.Interface, namespace, name string) (string, error) {
+	sa, err := clientset.CoreV1().ServiceAccounts(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error fetching service account details: %v", err)
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("ServiceAccount: %s\n", sa.Name))
+	sb.WriteString(fmt.Sprintf("Namespace: %s\n", sa.Namespace))
+	sb.WriteString(fmt.Sprintf("Created: %s\n", sa.CreationTimestamp.Format(time.RFC3339)))
+	if sa.AutomountServiceAccountToken != nil {
+		sb.WriteString(fmt.Sprintf("Automount Token: %t\n", *sa.AutomountServiceAccountToken))
+	}
+
+	if len(sa.Secrets) > 0 {
+		sb.WriteString("\nSecrets:\n")
+		for _, secret := range sa.Secrets {
+			sb.WriteString(fmt.Sprintf("  - %s\n", secret.Name))
+		}
+	}
+	if len(sa.ImagePullSecrets) > 0 {
+		sb.WriteString("\nImage Pull Secrets:\n")
+		for _, secret := range sa.ImagePullSecrets {
+			sb.WriteString(fmt.Sprintf("  - %s\n", secret.Name))
+		}
+	}
+
+	sb.WriteString("\nBindings:\n")
+
+	// Listing bindings needs cluster-wide access, the rest of the detail is
+	// still useful without it
+	bindings, err := bindingsFor(ctx, clientset, rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: sa.Name, Namespace: sa.Namespace})
+	if err != nil {
+		sb.WriteString(fmt.Sprintf("  %v\n", err))
+	} else if len(bindings) == 0 {
+		sb.WriteString("  No role bindings\n")
+	}
+	for _, b := range bindings {
+		sb.WriteString(fmt.Sprintf("  - %s %s -> %s %s\n", b.kind, qualifiedName(b.namespace, b.name), b.roleRef.Kind, b.roleRef.Name))
+		if err := writeRoleRules(ctx, clientset, &sb, b.namespace, b.roleRef, "    "); err != nil {
+			return "", err
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// GetRoles retrieves the roles of a namespace followed by the cluster roles
+func GetRoles(ctx context.Context, clientset kubernetes.Interface, namespace string) ([]RoleInfo, error) {
+	roleList, err := clientset.RbacV1().Roles(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching roles: %v", err)
+	}
+	clusterRoleList, err := clientset.RbacV1().ClusterRoles().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching cluster roles: %v", err)
+	}
+
+	roles := make([]RoleInfo, 0, len(roleList.Items)+len(clusterRoleList.Items))
+	for _, role := range roleList.Items {
+		roles = append(roles, roleInfo(KindRole, role.ObjectMeta, len(role.Rules)))
+	}
+	for _, role := range clusterRoleList.Items {
+		roles = append(roles, roleInfo(KindClusterRole, role.ObjectMeta, len(role.Rules)))
+	}
+
+	return roles, nil
+}
+
+// roleInfo builds the list entry of a Role or ClusterRole
+func roleInfo(kind ResourceKind, meta metav1.ObjectMeta, rules int) RoleInfo {
+	return RoleInfo{
+		Kind:      kind,
+		Name:      meta.Name,
+		Namespace: meta.Namespace,
+		Rules:     rules,
+		Age:       FormatDuration(time.Since(meta.CreationTimestamp.Time).Round(time.Second)),
+		Created:   meta.CreationTimestamp.Time,
+	}
+}
+
+// GetRoleDetail returns the rules of a Role, or of a ClusterRole when kind is
+// KindClusterRole
+func GetRoleDetail(ctx context.Context, clientset kubernetes.Interface, kind ResourceKind, namespace, name string) (string, error) {
+	var meta metav1.ObjectMeta
+	var rules []rbacv1.PolicyRule
+
+	if kind == KindClusterRole {
+		role, err := clientset.RbacV1().ClusterRoles().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("error fetching cluster role details: %v", err)
+		}
+		meta, rules = role.ObjectMeta, role.Rules
+	} else {
+		role, err := clientset.RbacV1().Roles(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("error fetching role details: %v", err)
+		}
+		meta, rules = role.ObjectMeta, role.Rules
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("%s: %s\n", kind, meta.Name))
+	if meta.Namespace != "" {
+		sb.WriteString(fmt.Sprintf("Namespace: %s\n", meta.Namespace))
+	}
+	sb.WriteString(fmt.Sprintf("Created: %s\n", meta.CreationTimestamp.Format(time.RFC3339)))
+
+	sb.WriteString("\nRules:\n")
+	writeRules(&sb, rules, "  ")
+
+	return sb.String(), nil
+}
+
+// GetRoleBindings retrieves the role bindings of a namespace followed by the
+// cluster role bindings
+func GetRoleBindings(ctx context.Context, clientset kubernetes.Interface, namespace string) ([]RoleBindingInfo, error) {
+	bindingList, err := clientset.RbacV1().RoleBindings(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching role bindings: %v", err)
+	}
+	clusterBindingList, err := clientset.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching cluster role bindings: %v", err)
+	}
+
+	bindings := make([]RoleBindingInfo, 0, len(bindingList.Items)+len(clusterBindingList.Items))
+	for _, b := range bindingList.Items {
+		bindings = append(bindings, roleBindingInfo(KindRoleBinding, b.ObjectMeta, b.RoleRef, b.Subjects))
+	}
+	for _, b := range clusterBindingList.Items {
+		bindings = append(bindings, roleBindingInfo(KindClusterRoleBinding, b.ObjectMeta, b.RoleRef, b.Subjects))
+	}
+
+	return bindings, nil
+}
+
+// roleBindingInfo builds the list entry of a RoleBinding or ClusterRoleBinding
+func roleBindingInfo(kind ResourceKind, meta metav1.ObjectMeta, roleRef rbacv1.RoleRef, subjects []rbacv1.Subject) RoleBindingInfo {
+	names := make([]string, 0, len(subjects))
+	for _, s := range subjects {
+		names = append(names, subjectName(s))
+	}
+
+	return RoleBindingInfo{
+		Kind:      kind,
+		Name:      meta.Name,
+		Namespace: meta.Namespace,
+		Role:      roleRef.Kind + "/" + roleRef.Name,
+		Subjects:  names,
+		Age:       FormatDuration(time.Since(meta.CreationTimestamp.Time).Round(time.Second)),
+		Created:   meta.CreationTimestamp.Time,
+	}
+}
+
+// GetRoleBindingDetail returns the subjects of a RoleBinding, or of a
+// ClusterRoleBinding when kind is KindClusterRoleBinding, together with the
+// rules the referenced role grants them
+func GetRoleBindingDetail(ctx context.Context, clientset kubernetes.Interface, kind ResourceKind, namespace, name string) (string, error) {
+	var meta metav1.ObjectMeta
+	var roleRef rbacv1.RoleRef
+	var subjects []rbacv1.Subject
+
+	if kind == KindClusterRoleBinding {
+		b, err := clientset.RbacV1().ClusterRoleBindings().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("error fetching cluster role binding details: %v", err)
+		}
+		meta, roleRef, subjects = b.ObjectMeta, b.RoleRef, b.Subjects
+	} else {
+		b, err := clientset.RbacV1().RoleBindings(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("error fetching role binding details: %v", err)
+		}
+		meta, roleRef, subjects = b.ObjectMeta, b.RoleRef, b.Subjects
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("%s: %s\n", kind, meta.Name))
+	scope := "all namespaces"
+	if meta.Namespace != "" {
+		sb.WriteString(fmt.Sprintf("Namespace: %s\n", meta.Namespace))
+		scope = "namespace " + meta.Namespace
+	}
+	sb.WriteString(fmt.Sprintf("Created: %s\n", meta.CreationTimestamp.Format(time.RFC3339)))
+	sb.WriteString(fmt.Sprintf("Role: %s %s\n", roleRef.Kind, roleRef.Name))
+
+	sb.WriteString("\nSubjects:\n")
+	if len(subjects) == 0 {
+		sb.WriteString("  No subjects\n")
+	}
+	for _, s := range subjects {
+		sb.WriteString(fmt.Sprintf("  - %s\n", subjectName(s)))
+	}
+
+	sb.WriteString(fmt.Sprintf("\nGranted in %s:\n", scope))
+	if err := writeRoleRules(ctx, clientset, &sb, meta.Namespace, roleRef, "  "); err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
+
+// binding is a RoleBinding or ClusterRoleBinding, namespace is empty for
+// cluster role bindings
+type binding struct {
+	kind      ResourceKind
+	name      string
+	namespace string
+	roleRef   rbacv1.RoleRef
+	subjects  []rbacv1.Subject
+}
+
+// listBindings returns the role bindings of every namespace and the cluster
+// role bindings
+func listBindings(ctx context.Context, clientset kubernetes.Interface) ([]binding, error) {
+	bindingList, err := clientset.RbacV1().RoleBindings(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching role bindings: %v", err)
+	}
+	clusterBindingList, err := clientset.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching cluster role bindings: %v", err)
+	}
+
+	bindings := make([]binding, 0, len(bindingList.Items)+len(clusterBindingList.Items))
+	for _, b := range clusterBindingList.Items {
+		bindings = append(bindings, binding{KindClusterRoleBinding, b.Name, "", b.RoleRef, b.Subjects})
+	}
+	for _, b := range bindingList.Items {
+		bindings = append(bindings, binding{KindRoleBinding, b.Name, b.Namespace, b.RoleRef, b.Subjects})
+	}
+
+	return bindings, nil
+}
+
+// bindingsFor returns the bindings that name the subject
+func bindingsFor(ctx context.Context, clientset kubernetes.Interface, subject rbacv1.Subject) ([]binding, error) {
+	all, err := listBindings(ctx, clientset)
+	if err != nil {
+		return nil, err
+	}
+
+	var bindings []binding
+	for _, b := range all {
+		for _, s := range b.subjects {
+			if s.Kind == subject.Kind && s.Name == subject.Name && s.Namespace == subject.Namespace {
+				bindings = append(bindings, b)
+				break
+			}
+		}
+	}
+
+	return bindings, nil
+}
+
+// WhoCan returns the subjects allowed to perform verb on resource in the
+// namespace, through cluster role bindings or role bindings of the namespace.
+// resource may be qualified with its API group, e.g. "deployments.apps", and
+// matches any group otherwise. Rules limited to resource names are ignored.
+func WhoCan(ctx context.Context, clientset kubernetes.Interface, verb, resource, namespace string) ([]Subject, error) {
+	resource, group, hasGroup := strings.Cut(resource, ".")
+
+	all, err := listBindings(ctx, clientset)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bindings often share roles, fetch each one once
+	rulesByRole := make(map[string][]rbacv1.PolicyRule)
+
+	var subjects []Subject
+	for _, b := range all {
+		if b.namespace != "" && b.namespace != namespace {
+			continue
+		}
+
+		roleKey := b.roleRef.Kind + "/" + b.namespace + "/" + b.roleRef.Name
+		rules, ok := rulesByRole[roleKey]
+		if !ok {
+			rules, err = roleRules(ctx, clientset, b.namespace, b.roleRef)
+			if err != nil && !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("error fetching %s %s: %v", strings.ToLower(b.roleRef.Kind), b.roleRef.Name, err)
+			}
+			rulesByRole[roleKey] = rules
+		}
+
+		if !rulesAllow(rules, verb, resource, group, hasGroup) {
+			continue
+		}
+		for _, s := range b.subjects {
+			subjects = append(subjects, Subject{
+				Kind:      s.Kind,
+				Name:      s.Name,
+				Namespace: s.Namespace,
+				Binding:   string(b.kind) + "/" + b.name,
+				Role:      b.roleRef.Kind + "/" + b.roleRef.Name,
+			})
+		}
+	}
+
+	sort.SliceStable(subjects, func(i, j int) bool {
+		if subjects[i].Kind != subjects[j].Kind {
+			return subjects[i].Kind < subjects[j].Kind
+		}
+		return qualifiedName(subjects[i].Namespace, subjects[i].Name) < qualifiedName(subjects[j].Namespace, subjects[j].Name)
+	})
+
+	return subjects, nil
+}
+
+// rulesAllow reports whether any rule grants verb on resource in group.
+// The group is only compared when hasGroup is set.
+func rulesAllow(rules []rbacv1.PolicyRule, verb, resource, group string, hasGroup bool) bool {
+	for _, rule := range rules {
+		if len(rule.ResourceNames) > 0 {
+			continue
+		}
+		if !matchesRule(rule.Verbs, verb) || !matchesRule(rule.Resources, resource) {
+			continue
+		}
+		if hasGroup && !matchesRule(rule.APIGroups, group) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// matchesRule reports whether a rule field lists value or the "*" wildcard
+func matchesRule(values []string, value string) bool {
+	for _, v := range values {
+		if v == rbacv1.ResourceAll || v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// roleRules returns the rules of the role a binding refers to. namespace is
+// the namespace of the binding, roles are resolved in it.
+func roleRules(ctx context.Context, clientset kubernetes.Interface, namespace string, roleRef rbacv1.RoleRef) ([]rbacv1.PolicyRule, error) {
+	if roleRef.Kind == string(KindClusterRole) {
+		role, err := clientset.RbacV1().ClusterRoles().Get(ctx, roleRef.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return role.Rules, nil
+	}
+
+	role, err := clientset.RbacV1().Roles(namespace).Get(ctx, roleRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return role.Rules, nil
+}
+
+// writeRoleRules writes the rules of the role a binding refers to, noting
+// roles that don't exist
+func writeRoleRules(ctx context.Context, clientset kubernetes.Interface, sb *strings.Builder, namespace string, roleRef rbacv1.RoleRef, indent string) error {
+	rules, err := roleRules(ctx, clientset, namespace, roleRef)
+	if apierrors.IsNotFound(err) {
+		sb.WriteString(fmt.Sprintf("%s%s %s not found\n", indent, roleRef.Kind, roleRef.Name))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error fetching %s %s: %v", strings.ToLower(roleRef.Kind), roleRef.Name, err)
+	}
+
+	writeRules(sb, rules, indent)
+	return nil
+}
+
+// writeRules writes policy rules, one per line, as "verbs on resources"
+func writeRules(sb *strings.Builder, rules []rbacv1.PolicyRule, indent string) {
+	if len(rules) == 0 {
+		sb.WriteString(indent + "No rules\n")
+		return
+	}
+	for _, rule := range rules {
+		sb.WriteString(indent + "- " + formatRule(rule) + "\n")
+	}
+}
+
+// formatRule describes a policy rule, e.g. "get, list on pods, pods/log"
+func formatRule(rule rbacv1.PolicyRule) string {
+	verbs := strings.Join(rule.Verbs, ", ")
+
+	if len(rule.NonResourceURLs) > 0 {
+		return fmt.Sprintf("%s on URLs %s", verbs, strings.Join(rule.NonResourceURLs, ", "))
+	}
+
+	targets := make([]string, 0, len(rule.Resources))
+	for _, resource := range rule.Resources {
+		for _, group := range rule.APIGroups {
+			if group != "" {
+				resource += "." + group
+				break
+			}
+		}
+		targets = append(targets, resource)
+	}
+	sort.Strings(targets)
+
+	desc := fmt.Sprintf("%s on %s", verbs, strings.Join(targets, ", "))
+	if len(rule.ResourceNames) > 0 {
+		desc += fmt.Sprintf(" (only %s)", strings.Join(rule.ResourceNames, ", "))
+	}
+	return desc
+}
+
+// subjectName describes a binding subject, e.g. "ServiceAccount kube-system/default"
+func subjectName(s rbacv1.Subject) string {
+	return s.Kind + " " + qualifiedName(s.Namespace, s.Name)
+}
+
+// qualifiedName prefixes a name with its namespace when it has one
+func qualifiedName(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
@@ -0,0 +1,137 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// revisionAnnotation holds the rollout revision of a ReplicaSet
+	revisionAnnotation = "deployment.kubernetes.io/revision"
+
+	// changeCauseAnnotation records why a revision was rolled out
+	changeCauseAnnotation = "kubernetes.io/change-cause"
+)
+
+// GetRolloutHistory lists the revisions of a Deployment, newest first, like
+// kubectl rollout history: the ReplicaSets it controls with their revision,
+// images and replicas
+func GetRolloutHistory(ctx context.Context, clientset kubernetes.Interface, namespace, name string) ([]RevisionInfo, error) {
+	deployment, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching deployment: %v", err)
+	}
+	replicaSets, err := deploymentReplicaSets(ctx, clientset, deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	current := deployment.Annotations[revisionAnnotation]
+	var revisions []RevisionInfo
+	for revision, rs := range replicaSets {
+		var images []string
+		for _, c := range rs.Spec.Template.Spec.Containers {
+			images = append(images, c.Image)
+		}
+		replicas := int32(0)
+		if rs.Spec.Replicas != nil {
+			replicas = *rs.Spec.Replicas
+		}
+		created := rs.CreationTimestamp.Time
+		revisions = append(revisions, RevisionInfo{
+			Revision:      revision,
+			ReplicaSet:    rs.Name,
+			Namespace:     rs.Namespace,
+			Images:        images,
+			Replicas:      replicas,
+			ReadyReplicas: rs.Status.ReadyReplicas,
+			Current:       rs.Annotations[revisionAnnotation] == current,
+			ChangeCause:   rs.Annotations[changeCauseAnnotation],
+			Age:           FormatDuration(time.Since(created).Round(time.Second)),
+			Created:       created,
+		})
+	}
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].Revision > revisions[j].Revision })
+
+	return revisions, nil
+}
+
+// deploymentReplicaSets returns the ReplicaSets a Deployment controls, by
+// revision
+func deploymentReplicaSets(ctx context.Context, clientset kubernetes.Interface, deployment *appsv1.Deployment) (map[int64]*appsv1.ReplicaSet, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing selector of deployment %s: %v", deployment.Name, err)
+	}
+	list, err := clientset.AppsV1().ReplicaSets(deployment.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching replica sets: %v", err)
+	}
+
+	replicaSets := make(map[int64]*appsv1.ReplicaSet)
+	for i := range list.Items {
+		rs := &list.Items[i]
+		// The selector may match ReplicaSets of other Deployments
+		if owner := metav1.GetControllerOf(rs); owner == nil || owner.UID != deployment.UID {
+			continue
+		}
+		revision, err := strconv.ParseInt(rs.Annotations[revisionAnnotation], 10, 64)
+		if err != nil {
+			continue
+		}
+		replicaSets[revision] = rs
+	}
+	return replicaSets, nil
+}
+
+// RollbackDeployment rolls a Deployment back to a revision, like kubectl
+// rollout undo: the pod template of the revision's ReplicaSet replaces the
+// Deployment's, which starts a new rollout. The ReplicaSets of old
+// revisions are pruned beyond the Deployment's revisionHistoryLimit, those
+// revisions can't be rolled back to.
+func RollbackDeployment(ctx context.Context, clientset kubernetes.Interface, namespace, name string, revision int64) error {
+	deployment, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error fetching deployment: %v", err)
+	}
+	if deployment.Annotations[revisionAnnotation] == strconv.FormatInt(revision, 10) {
+		return fmt.Errorf("deployment %s already runs revision %d", name, revision)
+	}
+	replicaSets, err := deploymentReplicaSets(ctx, clientset, deployment)
+	if err != nil {
+		return err
+	}
+	rs, ok := replicaSets[revision]
+	if !ok {
+		return fmt.Errorf("revision %d of deployment %s no longer exists, its replica set was pruned", revision, name)
+	}
+
+	// The hash label is added by the Deployment controller to tell its
+	// ReplicaSets apart, it is not part of the template
+	template := rs.Spec.Template.DeepCopy()
+	delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+
+	// Replacing the template as a whole keeps what the revision didn't
+	// have from being merged back in
+	patch, err := json.Marshal([]map[string]any{
+		{"op": "test", "path": "/metadata/resourceVersion", "value": deployment.ResourceVersion},
+		{"op": "replace", "path": "/spec/template", "value": template},
+	})
+	if err != nil {
+		return fmt.Errorf("error encoding rollback: %v", err)
+	}
+	_, err = clientset.AppsV1().Deployments(namespace).Patch(ctx, name, types.JSONPatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("error rolling back deployment: %v", err)
+	}
+	return nil
+}
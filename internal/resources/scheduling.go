@@ -0,0 +1,174 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// writeScheduling writes the constraints on where a pod may run: its node
+// selector, node and pod (anti-)affinity and tolerations. Affinity terms are
+// summarized one per line, required ones before preferred ones with their
+// weight. Nothing is written for a pod without constraints.
+func writeScheduling(w *detailWriter, spec corev1.PodSpec) {
+	var lines []schedulingLine
+
+	if len(spec.NodeSelector) > 0 {
+		lines = append(lines, schedulingLine{"  ", "Node Selector", FormatSelector(spec.NodeSelector)})
+	}
+
+	if a := spec.Affinity; a != nil {
+		if na := a.NodeAffinity; na != nil {
+			var terms []schedulingLine
+			if req := na.RequiredDuringSchedulingIgnoredDuringExecution; req != nil {
+				var alternatives []string
+				for _, term := range req.NodeSelectorTerms {
+					alternatives = append(alternatives, formatNodeSelectorTerm(term))
+				}
+				terms = append(terms, schedulingLine{key: "required", value: strings.Join(alternatives, " or ")})
+			}
+			for _, pref := range na.PreferredDuringSchedulingIgnoredDuringExecution {
+				terms = append(terms, schedulingLine{key: fmt.Sprintf("preferred (weight %d)", pref.Weight), value: formatNodeSelectorTerm(pref.Preference)})
+			}
+			lines = appendTerms(lines, "Node Affinity", terms)
+		}
+		if pa := a.PodAffinity; pa != nil {
+			lines = appendTerms(lines, "Pod Affinity", podAffinityTerms(pa.RequiredDuringSchedulingIgnoredDuringExecution, pa.PreferredDuringSchedulingIgnoredDuringExecution))
+		}
+		if paa := a.PodAntiAffinity; paa != nil {
+			lines = appendTerms(lines, "Pod Anti-Affinity", podAffinityTerms(paa.RequiredDuringSchedulingIgnoredDuringExecution, paa.PreferredDuringSchedulingIgnoredDuringExecution))
+		}
+	}
+
+	if len(spec.Tolerations) > 0 {
+		lines = append(lines, schedulingLine{text: "  Tolerations:"})
+		for _, t := range spec.Tolerations {
+			lines = append(lines, schedulingLine{text: "    - " + formatToleration(t)})
+		}
+	}
+
+	if len(lines) == 0 {
+		return
+	}
+	w.line("\nScheduling Constraints:")
+	for _, line := range lines {
+		if line.key != "" {
+			w.field(line.text, line.key, line.value)
+		} else {
+			w.line(line.text)
+		}
+	}
+}
+
+// schedulingLine is a line of the scheduling constraints, a field with its
+// indent as text or only text
+type schedulingLine struct {
+	text       string
+	key, value string
+}
+
+// appendTerms adds a titled list of affinity terms, nothing when empty
+func appendTerms(lines []schedulingLine, title string, terms []schedulingLine) []schedulingLine {
+	if len(terms) == 0 {
+		return lines
+	}
+	lines = append(lines, schedulingLine{text: "  " + title + ":"})
+	for _, term := range terms {
+		term.text = "    "
+		lines = append(lines, term)
+	}
+	return lines
+}
+
+// podAffinityTerms summarizes the required and preferred terms of a pod
+// affinity or anti-affinity
+func podAffinityTerms(required []corev1.PodAffinityTerm, preferred []corev1.WeightedPodAffinityTerm) []schedulingLine {
+	var terms []schedulingLine
+	for _, term := range required {
+		terms = append(terms, schedulingLine{key: "required", value: formatPodAffinityTerm(term)})
+	}
+	for _, pref := range preferred {
+		terms = append(terms, schedulingLine{key: fmt.Sprintf("preferred (weight %d)", pref.Weight), value: formatPodAffinityTerm(pref.PodAffinityTerm)})
+	}
+	return terms
+}
+
+// formatPodAffinityTerm describes the pods a term selects and the topology
+// they are counted in, e.g. "app=web per kubernetes.io/hostname"
+func formatPodAffinityTerm(term corev1.PodAffinityTerm) string {
+	s := metav1.FormatLabelSelector(term.LabelSelector)
+	if len(term.Namespaces) > 0 {
+		s += " in " + strings.Join(term.Namespaces, ",")
+	}
+	if term.NamespaceSelector != nil {
+		s += " in namespaces " + metav1.FormatLabelSelector(term.NamespaceSelector)
+	}
+	return s + " per " + term.TopologyKey
+}
+
+// formatNodeSelectorTerm joins the requirements of a node selector term,
+// which must all hold, like a label selector, e.g. "zone in (a,b),!gpu"
+func formatNodeSelectorTerm(term corev1.NodeSelectorTerm) string {
+	var reqs []string
+	for _, r := range term.MatchExpressions {
+		reqs = append(reqs, formatNodeRequirement(r))
+	}
+	for _, r := range term.MatchFields {
+		reqs = append(reqs, formatNodeRequirement(r))
+	}
+	if len(reqs) == 0 {
+		return "<any node>"
+	}
+	return strings.Join(reqs, ",")
+}
+
+// formatNodeRequirement writes a node selector requirement like a label
+// selector requirement
+func formatNodeRequirement(r corev1.NodeSelectorRequirement) string {
+	values := strings.Join(r.Values, ",")
+	switch r.Operator {
+	case corev1.NodeSelectorOpIn:
+		if len(r.Values) == 1 {
+			return r.Key + "=" + values
+		}
+		return fmt.Sprintf("%s in (%s)", r.Key, values)
+	case corev1.NodeSelectorOpNotIn:
+		if len(r.Values) == 1 {
+			return r.Key + "!=" + values
+		}
+		return fmt.Sprintf("%s notin (%s)", r.Key, values)
+	case corev1.NodeSelectorOpExists:
+		return r.Key
+	case corev1.NodeSelectorOpDoesNotExist:
+		return "!" + r.Key
+	case corev1.NodeSelectorOpGt:
+		return r.Key + " > " + values
+	case corev1.NodeSelectorOpLt:
+		return r.Key + " < " + values
+	}
+	return fmt.Sprintf("%s %s (%s)", r.Key, r.Operator, values)
+}
+
+// formatToleration writes a toleration like kubectl describe, e.g.
+// "node.kubernetes.io/not-ready:NoExecute op=Exists for 300s"
+func formatToleration(t corev1.Toleration) string {
+	s := t.Key
+	if t.Key == "" && t.Operator == corev1.TolerationOpExists {
+		s = "<all taints>"
+	}
+	if t.Value != "" {
+		s += "=" + t.Value
+	}
+	if t.Effect != "" {
+		s += ":" + string(t.Effect)
+	}
+	if t.Operator == corev1.TolerationOpExists && t.Key != "" {
+		s += " op=Exists"
+	}
+	if t.TolerationSeconds != nil {
+		s += fmt.Sprintf(" for %ds", *t.TolerationSeconds)
+	}
+	return s
+}
@@ -0,0 +1,72 @@
+package resources
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWriteScheduling(t *testing.T) {
+	notReady := int64(300)
+	spec := corev1.PodSpec{
+		NodeSelector: map[string]string{"kubernetes.io/os": "linux"},
+		Affinity: &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{{
+					Weight: 10,
+					Preference: corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{
+						{Key: "topology.kubernetes.io/zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"a", "b"}},
+					}},
+				}},
+			},
+			PodAntiAffinity: &corev1.PodAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
+					LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+					TopologyKey:   "kubernetes.io/hostname",
+				}},
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+					Weight: 100,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+						Namespaces:    []string{"shop"},
+						TopologyKey:   "topology.kubernetes.io/zone",
+					},
+				}},
+			},
+		},
+		Tolerations: []corev1.Toleration{
+			{Key: "node.kubernetes.io/not-ready", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute, TolerationSeconds: &notReady},
+			{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "web", Effect: corev1.TaintEffectNoSchedule},
+			{Operator: corev1.TolerationOpExists},
+		},
+	}
+
+	w := &detailWriter{}
+	writeScheduling(w, spec)
+
+	want := `
+Scheduling Constraints:
+  Node Selector: kubernetes.io/os=linux
+  Node Affinity:
+    preferred (weight 10): topology.kubernetes.io/zone in (a,b)
+  Pod Anti-Affinity:
+    required: app=web per kubernetes.io/hostname
+    preferred (weight 100): app=web in shop per topology.kubernetes.io/zone
+  Tolerations:
+    - node.kubernetes.io/not-ready:NoExecute op=Exists for 300s
+    - dedicated=web:NoSchedule
+    - <all taints>
+`
+	if got := w.sb.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestWriteSchedulingUnconstrained(t *testing.T) {
+	w := &detailWriter{}
+	writeScheduling(w, corev1.PodSpec{Affinity: &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{}}})
+	if got := w.sb.String(); got != "" {
+		t.Errorf("got %q for a pod without constraints, want nothing", got)
+	}
+}
@@ -0,0 +1,196 @@
+package resources
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// CertExpiryWarning is how close to expiry a certificate is flagged
+const CertExpiryWarning = 30 * 24 * time.Hour
+
+// GetSecrets retrieves secrets from the specified namespace without their data
+func GetSecrets(ctx context.Context, clientset kubernetes.Interface, namespace string) ([]SecretInfo, error) {
+	var secrets []SecretInfo
+
+	// Get secret list from K8s API
+	secretList, err := clientset.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching secrets: %v", err)
+	}
+
+	for _, secret := range secretList.Items {
+		age := time.Since(secret.CreationTimestamp.Time).Round(time.Second)
+
+		secrets = append(secrets, SecretInfo{
+			Name:      secret.Name,
+			Namespace: secret.Namespace,
+			Type:      string(secret.Type),
+			Keys:      len(secret.Data),
+			Age:       FormatDuration(age),
+			Created:   secret.CreationTimestamp.Time,
+		})
+	}
+
+	return secrets, nil
+}
+
+// GetSecretDetail returns detailed information about a specific secret. Values
+// are never shown; instead the data is summarized according to the secret type.
+// Without summarize the data isn't even decoded, only keys and sizes are
+// listed.
+func GetSecretDetail(ctx context.Context, clientset kubernetes.Interface, namespace, secretName string, summarize bool) (Detail, error) {
+	// Get the secret from the API
+	secret, err := clientset.CoreV1().Secrets(namespace).Get(ctx, secretName, metav1.GetOptions{})
+	if err != nil {
+		return Detail{}, fmt.Errorf("error fetching secret details: %v", err)
+	}
+
+	w := &detailWriter{}
+
+	// Basic secret information
+	w.field("", "Secret", secret.Name)
+	w.field("", "Namespace", secret.Namespace)
+	w.field("", "Type", string(secret.Type))
+	w.field("", "Created", secret.CreationTimestamp.Format(time.RFC3339))
+
+	// Labels
+	if len(secret.Labels) > 0 {
+		w.line("\nLabels:")
+		for _, key := range sortedKeys(secret.Labels) {
+			w.field("  ", key, secret.Labels[key])
+		}
+	}
+
+	// Type specific summary
+	switch {
+	case !summarize:
+		// Nothing read from the data
+	case secret.Type == corev1.SecretTypeTLS:
+		w.line("\nCertificates:")
+		certs, err := ParseCertificates(secret.Data[corev1.TLSCertKey])
+		if err != nil {
+			w.field("  ", "Error parsing "+corev1.TLSCertKey, err.Error())
+		}
+		for _, cert := range certs {
+			writeCertInfo(w, cert)
+		}
+
+	case secret.Type == corev1.SecretTypeDockerConfigJson, secret.Type == corev1.SecretTypeDockercfg:
+		w.line("\nRegistries:")
+		hosts, err := registryHosts(secret)
+		if err != nil {
+			w.field("  ", "Error parsing docker config", err.Error())
+		}
+		for _, host := range hosts {
+			w.line("  - " + host)
+		}
+	}
+
+	// Keys with sizes
+	w.line("\nData:")
+	if len(secret.Data) == 0 {
+		w.line("  No data")
+	} else {
+		for _, key := range sortedKeys(secret.Data) {
+			w.field("  ", key, fmt.Sprintf("%d bytes", len(secret.Data[key])))
+		}
+	}
+
+	return w.detail(), nil
+}
+
+// ParseCertificates parses all PEM encoded certificates in data
+func ParseCertificates(data []byte) ([]CertInfo, error) {
+	var certs []CertInfo
+
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return certs, err
+		}
+
+		certs = append(certs, CertInfo{
+			Subject:   cert.Subject.String(),
+			Issuer:    cert.Issuer.String(),
+			DNSNames:  cert.DNSNames,
+			NotBefore: cert.NotBefore,
+			NotAfter:  cert.NotAfter,
+		})
+	}
+
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no certificate found")
+	}
+
+	return certs, nil
+}
+
+// writeCertInfo writes a certificate summary, flagging expired and soon to
+// expire certificates
+func writeCertInfo(w *detailWriter, cert CertInfo) {
+	w.field("  - ", "Subject", cert.Subject)
+	w.field("    ", "Issuer", cert.Issuer)
+	if len(cert.DNSNames) > 0 {
+		w.field("    ", "DNS Names", strings.Join(cert.DNSNames, ", "))
+	}
+	w.field("    ", "Valid From", cert.NotBefore.Format(time.RFC3339))
+
+	expiry := cert.NotAfter.Format(time.RFC3339)
+	switch remaining := time.Until(cert.NotAfter); {
+	case remaining <= 0:
+		w.field("    ", "Expires", fmt.Sprintf("%s (EXPIRED)", expiry))
+		w.flag("EXPIRED", true)
+	case remaining < CertExpiryWarning:
+		w.field("    ", "Expires", fmt.Sprintf("%s (EXPIRES SOON, %d days left)", expiry, int(remaining.Hours()/24)))
+		w.flag("EXPIRES SOON", false)
+	default:
+		w.field("    ", "Expires", fmt.Sprintf("%s (%d days left)", expiry, int(remaining.Hours()/24)))
+	}
+}
+
+// registryHosts returns the registries a docker config secret has credentials for
+func registryHosts(secret *corev1.Secret) ([]string, error) {
+	var auths map[string]json.RawMessage
+
+	if secret.Type == corev1.SecretTypeDockerConfigJson {
+		var config struct {
+			Auths map[string]json.RawMessage `json:"auths"`
+		}
+		if err := json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &config); err != nil {
+			return nil, err
+		}
+		auths = config.Auths
+	} else {
+		// The legacy format is the auths map itself
+		if err := json.Unmarshal(secret.Data[corev1.DockerConfigKey], &auths); err != nil {
+			return nil, err
+		}
+	}
+
+	hosts := make([]string, 0, len(auths))
+	for host := range auths {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	return hosts, nil
+}
@@ -6,20 +6,29 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 )
 
 // GetServices retrieves services from the specified namespace
-func GetServices(clientset *kubernetes.Clientset, namespace string) ([]ServiceInfo, error) {
+func GetServices(ctx context.Context, clientset kubernetes.Interface, namespace string) ([]ServiceInfo, error) {
 	var services []ServiceInfo
 
 	// Get service list from K8s API
-	serviceList, err := clientset.CoreV1().Services(namespace).List(context.TODO(), metav1.ListOptions{})
+	serviceList, err := clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("error fetching services: %v", err)
 	}
 
+	// The services are still worth listing without their endpoints
+	endpoints, endpointsErr := readyEndpoints(ctx, clientset, namespace)
+
+	// One list of the pods serves the selectors of every service, from the
+	// API server cache
+	pods, podsErr := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{ResourceVersion: "0"})
+
 	// Process each service
 	for _, svc := range serviceList.Items {
 		// Calculate service age
@@ -33,7 +42,7 @@ func GetServices(clientset *kubernetes.Clientset, namespace string) ([]ServiceIn
 				Name:       port.Name,
 				Protocol:   string(port.Protocol),
 				Port:       port.Port,
-				TargetPort: port.TargetPort.IntVal,
+				TargetPort: port.TargetPort.String(),
 				NodePort:   port.NodePort,
 			}
 			ports = append(ports, svcPort)
@@ -49,6 +58,8 @@ func GetServices(clientset *kubernetes.Clientset, namespace string) ([]ServiceIn
 			}
 		} else if svc.Spec.Type == corev1.ServiceTypeNodePort || svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
 			externalIP = "<pending>"
+		} else if svc.Spec.Type == corev1.ServiceTypeExternalName {
+			externalIP = svc.Spec.ExternalName
 		}
 
 		// Create service info
@@ -59,8 +70,20 @@ func GetServices(clientset *kubernetes.Clientset, namespace string) ([]ServiceIn
 			ClusterIP:  svc.Spec.ClusterIP,
 			ExternalIP: externalIP,
 			Ports:      FormatPortsForDisplay(ports),
+			Targets:    FormatTargetPorts(ports),
 			Age:        ageStr,
 			Selector:   svc.Spec.Selector,
+			Labels:     svc.Labels,
+			Created:    svc.CreationTimestamp.Time,
+			Endpoints:  endpoints[svc.Name],
+		}
+		if endpointsErr != nil {
+			serviceInfo.Endpoints = -1
+		}
+		if podsErr != nil {
+			serviceInfo.PodsReady, serviceInfo.PodsTotal = -1, -1
+		} else {
+			serviceInfo.PodsReady, serviceInfo.PodsTotal = countMatchingPods(pods.Items, svc.Spec.Selector)
 		}
 
 		services = append(services, serviceInfo)
@@ -69,12 +92,87 @@ func GetServices(clientset *kubernetes.Clientset, namespace string) ([]ServiceIn
 	return services, nil
 }
 
+// readyEndpoints counts the ready endpoints of the services of a namespace
+// from their EndpointSlices, by service name
+func readyEndpoints(ctx context.Context, clientset kubernetes.Interface, namespace string) (map[string]int, error) {
+	slices, err := clientset.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching endpoint slices: %v", err)
+	}
+
+	counts := make(map[string]int)
+	for _, slice := range slices.Items {
+		service := slice.Labels[discoveryv1.LabelServiceName]
+		if service == "" {
+			continue
+		}
+		for _, endpoint := range slice.Endpoints {
+			// An unknown condition counts as ready, like kube-proxy does
+			if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+				counts[service]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+// NoMatchingPodsNote flags a service whose selector matches no pod, so it
+// has nothing to route to
+const NoMatchingPodsNote = "[no pods match the selector]"
+
+// CountMatchingPods counts the pods of a namespace matching the selector of
+// a service, and how many of them are ready. A service whose selector
+// matches no pod routes nowhere.
+func CountMatchingPods(ctx context.Context, clientset kubernetes.Interface, namespace string, selector map[string]string) (ready, total int, err error) {
+	if len(selector) == 0 {
+		return 0, 0, nil
+	}
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(selector).String(),
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("error fetching pods: %v", err)
+	}
+	ready, total = countMatchingPods(pods.Items, selector)
+	return ready, total, nil
+}
+
+// countMatchingPods counts the pods matching selector, and how many of them
+// are ready. An empty selector matches no pod, like for services.
+func countMatchingPods(pods []corev1.Pod, selector map[string]string) (ready, total int) {
+	if len(selector) == 0 {
+		return 0, 0
+	}
+	s := labels.SelectorFromSet(selector)
+	for i := range pods {
+		if !s.Matches(labels.Set(pods[i].Labels)) {
+			continue
+		}
+		total++
+		if podReady(&pods[i]) {
+			ready++
+		}
+	}
+	return ready, total
+}
+
+// podReady reports whether the Ready condition of a pod is true, which is
+// what puts it behind its services
+func podReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
 // GetServiceDetail returns detailed information about a specific service
-func GetServiceDetail(clientset *kubernetes.Clientset, namespace, serviceName string) (string, error) {
+func GetServiceDetail(ctx context.Context, clientset kubernetes.Interface, namespace, serviceName string) (Detail, error) {
 	// Get the service from the API
-	svc, err := clientset.CoreV1().Services(namespace).Get(context.TODO(), serviceName, metav1.GetOptions{})
+	svc, err := clientset.CoreV1().Services(namespace).Get(ctx, serviceName, metav1.GetOptions{})
 	if err != nil {
-		return "", fmt.Errorf("error fetching service details: %v", err)
+		return Detail{}, fmt.Errorf("error fetching service details: %v", err)
 	}
 
 	// Format external IP
@@ -87,6 +185,8 @@ func GetServiceDetail(clientset *kubernetes.Clientset, namespace, serviceName st
 		}
 	} else if svc.Spec.Type == corev1.ServiceTypeNodePort || svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
 		externalIP = "<pending>"
+	} else if svc.Spec.Type == corev1.ServiceTypeExternalName {
+		externalIP = svc.Spec.ExternalName
 	}
 
 	// Process ports
@@ -96,70 +196,113 @@ func GetServiceDetail(clientset *kubernetes.Clientset, namespace, serviceName st
 			Name:       port.Name,
 			Protocol:   string(port.Protocol),
 			Port:       port.Port,
-			TargetPort: port.TargetPort.IntVal,
+			TargetPort: port.TargetPort.String(),
 			NodePort:   port.NodePort,
 		}
 		ports = append(ports, svcPort)
 	}
 
-	// Build the detail string
-	detail := fmt.Sprintf("Service: %s\n", svc.Name)
-	detail += fmt.Sprintf("Namespace: %s\n", svc.Namespace)
-	detail += fmt.Sprintf("Type: %s\n", svc.Spec.Type)
-	detail += fmt.Sprintf("Cluster IP: %s\n", svc.Spec.ClusterIP)
-	detail += fmt.Sprintf("External IP: %s\n", externalIP)
+	// Build the detail along with its fields
+	w := &detailWriter{}
+	w.field("", "Service", svc.Name)
+	w.field("", "Namespace", svc.Namespace)
+	w.field("", "Type", string(svc.Spec.Type))
+	w.field("", "Cluster IP", svc.Spec.ClusterIP)
+	w.field("", "External IP", externalIP)
 
 	// Format ports
-	detail += "\nPorts:\n"
+	w.line("\nPorts:")
 	if len(svc.Spec.Ports) == 0 {
-		detail += "  No ports defined\n"
+		w.line("  No ports defined")
 	} else {
 		for _, port := range svc.Spec.Ports {
+			subject := fmt.Sprintf("%d/%s", port.Port, port.Protocol)
 			if port.NodePort > 0 {
-				detail += fmt.Sprintf("  - %d:%d/%s", port.Port, port.NodePort, port.Protocol)
-			} else {
-				detail += fmt.Sprintf("  - %d/%s", port.Port, port.Protocol)
+				subject = fmt.Sprintf("%d:%d/%s", port.Port, port.NodePort, port.Protocol)
 			}
 
 			if port.Name != "" {
-				detail += fmt.Sprintf(" (name: %s)", port.Name)
+				w.item("  ", subject, "name", port.Name)
+			} else {
+				w.line("  - " + subject)
 			}
-
-			detail += "\n"
 		}
 	}
 
 	// Selectors
-	detail += "\nSelector:\n"
+	w.line("\nSelector:")
 	if len(svc.Spec.Selector) == 0 {
-		detail += "  No selector defined\n"
+		w.line("  No selector defined")
 	} else {
-		for key, value := range svc.Spec.Selector {
-			detail += fmt.Sprintf("  %s: %s\n", key, value)
+		for _, key := range sortedKeys(svc.Spec.Selector) {
+			w.field("  ", key, svc.Spec.Selector[key])
+		}
+
+		// The pods behind the selector, the usual answer to a service not
+		// routing
+		if ready, total, err := CountMatchingPods(ctx, clientset, svc.Namespace, svc.Spec.Selector); err == nil {
+			if total == 0 {
+				w.field("  ", "Matching Pods", "0 "+NoMatchingPodsNote)
+				w.flag(NoMatchingPodsNote, true)
+			} else {
+				w.field("  ", "Matching Pods", fmt.Sprintf("%d/%d ready", ready, total))
+			}
 		}
 	}
 
 	// Session affinity
-	detail += fmt.Sprintf("\nSession Affinity: %s\n", svc.Spec.SessionAffinity)
+	w.line("")
+	w.field("", "Session Affinity", string(svc.Spec.SessionAffinity))
 
 	// Labels
 	if len(svc.Labels) > 0 {
-		detail += "\nLabels:\n"
-		for key, value := range svc.Labels {
-			detail += fmt.Sprintf("  %s: %s\n", key, value)
+		w.line("\nLabels:")
+		for _, key := range sortedKeys(svc.Labels) {
+			w.field("  ", key, svc.Labels[key])
 		}
 	}
 
 	// Annotations
 	if len(svc.Annotations) > 0 {
-		detail += "\nAnnotations:\n"
-		for key, value := range svc.Annotations {
-			detail += fmt.Sprintf("  %s: %s\n", key, value)
+		w.line("\nAnnotations:")
+		for _, key := range sortedKeys(svc.Annotations) {
+			w.field("  ", key, capValue(svc.Annotations[key]))
 		}
 	}
 
 	// Creation timestamp
-	detail += fmt.Sprintf("\nCreated: %s\n", svc.CreationTimestamp.Format(time.RFC3339))
+	w.line("")
+	w.field("", "Created", svc.CreationTimestamp.Format(time.RFC3339))
+
+	return w.detail(), nil
+}
+
+// serviceTypeOrder defines the grouping order used when sorting services by type
+var serviceTypeOrder = map[string]int{
+	string(corev1.ServiceTypeClusterIP):    0,
+	string(corev1.ServiceTypeNodePort):     1,
+	string(corev1.ServiceTypeLoadBalancer): 2,
+	string(corev1.ServiceTypeExternalName): 3,
+}
+
+// SortServicesByType sorts services by type (ClusterIP, NodePort, LoadBalancer,
+// ExternalName), then by name
+func SortServicesByType(services []ServiceInfo) error {
+	return SortBy(services, SortType, "", true)
+}
+
+// SortServicesByName sorts services by name
+func SortServicesByName(services []ServiceInfo) error {
+	return SortBy(services, SortName, "", true)
+}
+
+// IsHeadless reports whether the service is headless (ClusterIP: None)
+func (s ServiceInfo) IsHeadless() bool {
+	return s.ClusterIP == corev1.ClusterIPNone
+}
 
-	return detail, nil
+// IsExternalName reports whether the service is an alias of an external
+// host, which has no endpoints by design
+func (s ServiceInfo) IsExternalName() bool {
+	return s.Type == string(corev1.ServiceTypeExternalName)
 }
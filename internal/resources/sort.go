@@ -0,0 +1,167 @@
+package resources
+
+import (
+	"cmp"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Sort keys accepted by SortBy. The keys each resource supports are:
+//
+//	pods:        name, namespace, age, status, restarts, node, ip
+//	services:    name, namespace, age, type
+//	secrets:     name, namespace, age, type, keys
+//	namespaces:  name, age, status
+//	pod metrics: name, namespace, cpu, memory
+//
+// Ascending age lists the newest first, like kubectl's AGE column read top
+// down. Ascending service types follow ClusterIP, NodePort, LoadBalancer,
+// ExternalName.
+const (
+	SortName      = "name"
+	SortNamespace = "namespace"
+	SortAge       = "age"
+	SortStatus    = "status"
+	SortRestarts  = "restarts"
+	SortNode      = "node"
+	SortIP        = "ip"
+	SortType      = "type"
+	SortKeys      = "keys"
+	SortCPU       = "cpu"
+	SortMemory    = "memory"
+)
+
+// Sortable are the item types SortBy sorts
+type Sortable interface {
+	PodInfo | ServiceInfo | SecretInfo | NamespaceInfo | PodMetricsInfo
+}
+
+// comparator orders two items by one key, returning a negative number when
+// a sorts first
+type comparator[T any] func(a, b T) int
+
+var podComparators = map[string]comparator[PodInfo]{
+	SortName:      func(a, b PodInfo) int { return strings.Compare(a.Name, b.Name) },
+	SortNamespace: func(a, b PodInfo) int { return strings.Compare(a.Namespace, b.Namespace) },
+	SortAge:       func(a, b PodInfo) int { return b.Created.Compare(a.Created) },
+	SortStatus:    func(a, b PodInfo) int { return strings.Compare(a.Status, b.Status) },
+	SortRestarts:  func(a, b PodInfo) int { return cmp.Compare(podRestarts(a), podRestarts(b)) },
+	SortNode:      func(a, b PodInfo) int { return strings.Compare(a.Node, b.Node) },
+	SortIP:        func(a, b PodInfo) int { return strings.Compare(a.IP, b.IP) },
+}
+
+var serviceComparators = map[string]comparator[ServiceInfo]{
+	SortName:      func(a, b ServiceInfo) int { return strings.Compare(a.Name, b.Name) },
+	SortNamespace: func(a, b ServiceInfo) int { return strings.Compare(a.Namespace, b.Namespace) },
+	SortAge:       func(a, b ServiceInfo) int { return b.Created.Compare(a.Created) },
+	SortType: func(a, b ServiceInfo) int {
+		return cmp.Compare(serviceTypeOrder[a.Type], serviceTypeOrder[b.Type])
+	},
+}
+
+var secretComparators = map[string]comparator[SecretInfo]{
+	SortName:      func(a, b SecretInfo) int { return strings.Compare(a.Name, b.Name) },
+	SortNamespace: func(a, b SecretInfo) int { return strings.Compare(a.Namespace, b.Namespace) },
+	SortAge:       func(a, b SecretInfo) int { return b.Created.Compare(a.Created) },
+	SortType:      func(a, b SecretInfo) int { return strings.Compare(a.Type, b.Type) },
+	SortKeys:      func(a, b SecretInfo) int { return cmp.Compare(a.Keys, b.Keys) },
+}
+
+var namespaceComparators = map[string]comparator[NamespaceInfo]{
+	SortName:   func(a, b NamespaceInfo) int { return strings.Compare(a.Name, b.Name) },
+	SortAge:    func(a, b NamespaceInfo) int { return b.Created.Compare(a.Created) },
+	SortStatus: func(a, b NamespaceInfo) int { return strings.Compare(a.Status, b.Status) },
+}
+
+var podMetricsComparators = map[string]comparator[PodMetricsInfo]{
+	SortName:      func(a, b PodMetricsInfo) int { return strings.Compare(a.Name, b.Name) },
+	SortNamespace: func(a, b PodMetricsInfo) int { return strings.Compare(a.Namespace, b.Namespace) },
+	SortCPU:       func(a, b PodMetricsInfo) int { return cmp.Compare(a.CPUMilli, b.CPUMilli) },
+	SortMemory:    func(a, b PodMetricsInfo) int { return cmp.Compare(a.MemoryBytes, b.MemoryBytes) },
+}
+
+// SortBy sorts pods, services, secrets, namespaces or pod metrics by the
+// primary key, then by the secondary key when it is not empty, in ascending
+// order or descending when asc is false. Items equal on both keys are
+// ordered by name, always ascending, so the order is deterministic. An
+// error is returned for keys the item type doesn't support, leaving the
+// items as they were.
+func SortBy[T Sortable](items []T, primary, secondary string, asc bool) error {
+	return sortWith(items, comparatorsOf[T](), primary, secondary, asc)
+}
+
+// SortKeysOf returns the sort keys supported by the item type, sorted
+func SortKeysOf[T Sortable]() []string {
+	return comparatorKeys(comparatorsOf[T]())
+}
+
+// comparatorsOf returns the comparators of an item type by sort key
+func comparatorsOf[T Sortable]() map[string]comparator[T] {
+	var comparators any
+	switch any(*new(T)).(type) {
+	case PodInfo:
+		comparators = podComparators
+	case ServiceInfo:
+		comparators = serviceComparators
+	case SecretInfo:
+		comparators = secretComparators
+	case NamespaceInfo:
+		comparators = namespaceComparators
+	case PodMetricsInfo:
+		comparators = podMetricsComparators
+	}
+	return comparators.(map[string]comparator[T])
+}
+
+// sortWith stably sorts items with the comparators of the keys, breaking
+// ties on name
+func sortWith[T any](items []T, comparators map[string]comparator[T], primary, secondary string, asc bool) error {
+	keys := []string{primary}
+	if secondary != "" {
+		keys = append(keys, secondary)
+	}
+
+	compare := make([]comparator[T], 0, len(keys))
+	for _, key := range keys {
+		c, ok := comparators[key]
+		if !ok {
+			return fmt.Errorf("unknown sort key %q, expected one of %s", key, strings.Join(comparatorKeys(comparators), ", "))
+		}
+		compare = append(compare, c)
+	}
+	byName := comparators[SortName]
+
+	sort.SliceStable(items, func(i, j int) bool {
+		for _, c := range compare {
+			if r := c(items[i], items[j]); r != 0 {
+				if !asc {
+					r = -r
+				}
+				return r < 0
+			}
+		}
+		return byName(items[i], items[j]) < 0
+	})
+
+	return nil
+}
+
+// comparatorKeys returns the keys of a comparator map, sorted
+func comparatorKeys[T any](comparators map[string]comparator[T]) []string {
+	keys := make([]string, 0, len(comparators))
+	for key := range comparators {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// podRestarts sums the restarts of a pod's containers
+func podRestarts(pod PodInfo) int {
+	restarts := 0
+	for _, c := range pod.Containers {
+		restarts += c.RestartCount
+	}
+	return restarts
+}
@@ -0,0 +1,118 @@
+package resources
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSortByPods(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	pods := []PodInfo{
+		{Name: "web-b", Namespace: "prod", Status: "Running", Created: now.Add(-time.Hour)},
+		{Name: "db", Namespace: "prod", Status: "Pending", Created: now.Add(-2 * time.Hour)},
+		{Name: "web-a", Namespace: "dev", Status: "Running", Created: now.Add(-time.Hour)},
+		{Name: "cache", Namespace: "dev", Status: "Running", Created: now},
+	}
+
+	tests := []struct {
+		name               string
+		primary, secondary string
+		asc                bool
+		want               []string
+	}{
+		{"primary key", SortNamespace, "", true, []string{"cache", "web-a", "db", "web-b"}},
+		{"secondary key", SortStatus, SortNamespace, true, []string{"db", "cache", "web-a", "web-b"}},
+		{"equal ages by name", SortAge, "", true, []string{"cache", "web-a", "web-b", "db"}},
+		{"descending", SortAge, "", false, []string{"db", "web-a", "web-b", "cache"}},
+		{"descending keeps names ascending", SortStatus, "", false, []string{"cache", "web-a", "web-b", "db"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := slices.Clone(pods)
+			if err := SortBy(items, tt.primary, tt.secondary, tt.asc); err != nil {
+				t.Fatalf("SortBy: %v", err)
+			}
+			var got []string
+			for _, pod := range items {
+				got = append(got, pod.Name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortByUnknownKey(t *testing.T) {
+	services := []ServiceInfo{{Name: "b"}, {Name: "a"}}
+
+	for _, keys := range [][2]string{{SortRestarts, ""}, {SortName, "bogus"}} {
+		items := slices.Clone(services)
+		err := SortBy(items, keys[0], keys[1], true)
+		if err == nil {
+			t.Errorf("SortBy(%q, %q) succeeded, want an error", keys[0], keys[1])
+			continue
+		}
+		if !strings.Contains(err.Error(), "expected one of age, name, namespace, type") {
+			t.Errorf("error %q does not list the service keys", err)
+		}
+		if items[0].Name != "b" || items[1].Name != "a" {
+			t.Errorf("items sorted despite the error: %v", items)
+		}
+	}
+}
+
+func TestSortServicesByType(t *testing.T) {
+	services := []ServiceInfo{
+		{Name: "ext", Type: "ExternalName"},
+		{Name: "lb", Type: "LoadBalancer"},
+		{Name: "b", Type: "ClusterIP"},
+		{Name: "a", Type: "ClusterIP"},
+		{Name: "np", Type: "NodePort"},
+	}
+	if err := SortServicesByType(services); err != nil {
+		t.Fatalf("SortServicesByType: %v", err)
+	}
+	var got []string
+	for _, svc := range services {
+		got = append(got, svc.Name)
+	}
+	if want := []string{"a", "b", "np", "lb", "ext"}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestSortPodMetrics(t *testing.T) {
+	metrics := []PodMetricsInfo{
+		{Name: "a", CPUMilli: 10, MemoryBytes: 300},
+		{Name: "b", CPUMilli: 30, MemoryBytes: 100},
+		{Name: "c", CPUMilli: 20, MemoryBytes: 200},
+	}
+	for _, tt := range []struct {
+		byMemory bool
+		want     []string
+	}{
+		{false, []string{"b", "c", "a"}},
+		{true, []string{"a", "c", "b"}},
+	} {
+		if err := SortPodMetrics(metrics, tt.byMemory); err != nil {
+			t.Fatalf("SortPodMetrics: %v", err)
+		}
+		var got []string
+		for _, info := range metrics {
+			got = append(got, info.Name)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("byMemory=%v: got %v, want %v", tt.byMemory, got, tt.want)
+		}
+	}
+}
+
+func TestSortKeysOf(t *testing.T) {
+	if got, want := SortKeysOf[NamespaceInfo](), []string{"age", "name", "status"}; !slices.Equal(got, want) {
+		t.Errorf("SortKeysOf[NamespaceInfo]() = %v, want %v", got, want)
+	}
+}
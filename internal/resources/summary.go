@@ -0,0 +1,111 @@
+package resources
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// PodSummary tallies pods by effective status
+type PodSummary struct {
+	Total int
+
+	// ByStatus counts the pods of each effective status
+	ByStatus map[string]int
+
+	// Failing counts the pods that failed or are crashing
+	Failing int
+}
+
+// SummarizePods tallies pods by their effective status
+func SummarizePods(pods []PodInfo) PodSummary {
+	summary := PodSummary{Total: len(pods), ByStatus: make(map[string]int)}
+	for _, pod := range pods {
+		status := pod.EffectiveStatus()
+		summary.ByStatus[status]++
+		if IsFailingStatus(status) {
+			summary.Failing++
+		}
+	}
+	return summary
+}
+
+// String formats the summary the way the one line output shows it, e.g.
+// "12 pods (10 Running, 1 CrashLoopBackOff, 1 Pending)", the most common
+// statuses first
+func (s PodSummary) String() string {
+	if s.Total == 0 {
+		return "0 pods"
+	}
+	noun := "pods"
+	if s.Total == 1 {
+		noun = "pod"
+	}
+
+	statuses := make([]string, 0, len(s.ByStatus))
+	for status := range s.ByStatus {
+		statuses = append(statuses, status)
+	}
+	slices.SortFunc(statuses, func(a, b string) int {
+		if c := cmp.Compare(s.ByStatus[b], s.ByStatus[a]); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+
+	counts := make([]string, len(statuses))
+	for i, status := range statuses {
+		counts[i] = fmt.Sprintf("%d %s", s.ByStatus[status], status)
+	}
+	return fmt.Sprintf("%d %s (%s)", s.Total, noun, strings.Join(counts, ", "))
+}
+
+// EffectiveStatus returns the status kubectl would list for the pod: the
+// waiting reason of a container that isn't running, e.g. CrashLoopBackOff,
+// rather than the phase the pod is still in
+func (p PodInfo) EffectiveStatus() string {
+	// Unschedulable and init statuses already say more than the phase
+	if p.Status != p.Phase {
+		return p.Status
+	}
+	if p.Phase != "Pending" && p.Phase != "Running" {
+		return p.Status
+	}
+
+	for _, c := range p.Containers {
+		if !c.IsInit && c.State == string(ContainerWaiting) && c.Reason != "" {
+			return c.Reason
+		}
+	}
+	return p.Status
+}
+
+// failingReasons are the container waiting reasons that won't resolve
+// without a fix
+var failingReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"RunContainerError":          true,
+}
+
+// IsFailingStatus reports whether an effective pod status means the pod
+// failed or is crashing
+func IsFailingStatus(status string) bool {
+	switch status {
+	case "Failed", "Unknown", "Error":
+		return true
+	}
+
+	// Init progress like "Init:0/2" is fine, any other init status means an
+	// init container failed
+	if reason, ok := strings.CutPrefix(status, "Init:"); ok {
+		return !strings.Contains(reason, "/")
+	}
+
+	return failingReasons[status]
+}
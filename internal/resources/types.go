@@ -2,7 +2,11 @@ package resources
 
 import (
 	"fmt"
+	"strings"
 	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 // ViewType represents different UI views
@@ -20,12 +24,184 @@ const (
 
 	// NamespaceView is the view for selecting namespaces
 	NamespaceView ViewType = "namespaces"
+
+	// LogView is the view that shows container logs
+	LogView ViewType = "logs"
+
+	// ContainerView is the view for picking a container of a pod
+	ContainerView ViewType = "containers"
+
+	// EventsView is the view that shows the events of a resource
+	EventsView ViewType = "events"
+
+	// SecretView is the view that shows secrets
+	SecretView ViewType = "secrets"
+
+	// CustomTypeView is the view for picking a custom resource type
+	CustomTypeView ViewType = "customtypes"
+
+	// CustomResourceView is the view that shows instances of a custom resource
+	CustomResourceView ViewType = "customresources"
+
+	// DiagnosisView is the view that explains why a pod is pending
+	DiagnosisView ViewType = "diagnosis"
+
+	// TopView is the view that shows pods sorted by resource usage
+	TopView ViewType = "top"
+
+	// ServiceAccountView is the view that lists service accounts
+	ServiceAccountView ViewType = "serviceaccounts"
+
+	// RoleView is the view that lists the roles of a namespace and the
+	// cluster roles
+	RoleView ViewType = "roles"
+
+	// RoleBindingView is the view that lists the role bindings of a
+	// namespace and the cluster role bindings
+	RoleBindingView ViewType = "rolebindings"
+
+	// WhoCanView is the view that lists the subjects allowed an action
+	WhoCanView ViewType = "whocan"
+
+	// EventStreamView is the view that follows events as they happen
+	EventStreamView ViewType = "eventstream"
+
+	// DashboardView is the view that summarizes the current namespace
+	DashboardView ViewType = "dashboard"
+
+	// RouteView is the view that shows OpenShift routes
+	RouteView ViewType = "routes"
+
+	// ContextView is the view that picks a kubeconfig context
+	ContextView ViewType = "contexts"
+
+	// ResourceQuotaView is the view that shows the usage of the resource
+	// quotas of a namespace
+	ResourceQuotaView ViewType = "quotas"
+
+	// RecentView is the view that lists the resources opened recently
+	RecentView ViewType = "recent"
+
+	// FleetView is the view that summarizes the cluster of every kubeconfig
+	// context
+	FleetView ViewType = "fleet"
+
+	// HistoryView is the view that lists the rollout revisions of a
+	// Deployment
+	HistoryView ViewType = "history"
+
+	// CertificateView is the view that lists the certificates of the TLS
+	// secrets of a namespace by expiry
+	CertificateView ViewType = "certificates"
+
+	// EnvView is the view that lists the environment variables of a
+	// container
+	EnvView ViewType = "env"
+)
+
+// ResourceKind identifies the kind of a Kubernetes resource
+type ResourceKind string
+
+const (
+	// KindPod is the Pod kind
+	KindPod ResourceKind = "Pod"
+
+	// KindService is the Service kind
+	KindService ResourceKind = "Service"
+
+	// KindSecret is the Secret kind
+	KindSecret ResourceKind = "Secret"
+
+	// KindServiceAccount is the ServiceAccount kind
+	KindServiceAccount ResourceKind = "ServiceAccount"
+
+	// KindRole and KindClusterRole are the RBAC role kinds
+	KindRole        ResourceKind = "Role"
+	KindClusterRole ResourceKind = "ClusterRole"
+
+	// KindRoleBinding and KindClusterRoleBinding are the RBAC binding kinds
+	KindRoleBinding        ResourceKind = "RoleBinding"
+	KindClusterRoleBinding ResourceKind = "ClusterRoleBinding"
+
+	// KindRoute is the OpenShift Route kind
+	KindRoute ResourceKind = "Route"
 )
 
+// RecentResource is a resource whose detail was opened, listed by the
+// recent view
+type RecentResource struct {
+	Kind      ResourceKind
+	Namespace string
+	Name      string
+	Opened    time.Time
+}
+
+// ClusterSummary is the state of the cluster of a kubeconfig context, a row
+// of the fleet view
+type ClusterSummary struct {
+	Context string
+
+	// Version is the Kubernetes version of the API server
+	Version string
+
+	Nodes      int
+	NodesReady int
+	Pods       int
+	PodsReady  int
+
+	// Err is why the cluster could not be reached, nil when it answered.
+	// NodesErr and PodsErr are why its nodes or pods could not be listed.
+	Err      error
+	NodesErr error
+	PodsErr  error
+}
+
+// DetailField is a "Key: value" line of a detail text
+type DetailField struct {
+	Key   string
+	Value string
+
+	// Line is the index of the line in the detail text
+	Line int
+
+	// Container is the container of a pod the field is about, empty for
+	// the fields of the pod itself and of other resources
+	Container string
+}
+
+// DetailFlag calls out a part of a detail line, e.g. "[out of memory]",
+// highlighted in the detail view
+type DetailFlag struct {
+	// Line is the index of the line in the detail text, Start and End the
+	// byte offsets of the flagged text in it
+	Line       int
+	Start, End int
+
+	// Severe flags an error, the others warnings
+	Severe bool
+}
+
+// Detail is the detail text of a resource along with the fields written in
+// it, see detailWriter
+type Detail struct {
+	Text   string
+	Fields []DetailField
+
+	// Flags are the parts of lines to call out
+	Flags []DetailFlag
+
+	// Containers are the regular containers of a pod, in order
+	Containers []string
+}
+
 // PodInfo contains essential pod information
 type PodInfo struct {
+	// ResourceVersion changes whenever the pod does
+	ResourceVersion string
+
 	Name       string
 	Namespace  string
+	Phase      string
 	Status     string
 	Age        string
 	IP         string
@@ -33,6 +209,25 @@ type PodInfo struct {
 	Created    time.Time
 	Labels     map[string]string
 	Containers []ContainerInfo
+
+	// NominatedNode is where the scheduler plans to place the pod once
+	// lower priority pods are preempted
+	NominatedNode string
+
+	// ReadinessGates counts the readiness gates that passed, e.g. "1/2",
+	// empty when the pod has none
+	ReadinessGates string
+
+	// OwnerKind is the kind of the controller owning the pod, e.g.
+	// ReplicaSet or Job, empty for bare pods
+	OwnerKind string
+
+	// QOSClass is Guaranteed, Burstable or BestEffort
+	QOSClass string
+
+	// StatusMessage explains Status when the pod is stuck, e.g. the
+	// scheduler message of an unschedulable pod
+	StatusMessage string
 }
 
 // ContainerInfo contains container details
@@ -47,6 +242,13 @@ type ContainerInfo struct {
 	CPULimit        string
 	MemoryLimit     string
 	EnvironmentVars map[string]string
+
+	// Reason is why a waiting container isn't running, e.g.
+	// CrashLoopBackOff or ContainerCreating
+	Reason string
+
+	// IsInit is true for init containers
+	IsInit bool
 }
 
 // ServiceInfo contains essential service information
@@ -59,12 +261,295 @@ type ServiceInfo struct {
 	Ports      string
 	Age        string
 	Selector   map[string]string
+	Labels     map[string]string
+	Created    time.Time
+
+	// Targets maps the ports to the ports of the pods, see FormatTargetPorts
+	Targets string
+
+	// Endpoints counts the ready endpoints of the service, -1 when they
+	// could not be listed
+	Endpoints int
+
+	// PodsReady and PodsTotal count the pods matching the selector of the
+	// service, -1 when the pods could not be listed
+	PodsReady int
+	PodsTotal int
+}
+
+// SecretInfo contains essential secret information
+type SecretInfo struct {
+	Name      string
+	Namespace string
+	Type      string
+	Keys      int
+	Age       string
+	Created   time.Time
+}
+
+// ServiceAccountInfo contains essential service account information
+type ServiceAccountInfo struct {
+	Name      string
+	Namespace string
+	Secrets   int
+	Age       string
+	Created   time.Time
+}
+
+// RoleInfo contains essential information about a Role or ClusterRole
+type RoleInfo struct {
+	Kind      ResourceKind
+	Name      string
+	Namespace string
+	Rules     int
+	Age       string
+	Created   time.Time
+}
+
+// RoleBindingInfo contains essential information about a RoleBinding or
+// ClusterRoleBinding
+type RoleBindingInfo struct {
+	Kind      ResourceKind
+	Name      string
+	Namespace string
+	Role      string
+	Subjects  []string
+	Age       string
+	Created   time.Time
+}
+
+// Subject is a user, group or service account granted a permission, with
+// the binding and role granting it
+type Subject struct {
+	Kind      string
+	Name      string
+	Namespace string
+	Binding   string
+	Role      string
+}
+
+// CertInfo contains the details of an X.509 certificate
+type CertInfo struct {
+	Subject   string
+	Issuer    string
+	DNSNames  []string
+	NotBefore time.Time
+	NotAfter  time.Time
+
+	// Namespace and Secret name the TLS secret holding the certificate, and
+	// Err why it could not be parsed, set by ScanTLSSecrets
+	Namespace string
+	Secret    string
+	Err       error
+}
+
+// CustomResourceType describes a custom resource defined by a CRD
+type CustomResourceType struct {
+	// Name is the CRD name, <plural>.<group>
+	Name       string
+	Group      string
+	Version    string
+	Resource   string
+	Kind       string
+	Namespaced bool
+
+	// Columns are the fields shown when listing instances
+	Columns []PrinterColumn
+}
+
+// GVR returns the group, version and resource of the type
+func (t CustomResourceType) GVR() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: t.Group, Version: t.Version, Resource: t.Resource}
+}
+
+// PrinterColumn is a field of a custom resource shown as a list column
+type PrinterColumn struct {
+	Name     string
+	JSONPath string
+	Type     string
+}
+
+// CustomResourceInfo contains essential custom resource information
+type CustomResourceInfo struct {
+	Name      string
+	Namespace string
+
+	// Fields holds the values of the type's printer columns
+	Fields  []string
+	Age     string
+	Created time.Time
+}
+
+// NamespaceInfo contains essential namespace information
+type NamespaceInfo struct {
+	Name    string
+	Status  string
+	Age     string
+	Created time.Time
+}
+
+// Workload is the controller at the top of the owner chain of pods, e.g.
+// a Deployment, a CronJob or a custom resource
+type Workload struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// RawResult is what a raw query fetched, see GetRaw
+type RawResult struct {
+	// Kind is the kind the resource type of the query resolved to
+	Kind ResourceKind
+
+	// Content is the object or list as YAML or JSON
+	Content string
+
+	// Truncated is set when the list had more than MaxRawItems objects
+	Truncated bool
+}
+
+// CronJobInfo is the schedule and state of a CronJob
+type CronJobInfo struct {
+	Name      string
+	Namespace string
+	Schedule  string
+	Suspended bool
+
+	// LastSchedule is when a Job was last scheduled, zero when none was
+	LastSchedule time.Time
+}
+
+// RevisionInfo is a rollout revision of a Deployment: the ReplicaSet
+// created for it and the pod template it runs
+type RevisionInfo struct {
+	Revision      int64
+	ReplicaSet    string
+	Namespace     string
+	Images        []string
+	Replicas      int32
+	ReadyReplicas int32
+
+	// Current is set for the revision the Deployment rolls out
+	Current bool
+
+	// ChangeCause is the kubernetes.io/change-cause annotation, if any
+	ChangeCause string
+
+	Age     string
+	Created time.Time
+}
+
+// PodCounts counts the pods of a namespace, with the pending and failed
+// ones that point at trouble
+type PodCounts struct {
+	Total   int
+	Pending int
+	Failed  int
+}
+
+// PodMetricsInfo contains the resource usage of a pod
+type PodMetricsInfo struct {
+	Name        string
+	Namespace   string
+	CPUMilli    int64
+	MemoryBytes int64
+}
+
+// ResourceQuotaInfo contains the limits and usage of a resource quota
+type ResourceQuotaInfo struct {
+	Name       string
+	Namespace  string
+	Dimensions []QuotaDimension
+	Age        string
+	Created    time.Time
+}
+
+// QuotaDimension is the usage of one resource limited by a quota, e.g. pods
+// or requests.cpu
+type QuotaDimension struct {
+	Resource string
+	Used     string
+	Hard     string
+
+	// Ratio is the share of the limit in use, 1 or more once it is reached
+	Ratio float64
+}
+
+// RouteInfo contains essential OpenShift route information
+type RouteInfo struct {
+	Name      string
+	Namespace string
+	Host      string
+	Path      string
+
+	// Service is the target service, with the target port when one is set
+	Service string
+
+	// TLS is the TLS termination: edge, passthrough, reencrypt or empty
+	// for plain HTTP
+	TLS string
+
+	Age     string
+	Created time.Time
+}
+
+// NodePressure names the pressure conditions, e.g. DiskPressure, that are
+// true on a node
+type NodePressure struct {
+	Node       string
+	Conditions []string
+}
+
+// DashboardInfo holds the parts of the namespace overview that are not in
+// ResourceData. A part that could not be fetched has its error set.
+type DashboardInfo struct {
+	Deployments      int
+	DeploymentsReady int
+	DeploymentsErr   error
+
+	// Node count, readiness, pressure conditions and the allocatable
+	// capacity of all nodes
+	Nodes                  int
+	NodesReady             int
+	NodePressure           []NodePressure
+	CPUAllocatableMilli    int64
+	MemoryAllocatableBytes int64
+	NodesErr               error
+
+	// Current usage of the pods of the namespace, UsageErr is
+	// ErrMetricsUnavailable without metrics-server
+	CPUUsageMilli    int64
+	MemoryUsageBytes int64
+	UsageErr         error
+
+	// Warnings are the most recent warning events of the namespace
+	Warnings    []EventInfo
+	WarningsErr error
+
+	// Quotas are the resource quotas of the namespace
+	Quotas    []ResourceQuotaInfo
+	QuotasErr error
+}
+
+// EventInfo contains essential event information
+type EventInfo struct {
+	Type      string
+	Reason    string
+	Count     int32
+	Age       string
+	Message   string
+	LastSeen  time.Time
+	Namespace string
+
+	// Object is the involved object as "Kind/name"
+	Object string
 }
 
 // ResourceData contains all resource information
 type ResourceData struct {
 	Pods     []PodInfo
 	Services []ServiceInfo
+	Secrets  []SecretInfo
 }
 
 // FormatDuration converts a duration to a human-readable string like "5d12h"
@@ -81,6 +566,16 @@ func FormatDuration(d time.Duration) string {
 	return fmt.Sprintf("%dm", minutes)
 }
 
+// TimestampLabels are the labels written before the RFC3339 timestamps of
+// detail text, at the start of a line or, for "started at", in parentheses.
+// Timestamps elsewhere, e.g. in annotations, are values to keep as written.
+var TimestampLabels = []string{"Created: ", "Finished: ", "Valid From: ", "Expires: ", "started at "}
+
+// FormatSelector formats a label selector like kubectl, e.g. "app=web,tier=frontend"
+func FormatSelector(selector map[string]string) string {
+	return labels.Set(selector).String()
+}
+
 // FormatPortsForDisplay formats service ports for display
 func FormatPortsForDisplay(ports []ServicePort) string {
 	if len(ports) == 0 {
@@ -103,13 +598,25 @@ func FormatPortsForDisplay(ports []ServicePort) string {
 	return result
 }
 
+// FormatTargetPorts formats where the ports of a service send traffic on
+// its pods, e.g. "80→8080, 443→https"
+func FormatTargetPorts(ports []ServicePort) string {
+	targets := make([]string, 0, len(ports))
+	for _, port := range ports {
+		targets = append(targets, fmt.Sprintf("%d→%s", port.Port, port.TargetPort))
+	}
+	return strings.Join(targets, ", ")
+}
+
 // ServicePort represents a port mapping in a service
 type ServicePort struct {
-	Name       string
-	Protocol   string
-	Port       int32
-	TargetPort int32
-	NodePort   int32
+	Name     string
+	Protocol string
+	Port     int32
+	NodePort int32
+
+	// TargetPort is the port number or name on the pods
+	TargetPort string
 }
 
 // ContainerState represents the state of a container
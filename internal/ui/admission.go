@@ -0,0 +1,36 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zvelocity/k8s-cli/internal/resources"
+)
+
+// maxDenialReasons bounds the lines of a denial message a modal shows
+const maxDenialReasons = 12
+
+// NewDenialModal returns a modal telling what admission webhook or policy
+// denied action, e.g. "deleting pod web-1", and why, asking question to try
+// again once the cause is fixed
+func NewDenialModal(denial resources.AdmissionDenial, action, question string) Modal {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s denied %s", strings.ToUpper(denial.Source[:1])+denial.Source[1:], action)
+	if len(denial.Reasons) == 0 {
+		sb.WriteString(" without giving a reason.")
+	} else {
+		sb.WriteString(":\n")
+		for i, reason := range denial.Reasons {
+			if i == maxDenialReasons {
+				sb.WriteString("\n" + StatusStyle.Render(fmt.Sprintf("... %d more lines", len(denial.Reasons)-i)))
+				break
+			}
+			sb.WriteString("\n" + WarningStyle.Render(reason))
+		}
+	}
+	sb.WriteString("\n\n" + question)
+
+	modal := NewConfirmModal("Denied by admission control", sb.String())
+	modal.Danger = true
+	return modal
+}
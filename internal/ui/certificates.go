@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zvelocity/k8s-cli/internal/resources"
+)
+
+// RenderCertificatesView renders the certificates of the TLS secrets of a
+// namespace, the first to expire first. Expired certificates are flagged in
+// the error color, the ones expiring within resources.CertExpiryWarning in
+// the warning color.
+func RenderCertificatesView(certs []resources.CertInfo, lv ListView) string {
+	var sb strings.Builder
+
+	sb.WriteString(renderListHeader(fmt.Sprintf("TLS certificates in namespace: %s", lv.Namespace), lv))
+
+	table := Table{
+		Columns: []Column{
+			{Title: "SECRET", TruncateMiddle: true},
+			{Title: "EXPIRES IN", Priority: 1},
+			{Title: "NOT AFTER", Priority: 3},
+			{Title: "SUBJECT", Priority: 2, MaxWidth: 50, TruncateMiddle: true},
+			{Title: "ISSUER", Priority: 4, MaxWidth: 50, TruncateMiddle: true},
+		},
+		Selected: lv.Selected,
+		Width:    lv.Width,
+		Height:   lv.Height,
+	}
+	for _, cert := range certs {
+		if cert.Err != nil {
+			table.Rows = append(table.Rows, []string{
+				lv.highlight(cert.Secret),
+				ErrorStyle.Render("unreadable"),
+				"-",
+				ErrorStyle.Render(cert.Err.Error()),
+				"-",
+			})
+			continue
+		}
+		table.Rows = append(table.Rows, []string{
+			lv.highlight(cert.Secret),
+			certExpiry(cert.NotAfter),
+			cert.NotAfter.Format(time.DateOnly),
+			cert.Subject,
+			cert.Issuer,
+		})
+	}
+	if len(certs) == 0 {
+		sb.WriteString(emptyNamespaceList("TLS secrets", lv))
+	} else {
+		sb.WriteString(table.Render())
+	}
+
+	sb.WriteString(renderHelp(lv.Keys, "  ↑/{up} up • ↓/{down} down • {search} search • enter details • {refresh} refresh • esc back • {quit} quit"))
+
+	return sb.String()
+}
+
+// certExpiry renders the days left before a certificate expires, flagged
+// once it is within resources.CertExpiryWarning of expiring
+func certExpiry(notAfter time.Time) string {
+	remaining := time.Until(notAfter)
+	days := int(remaining.Hours() / 24)
+	switch {
+	case remaining <= 0:
+		return ErrorStyle.Render(fmt.Sprintf("expired %dd ago", -days))
+	case remaining < resources.CertExpiryWarning:
+		return WarningStyle.Render(fmt.Sprintf("%dd", days))
+	}
+	return fmt.Sprintf("%dd", days)
+}
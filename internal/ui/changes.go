@@ -0,0 +1,47 @@
+package ui
+
+// RowChanges are the rows of a list that changed with the last refresh,
+// highlighted for a moment. Rows are keyed by ChangeKey.
+type RowChanges struct {
+	Added   map[string]bool
+	Changed map[string]bool
+
+	// Removed holds the names of the rows that vanished, shown below the
+	// others
+	Removed []string
+}
+
+// ChangeKey identifies a row of a list that may span namespaces
+func ChangeKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// added highlights the name of a row that just appeared
+func (c RowChanges) added(namespace, name, rendered string) string {
+	if c.Added[ChangeKey(namespace, name)] {
+		return SuccessStyle.Bold(true).Render(name)
+	}
+	return rendered
+}
+
+// changed highlights a cell of a row that just changed, text being the
+// cell without styling
+func (c RowChanges) changed(namespace, name, text, rendered string) string {
+	if c.Changed[ChangeKey(namespace, name)] {
+		return ChangedStyle.Render(text)
+	}
+	return rendered
+}
+
+// removedRows returns rows of columns cells standing for the rows that
+// vanished, marked as deleted in the column after the name
+func (c RowChanges) removedRows(columns int) [][]string {
+	rows := make([][]string, 0, len(c.Removed))
+	for _, name := range c.Removed {
+		row := make([]string, columns)
+		row[0] = ErrorStyle.Render(name)
+		row[1] = ErrorStyle.Render("Deleted")
+		rows = append(rows, row)
+	}
+	return rows
+}
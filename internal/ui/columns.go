@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// knownColumns are the columns of the configurable lists, by the names used
+// in the configuration file, in the order the renderers build them
+var knownColumns = map[string][]string{
+	"pods":     {"name", "status", "ready", "restarts", "age", "qos", "image", "ip", "node", "nominated-node", "readiness-gates"},
+	"services": {"name", "type", "cluster-ip", "external-ip", "ports", "pods", "age", "selector", "target-ports", "endpoints"},
+	"secrets":  {"name", "type", "keys", "age"},
+}
+
+// defaultColumns are the columns shown by the lists without configuration,
+// the wide ones only in wide mode
+var defaultColumns = map[string][]string{
+	"pods":     {"name", "status", "ready", "age", "qos", "image", "ip", "node", "nominated-node", "readiness-gates"},
+	"services": {"name", "type", "cluster-ip", "external-ip", "ports", "pods", "age", "selector", "target-ports", "endpoints"},
+	"secrets":  {"name", "type", "keys", "age"},
+}
+
+// columnSets are the configured columns of the lists, by resource
+var columnSets = map[string][]string{}
+
+// SetColumns sets the columns shown by the lists and their order, by
+// resource, e.g. "pods": {"name", "ready", "status", "age"}. The name always
+// comes first, added when missing. Unknown resources and columns are left
+// out and reported in the returned warnings.
+func SetColumns(sets map[string][]string) []string {
+	var warnings []string
+	columnSets = make(map[string][]string)
+
+	resources := make([]string, 0, len(sets))
+	for resource := range sets {
+		resources = append(resources, resource)
+	}
+	sort.Strings(resources)
+
+	for _, resource := range resources {
+		known, ok := knownColumns[resource]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("columns: unknown resource %q, expected pods, services or secrets", resource))
+			continue
+		}
+
+		set := []string{"name"}
+		for _, name := range sets[resource] {
+			name = strings.ToLower(strings.TrimSpace(name))
+			switch {
+			case !slices.Contains(known, name):
+				warnings = append(warnings, fmt.Sprintf("columns: unknown %s column %q, expected one of %s", resource, name, strings.Join(known, ", ")))
+			case !slices.Contains(set, name):
+				set = append(set, name)
+			}
+		}
+		columnSets[resource] = set
+	}
+	return warnings
+}
+
+// pickColumns keeps the columns of the table configured for resource, in the
+// configured order, the default ones when there is no configuration. The
+// columns and cells of the table are in the order of knownColumns.
+// Configured columns are shown even when they are wide.
+func (t *Table) pickColumns(resource string) {
+	known := knownColumns[resource]
+	set, configured := columnSets[resource]
+	if !configured {
+		set = defaultColumns[resource]
+	}
+
+	indexes := make([]int, 0, len(set))
+	for _, name := range set {
+		if i := slices.Index(known, name); i >= 0 {
+			indexes = append(indexes, i)
+		}
+	}
+
+	columns := make([]Column, len(indexes))
+	for j, i := range indexes {
+		columns[j] = t.Columns[i]
+		if configured {
+			columns[j].Wide = false
+		}
+	}
+	t.Columns = columns
+
+	for r, row := range t.Rows {
+		cells := make([]string, len(indexes))
+		for j, i := range indexes {
+			cells[j] = row[i]
+		}
+		t.Rows[r] = cells
+	}
+}
@@ -0,0 +1,242 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/zvelocity/k8s-cli/internal/resources"
+)
+
+// maxEvictionRisks is how many pods at risk of eviction the dashboard lists
+const maxEvictionRisks = 5
+
+// podPhases is the order pod phases are listed in on the dashboard
+var podPhases = []string{"Running", "Pending", "Succeeded", "Failed", "Unknown"}
+
+// RenderDashboardView renders the overview of a namespace as summary cards:
+// pods by phase, services by type, deployment readiness, nodes under
+// pressure, requests against node capacity, quotas close to their limits,
+// the pods at risk of eviction and the latest warning events. Parts that
+// could not be fetched say so instead of failing the whole view.
+func RenderDashboardView(data resources.ResourceData, info resources.DashboardInfo, lv ListView) string {
+	var sb strings.Builder
+
+	sb.WriteString(renderListHeader(fmt.Sprintf("Overview of namespace: %s", lv.Namespace), lv))
+
+	cards := []string{
+		renderCard("Pods", podsCard(data.Pods)),
+		renderCard("Services", servicesCard(data.Services)),
+		renderCard("Deployments", deploymentsCard(info)),
+		renderCard("Nodes", nodesCard(info)),
+		renderCard("Resources", resourcesCard(data.Pods, info)),
+		renderCard("Quotas", quotasCard(info)),
+	}
+	sb.WriteString(layoutCards(cards, lv.Width))
+	sb.WriteString("\n\n")
+
+	sb.WriteString(evictionRisks(data.Pods, info, lv.Width))
+	sb.WriteString("\n")
+
+	sb.WriteString(TitleStyle.Render("Recent warnings"))
+	sb.WriteString("\n")
+	switch {
+	case info.WarningsErr != nil:
+		sb.WriteString("  " + WarningStyle.Render("unavailable: "+resources.ShortError(info.WarningsErr)) + "\n")
+	case len(info.Warnings) == 0:
+		sb.WriteString("  " + SuccessStyle.Render("No warning events") + "\n")
+	default:
+		table := Table{
+			Columns: []Column{
+				{Title: lastSeenTitle(lv.AbsoluteTime)},
+				{Title: "REASON"},
+				{Title: "OBJECT", Priority: 1, MaxWidth: 40, TruncateMiddle: true},
+				{Title: "MESSAGE", Priority: 2},
+			},
+			Selected: -1,
+			Width:    lv.Width,
+		}
+		for _, e := range info.Warnings {
+			table.Rows = append(table.Rows, []string{
+				FormatAge(e.Age, e.LastSeen, lv.AbsoluteTime),
+				WarningStyle.Render(e.Reason),
+				e.Object,
+				e.Message,
+			})
+		}
+		sb.WriteString(table.Render())
+	}
+
+	sb.WriteString(renderHelp(lv.Keys, "  {pods} pods • {services} services • {top} top • {quotas} quotas • {event-stream} event stream • {namespaces} namespaces • {palette} palette • {refresh} refresh • esc back • {quit} quit"))
+
+	return sb.String()
+}
+
+// evictionRisks renders the pods of the namespace first in line for
+// eviction on the nodes under memory pressure, see
+// resources.AssessEvictionRisk
+func evictionRisks(pods []resources.PodInfo, info resources.DashboardInfo, width int) string {
+	var sb strings.Builder
+
+	sb.WriteString(TitleStyle.Render("Eviction risk"))
+	sb.WriteString("\n")
+	if info.NodesErr != nil {
+		sb.WriteString("  " + WarningStyle.Render("unavailable: "+resources.ShortError(info.NodesErr)) + "\n")
+		return sb.String()
+	}
+
+	risks := resources.AssessEvictionRisk(info.NodePressure, pods)
+	if len(risks) == 0 {
+		sb.WriteString("  " + SuccessStyle.Render("No BestEffort or over-committed pods on nodes under memory pressure") + "\n")
+		return sb.String()
+	}
+
+	table := Table{
+		Columns: []Column{
+			{Title: "POD", TruncateMiddle: true},
+			{Title: "NODE", Priority: 2, MaxWidth: 40, TruncateMiddle: true},
+			{Title: "QOS", Priority: 1},
+			{Title: "REASON", Priority: 1},
+		},
+		Selected: -1,
+		Width:    width,
+	}
+	for _, r := range risks[:min(len(risks), maxEvictionRisks)] {
+		table.Rows = append(table.Rows, []string{r.Pod, r.Node, StyleQOSClass(r.QOSClass), WarningStyle.Render(r.Reason)})
+	}
+	sb.WriteString(table.Render())
+	if len(risks) > maxEvictionRisks {
+		sb.WriteString("  " + StatusStyle.Render(fmt.Sprintf("... %d more", len(risks)-maxEvictionRisks)) + "\n")
+	}
+	return sb.String()
+}
+
+// renderCard renders a bordered card with a title above its lines
+func renderCard(title string, lines []string) string {
+	return CardStyle.Render(TableHeaderStyle.Render(title) + "\n" + strings.Join(lines, "\n"))
+}
+
+// layoutCards places cards side by side, wrapping to a new row when the
+// next card would not fit in width
+func layoutCards(cards []string, width int) string {
+	var rows, row []string
+	rowWidth := 0
+	for _, card := range cards {
+		w := lipgloss.Width(card)
+		if len(row) > 0 && width > 0 && rowWidth+w > width-2 {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+			row, rowWidth = nil, 0
+		}
+		row = append(row, card)
+		rowWidth += w
+	}
+	if len(row) > 0 {
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+	}
+	return lipgloss.NewStyle().MarginLeft(2).Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
+// podsCard lists the pod count per phase
+func podsCard(pods []resources.PodInfo) []string {
+	counts := make(map[string]int)
+	for _, pod := range pods {
+		counts[pod.Phase]++
+	}
+
+	lines := []string{fmt.Sprintf("%d total", len(pods))}
+	for _, phase := range podPhases {
+		if counts[phase] > 0 {
+			lines = append(lines, fmt.Sprintf("%s %d", StylePodStatus(phase), counts[phase]))
+		}
+	}
+	return lines
+}
+
+// servicesCard lists the service count per type
+func servicesCard(services []resources.ServiceInfo) []string {
+	counts := make(map[string]int)
+	for _, svc := range services {
+		counts[svc.Type]++
+	}
+	types := make([]string, 0, len(counts))
+	for t := range counts {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	lines := []string{fmt.Sprintf("%d total", len(services))}
+	for _, t := range types {
+		lines = append(lines, fmt.Sprintf("%s %d", t, counts[t]))
+	}
+	return lines
+}
+
+// deploymentsCard shows how many deployments have all replicas ready
+func deploymentsCard(info resources.DashboardInfo) []string {
+	if info.DeploymentsErr != nil {
+		return []string{WarningStyle.Render("unavailable: " + resources.ShortError(info.DeploymentsErr))}
+	}
+
+	ready := fmt.Sprintf("%d/%d ready", info.DeploymentsReady, info.Deployments)
+	if info.DeploymentsReady < info.Deployments {
+		return []string{fmt.Sprintf("%d total", info.Deployments), WarningStyle.Render(ready)}
+	}
+	return []string{fmt.Sprintf("%d total", info.Deployments), SuccessStyle.Render(ready)}
+}
+
+// nodesCard shows how many nodes are ready and the ones under memory, disk
+// or PID pressure, which precede evictions
+func nodesCard(info resources.DashboardInfo) []string {
+	if info.NodesErr != nil {
+		return []string{WarningStyle.Render("unavailable: " + resources.ShortError(info.NodesErr))}
+	}
+
+	lines := []string{fmt.Sprintf("%d total", info.Nodes)}
+	ready := fmt.Sprintf("%d/%d ready", info.NodesReady, info.Nodes)
+	if info.NodesReady < info.Nodes {
+		lines = append(lines, WarningStyle.Render(ready))
+	} else {
+		lines = append(lines, SuccessStyle.Render(ready))
+	}
+
+	if len(info.NodePressure) == 0 {
+		return append(lines, SuccessStyle.Render("no pressure"))
+	}
+	for _, p := range info.NodePressure {
+		lines = append(lines, WarningStyle.Render(p.Node+" "+strings.Join(p.Conditions, ", ")))
+	}
+	return lines
+}
+
+// resourcesCard compares the requests of the namespace, and its usage when
+// metrics-server is installed, with the allocatable capacity of the nodes
+func resourcesCard(pods []resources.PodInfo, info resources.DashboardInfo) []string {
+	cpu, mem := resources.PodRequests(pods)
+
+	var lines []string
+	if info.NodesErr != nil {
+		lines = append(lines,
+			fmt.Sprintf("CPU requests    %dm", cpu),
+			fmt.Sprintf("Memory requests %dMi", mem/(1024*1024)),
+			WarningStyle.Render("node capacity unavailable: "+resources.ShortError(info.NodesErr)))
+	} else {
+		lines = append(lines,
+			fmt.Sprintf("CPU requests    %dm / %dm (%s)", cpu, info.CPUAllocatableMilli, share(cpu, info.CPUAllocatableMilli)),
+			fmt.Sprintf("Memory requests %dMi / %dMi (%s)", mem/(1024*1024), info.MemoryAllocatableBytes/(1024*1024), share(mem, info.MemoryAllocatableBytes)))
+	}
+
+	switch {
+	case errors.Is(info.UsageErr, resources.ErrMetricsUnavailable):
+		lines = append(lines, StatusStyle.Render("usage unavailable, metrics-server is not installed"))
+	case info.UsageErr != nil:
+		lines = append(lines, WarningStyle.Render("usage unavailable: "+resources.ShortError(info.UsageErr)))
+	default:
+		lines = append(lines,
+			fmt.Sprintf("CPU usage       %dm", info.CPUUsageMilli),
+			fmt.Sprintf("Memory usage    %dMi", info.MemoryUsageBytes/(1024*1024)))
+	}
+	return lines
+}
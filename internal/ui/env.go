@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zvelocity/k8s-cli/internal/resources"
+)
+
+// RenderEnvView renders the environment variables of a container, those
+// matching the search when there is one. total is the number of variables
+// before filtering. Masked values from Secrets and missing sources are
+// dimmed and flagged. revealed tells whether values from Secrets are shown,
+// canReveal whether they may be.
+func RenderEnvView(pod, container string, vars []resources.EnvVar, total int, lv ListView, revealed, canReveal bool) string {
+	var sb strings.Builder
+
+	title := fmt.Sprintf("Environment of container %s in pod %s", container, pod)
+	if lv.Search != "" {
+		title += fmt.Sprintf(" (%d of %d)", len(vars), total)
+	}
+	sb.WriteString(renderListHeader(title, lv))
+
+	table := Table{
+		Columns: []Column{
+			{Title: "NAME", TruncateMiddle: true, MaxWidth: 50},
+			{Title: "VALUE", MaxWidth: 80},
+			{Title: "SOURCE", Priority: 1, MaxWidth: 60, TruncateMiddle: true},
+		},
+		Selected: lv.Selected,
+		Width:    lv.Width,
+		Height:   lv.Height,
+	}
+	for _, v := range vars {
+		table.Rows = append(table.Rows, []string{
+			lv.highlight(v.Name),
+			envValue(v.Value),
+			envSource(v.Source),
+		})
+	}
+	switch {
+	case total == 0:
+		sb.WriteString(emptyList("No environment variables defined."))
+	case len(vars) == 0:
+		sb.WriteString(emptyList(fmt.Sprintf("No variable matches %q, press esc to show them all.", lv.Search)))
+	default:
+		sb.WriteString(table.Render())
+	}
+
+	revealHelp := ""
+	if canReveal {
+		revealHelp = "{env} reveal secrets • "
+		if revealed {
+			revealHelp = "{env} hide secrets • "
+		}
+	}
+	sb.WriteString(renderHelp(lv.Keys, "  ↑/{up} up • ↓/{down} down • {search} filter • "+revealHelp+"{copy} copy value • {refresh} refresh • esc back • {quit} quit"))
+
+	return sb.String()
+}
+
+// envValue renders the value of a variable on a single line, the
+// placeholders for masked or missing values set apart
+func envValue(value string) string {
+	switch {
+	case value == resources.EnvSourceMissing || value == resources.EnvKeyMissing:
+		return ErrorStyle.Render(value)
+	case strings.HasPrefix(value, "[hidden, ") || value == resources.EnvKubeletValue:
+		return StatusStyle.Render(value)
+	}
+	return strings.ReplaceAll(value, "\n", "\\n")
+}
+
+// envSource renders where a variable comes from, a dash for the values
+// written in the spec
+func envSource(source string) string {
+	if source == "" {
+		return "-"
+	}
+	return source
+}
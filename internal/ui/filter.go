@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+)
+
+// CompileFilter compiles a filter term as a regular expression, falling back
+// to a literal match when the term is not a valid expression
+func CompileFilter(term string) *regexp.Regexp {
+	if re, err := regexp.Compile(term); err == nil {
+		return re
+	}
+	return regexp.MustCompile(regexp.QuoteMeta(term))
+}
+
+// FilterLines returns the lines of content matching re with the matching
+// substrings highlighted, along with the number of matching lines
+func FilterLines(content string, re *regexp.Regexp) (string, int) {
+	var sb strings.Builder
+	matches := 0
+
+	for _, line := range strings.Split(content, "\n") {
+		locs := re.FindAllStringIndex(line, -1)
+		if len(locs) == 0 {
+			continue
+		}
+
+		// Highlight every match, skipping empty ones (e.g. from "a*")
+		last := 0
+		for _, loc := range locs {
+			if loc[0] == loc[1] {
+				continue
+			}
+			sb.WriteString(line[last:loc[0]])
+			sb.WriteString(HighlightStyle.Render(line[loc[0]:loc[1]]))
+			last = loc[1]
+		}
+		sb.WriteString(line[last:])
+		sb.WriteString("\n")
+		matches++
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n"), matches
+}
+
+// FuzzyMatch reports whether the characters of pattern appear in s in order,
+// ignoring case, and scores the match. Matches at the start of s or of a word
+// and runs of consecutive characters score higher.
+func FuzzyMatch(pattern, s string) (int, bool) {
+	r := []rune(strings.ToLower(s))
+	positions, ok := fuzzyPositions(pattern, s)
+	if !ok {
+		return 0, false
+	}
+
+	score, last := 0, -1
+	for _, i := range positions {
+		switch {
+		case i == 0 || r[i-1] == ' ' || r[i-1] == '.' || r[i-1] == '-':
+			score += 10
+		case last == i-1:
+			score += 5
+		default:
+			score++
+		}
+		last = i
+	}
+
+	// Prefer shorter names when the matches are otherwise equal
+	return score*100 - len(r), true
+}
+
+// fuzzyPositions returns the indexes of the runes of s matched by the
+// characters of pattern, taking the first occurrence of each, ignoring case
+func fuzzyPositions(pattern, s string) ([]int, bool) {
+	p := []rune(strings.ToLower(pattern))
+	r := []rune(strings.ToLower(s))
+
+	positions := make([]int, 0, len(p))
+	for i := 0; i < len(r) && len(positions) < len(p); i++ {
+		if r[i] == p[len(positions)] {
+			positions = append(positions, i)
+		}
+	}
+	return positions, len(positions) == len(p)
+}
+
+// HighlightFuzzy highlights the characters of s matched by pattern the way
+// FuzzyMatch matches them. s is returned as is when it doesn't match.
+func HighlightFuzzy(s, pattern string) string {
+	positions, ok := fuzzyPositions(pattern, s)
+	r := []rune(s)
+	// Lowercasing may change the length of unusual characters
+	if !ok || len(positions) == 0 || len(r) != len([]rune(strings.ToLower(s))) {
+		return s
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, i := range positions {
+		sb.WriteString(string(r[last:i]))
+		sb.WriteString(HighlightStyle.Render(string(r[i])))
+		last = i + 1
+	}
+	sb.WriteString(string(r[last:]))
+	return sb.String()
+}
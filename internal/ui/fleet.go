@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zvelocity/k8s-cli/internal/resources"
+)
+
+// RenderFleetView renders a row per kubeconfig context with the state of
+// its cluster. The current context is marked with a star.
+func RenderFleetView(clusters []resources.ClusterSummary, lv ListView) string {
+	var sb strings.Builder
+
+	sb.WriteString(renderListHeader("Clusters of every context", lv))
+
+	table := Table{
+		Columns: []Column{
+			{Title: "CONTEXT", TruncateMiddle: true},
+			{Title: "STATUS"},
+			{Title: "VERSION", Priority: 2},
+			{Title: "NODES", Priority: 1},
+			{Title: "PODS", Priority: 1},
+			{Title: "ERROR", Priority: 3, MaxWidth: 60},
+		},
+		Selected: lv.Selected,
+		Width:    lv.Width,
+		Height:   lv.Height,
+	}
+	for _, c := range clusters {
+		name := lv.highlight(c.Context)
+		if c.Context == lv.Context {
+			name += " *"
+		}
+		if c.Err != nil {
+			table.Rows = append(table.Rows, []string{
+				name,
+				ErrorStyle.Render("Unreachable"),
+				"-", "-", "-",
+				ErrorStyle.Render(resources.ShortError(c.Err)),
+			})
+			continue
+		}
+		table.Rows = append(table.Rows, []string{
+			name,
+			SuccessStyle.Render("Reachable"),
+			c.Version,
+			readyCount(c.NodesReady, c.Nodes, c.NodesErr),
+			readyCount(c.PodsReady, c.Pods, c.PodsErr),
+			"",
+		})
+	}
+	if len(clusters) == 0 {
+		sb.WriteString(emptyList("The kubeconfig has no contexts, press esc to go back."))
+	} else {
+		sb.WriteString(table.Render())
+	}
+
+	sb.WriteString(renderHelp(lv.Keys, "  ↑/{up} up • ↓/{down} down • {search} search • enter switch to context • {refresh} refresh • esc back • {quit} quit"))
+
+	return sb.String()
+}
+
+// readyCount renders "ready/total", in the warning color when some are not
+// ready, or why they could not be counted
+func readyCount(ready, total int, err error) string {
+	if err != nil {
+		return WarningStyle.Render(resources.ShortError(err))
+	}
+	count := fmt.Sprintf("%d/%d", ready, total)
+	if ready < total {
+		return WarningStyle.Render(count)
+	}
+	return count
+}
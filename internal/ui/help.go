@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Keys are the keys bound to the remappable actions as help lines show
+// them, by the action names of the configuration file, e.g. "delete" to "d"
+type Keys map[string]string
+
+// helpKeyPattern matches the {action} standing for the key of an action in
+// a help line
+var helpKeyPattern = regexp.MustCompile(`\{[a-z-]+\}`)
+
+// renderHelp renders a help line, "key action" entries separated by " • ",
+// where {name} stands for the key bound to the action of that name, e.g.
+// "↑/{up} up • enter details"
+func renderHelp(keys Keys, help string) string {
+	return HelpStyle.Render(keys.expand(help))
+}
+
+// expand replaces the {name} in a text with the key bound to the action of
+// that name, for the hints of messages, e.g. "Press {refresh} to refresh"
+func (k Keys) expand(text string) string {
+	return helpKeyPattern.ReplaceAllStringFunc(text, func(ref string) string {
+		if key, ok := k[strings.Trim(ref, "{}")]; ok {
+			return key
+		}
+		return ref
+	})
+}
+
+// KeyHelp is a key and what it does, as the key bindings overlay lists it
+type KeyHelp struct {
+	Key  string
+	Help string
+}
+
+// RenderKeyBindings renders the key bindings overlay, the keys in as many
+// columns as fit in width
+func RenderKeyBindings(bindings []KeyHelp, width int) string {
+	keyWidth, entryWidth := 0, 0
+	for _, b := range bindings {
+		keyWidth = max(keyWidth, ansi.StringWidth(b.Key))
+	}
+	for _, b := range bindings {
+		entryWidth = max(entryWidth, keyWidth+2+ansi.StringWidth(b.Help))
+	}
+
+	// The border and padding of the box take 6 columns, entries are 3 apart
+	columns := 1
+	if width > 0 {
+		columns = max(min((width-6+3)/(entryWidth+3), 4), 1)
+	}
+	rows := (len(bindings) + columns - 1) / columns
+
+	var sb strings.Builder
+	sb.WriteString(TitleStyle.UnsetMargins().Render("Key bindings"))
+	sb.WriteString("\n\n")
+	for row := range rows {
+		var line strings.Builder
+		for col := range columns {
+			i := col*rows + row
+			if i >= len(bindings) {
+				break
+			}
+			if col > 0 {
+				line.WriteString("   ")
+			}
+			b := bindings[i]
+			line.WriteString(InfoStyle.Render(b.Key) + strings.Repeat(" ", keyWidth-ansi.StringWidth(b.Key)+2) + b.Help)
+			line.WriteString(strings.Repeat(" ", entryWidth-keyWidth-2-ansi.StringWidth(b.Help)))
+		}
+		sb.WriteString(strings.TrimRight(line.String(), " "))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+	sb.WriteString(StatusStyle.Render("any key to close"))
+	return ModalStyle.Render(sb.String())
+}
@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestRenderHelp(t *testing.T) {
+	keys := Keys{"up": "k", "search": "/", "mark": "space", "wide": "ctrl+w"}
+	got := strings.TrimSpace(ansi.Strip(renderHelp(keys, "  ↑/{up} up • {search} search • {mark} mark • {wide} wide • {unknown} kept • esc back")))
+	want := "↑/k up • / search • space mark • ctrl+w wide • {unknown} kept • esc back"
+	if got != want {
+		t.Errorf("got  %q\nwant %q", got, want)
+	}
+}
+
+func TestEmptyListHintsFollowBindings(t *testing.T) {
+	lv := ListView{Namespace: "shop", Keys: Keys{"refresh": "ctrl+r", "namespaces": "N", "unhealthy": "z"}}
+	got := ansi.Strip(emptyNamespaceList("pods", lv))
+	if want := "Press ctrl+r to refresh or N to switch namespace."; !strings.Contains(got, want) {
+		t.Errorf("got %q, want it to contain %q", got, want)
+	}
+
+	lv.OnlyUnhealthy, lv.HiddenHealthy = true, 3
+	got = ansi.Strip(emptyNamespaceList("pods", lv))
+	if want := "Press z to show them."; !strings.Contains(got, want) {
+		t.Errorf("got %q, want it to contain %q", got, want)
+	}
+}
+
+func TestRenderKeyBindings(t *testing.T) {
+	bindings := []KeyHelp{{"enter", "open"}, {"esc", "back"}, {"q", "quit"}, {"ctrl+x", "delete"}, {"?", "key bindings"}}
+	for _, width := range []int{0, 30, 80, 200} {
+		box := RenderKeyBindings(bindings, width)
+		if width > 0 && lipgloss.Width(box) > width {
+			t.Errorf("width %d: box is %d wide", width, lipgloss.Width(box))
+		}
+		plain := ansi.Strip(box)
+		for _, b := range bindings {
+			if !strings.Contains(plain, b.Help) {
+				t.Errorf("width %d: %q not listed", width, b.Help)
+			}
+		}
+	}
+}
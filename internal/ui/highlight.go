@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/zvelocity/k8s-cli/internal/resources"
+)
+
+// StyleDetail highlights the flags of detail text, as errors or warnings.
+// Only the parts of lines flagged as they were written are styled, the same
+// words elsewhere, e.g. in a label, are left alone.
+func StyleDetail(detail string, flags []resources.DetailFlag) string {
+	if len(flags) == 0 {
+		return detail
+	}
+	byLine := make(map[int][]resources.DetailFlag, len(flags))
+	for _, f := range flags {
+		byLine[f.Line] = append(byLine[f.Line], f)
+	}
+
+	lines := strings.Split(detail, "\n")
+	for i, line := range lines {
+		lineFlags := byLine[i]
+		if len(lineFlags) == 0 {
+			continue
+		}
+		// Later flags first so the offsets of the earlier ones hold
+		sort.Slice(lineFlags, func(a, b int) bool { return lineFlags[a].Start < lineFlags[b].Start })
+		for j := len(lineFlags) - 1; j >= 0; j-- {
+			f := lineFlags[j]
+			if f.Start < 0 || f.Start > f.End || f.End > len(line) {
+				continue
+			}
+			style := WarningStyle
+			if f.Severe {
+				style = ErrorStyle
+			}
+			line = line[:f.Start] + style.Render(line[f.Start:f.End]) + line[f.End:]
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
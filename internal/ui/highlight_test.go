@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+
+	"github.com/zvelocity/k8s-cli/internal/resources"
+)
+
+func TestStyleDetail(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	defer lipgloss.SetColorProfile(profile)
+
+	detail := "Labels:\n  status: EXPIRED\n  Expires: 2020-01-01T00:00:00Z (EXPIRED)\n  Tag: latest [mutable tag] [no probes]"
+	start := strings.LastIndex("  Expires: 2020-01-01T00:00:00Z (EXPIRED)", "EXPIRED")
+	flags := []resources.DetailFlag{
+		{Line: 2, Start: start, End: start + len("EXPIRED"), Severe: true},
+		{Line: 3, Start: 28, End: 39},
+		{Line: 3, Start: 14, End: 27},
+	}
+	lines := strings.Split(StyleDetail(detail, flags), "\n")
+
+	if lines[1] != "  status: EXPIRED" {
+		t.Errorf("unflagged line styled: %q", lines[1])
+	}
+	if want := "  Expires: 2020-01-01T00:00:00Z (" + ErrorStyle.Render("EXPIRED") + ")"; lines[2] != want {
+		t.Errorf("got  %q\nwant %q", lines[2], want)
+	}
+	if want := "  Tag: latest " + WarningStyle.Render("[mutable tag]") + " " + WarningStyle.Render("[no probes]"); lines[3] != want {
+		t.Errorf("got  %q\nwant %q", lines[3], want)
+	}
+	if got := ansi.Strip(strings.Join(lines, "\n")); got != detail {
+		t.Errorf("text changed: %q", got)
+	}
+}
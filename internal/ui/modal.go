@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// ModalResult tells how a key press left a modal
+type ModalResult int
+
+const (
+	// ModalOpen means the modal is still waiting for an answer
+	ModalOpen ModalResult = iota
+	// ModalConfirmed means the user answered yes or submitted the input
+	ModalConfirmed
+	// ModalCancelled means the user dismissed the modal
+	ModalCancelled
+)
+
+// modalBodyWidth is the width the body of a modal is wrapped at
+const modalBodyWidth = 60
+
+// Modal is a dialog drawn over the current view, asking either a yes/no
+// question or for a line of text. It captures all keys until it is
+// confirmed or cancelled.
+type Modal struct {
+	Title string
+	Body  string
+
+	// Danger renders the title in the error color, for destructive actions
+	Danger bool
+
+	prompt bool
+	input  textinput.Model
+}
+
+// NewConfirmModal returns a modal answered with y or n
+func NewConfirmModal(title, body string) Modal {
+	return Modal{Title: title, Body: body}
+}
+
+// NewPromptModal returns a modal asking for a line of text, submitted with
+// enter. The placeholder is shown while the input is empty.
+func NewPromptModal(title, body, placeholder string) Modal {
+	input := textinput.New()
+	input.Prompt = "> "
+	input.Placeholder = placeholder
+	input.Focus()
+	return Modal{Title: title, Body: body, prompt: true, input: input}
+}
+
+// Init returns the command starting the cursor blink of a prompt
+func (m Modal) Init() tea.Cmd {
+	if m.prompt {
+		return textinput.Blink
+	}
+	return nil
+}
+
+// Update handles a key press and reports whether it answered the modal.
+// Keys that don't answer a yes/no question are ignored.
+func (m Modal) Update(msg tea.KeyMsg) (Modal, ModalResult, tea.Cmd) {
+	if msg.String() == "esc" {
+		return m, ModalCancelled, nil
+	}
+
+	if !m.prompt {
+		switch msg.String() {
+		case "y", "Y":
+			return m, ModalConfirmed, nil
+		case "n", "N":
+			return m, ModalCancelled, nil
+		}
+		return m, ModalOpen, nil
+	}
+
+	if msg.String() == "enter" {
+		return m, ModalConfirmed, nil
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, ModalOpen, cmd
+}
+
+// Value returns the text typed into a prompt
+func (m Modal) Value() string {
+	return m.input.Value()
+}
+
+// View renders the modal box
+func (m Modal) View() string {
+	var sb strings.Builder
+
+	if m.Danger {
+		sb.WriteString(ErrorStyle.Bold(true).Render(m.Title))
+	} else {
+		sb.WriteString(TitleStyle.UnsetMargins().Render(m.Title))
+	}
+	if m.Body != "" {
+		body := m.Body
+		if lipgloss.Width(body) > modalBodyWidth {
+			body = lipgloss.NewStyle().Width(modalBodyWidth).Render(body)
+		}
+		sb.WriteString("\n\n")
+		sb.WriteString(body)
+	}
+	sb.WriteString("\n\n")
+
+	if m.prompt {
+		sb.WriteString(m.input.View())
+		sb.WriteString("\n\n")
+		sb.WriteString(StatusStyle.Render("enter confirm • esc cancel"))
+	} else {
+		sb.WriteString(StatusStyle.Render("y yes • n/esc no"))
+	}
+
+	return ModalStyle.Render(sb.String())
+}
+
+// OverlayCenter draws box centered over base, which is width columns wide
+// and height lines high. The size of base is used when they are 0.
+func OverlayCenter(base, box string, width, height int) string {
+	lines := strings.Split(base, "\n")
+	if width == 0 {
+		width = lipgloss.Width(base)
+	}
+	if height == 0 {
+		height = len(lines)
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+
+	boxLines := strings.Split(box, "\n")
+	boxWidth := lipgloss.Width(box)
+	top := max((height-len(boxLines))/2, 0)
+	left := max((width-boxWidth)/2, 0)
+
+	for i, boxLine := range boxLines {
+		row := top + i
+		if row >= len(lines) {
+			lines = append(lines, "")
+		}
+		line := lines[row]
+
+		// Keep what the box doesn't cover on both sides
+		before := ansi.Truncate(line, left, "")
+		before += strings.Repeat(" ", left-ansi.StringWidth(before))
+		after := ansi.TruncateLeft(line, left+boxWidth, "")
+		lines[row] = before + boxLine + after
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/zvelocity/k8s-cli/internal/resources"
+)
+
+// gaugeWidth is the number of cells of a quota usage bar
+const gaugeWidth = 20
+
+// RenderResourceQuotasView renders the usage of every dimension of the
+// resource quotas of a namespace as bars, the ones above
+// resources.QuotaPressure highlighted
+func RenderResourceQuotasView(quotas []resources.ResourceQuotaInfo, lv ListView) string {
+	var sb strings.Builder
+
+	sb.WriteString(renderListHeader(fmt.Sprintf("Resource quotas in namespace: %s", lv.Namespace), lv))
+
+	if len(quotas) == 0 {
+		sb.WriteString(emptyList(fmt.Sprintf("No resource quotas in namespace %s, nothing limits it beyond the node capacity.", lv.Namespace)))
+	}
+
+	for i, quota := range quotas {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("  " + TableHeaderStyle.Render(quota.Name) + " " + StatusStyle.Render(FormatAge(quota.Age, quota.Created, lv.AbsoluteTime)))
+		sb.WriteString("\n")
+
+		table := Table{
+			Columns: []Column{
+				{Title: "RESOURCE"},
+				{Title: "USED", Priority: 2},
+				{Title: "HARD", Priority: 2},
+				{Title: "USAGE", Priority: 1},
+			},
+			Selected: -1,
+			Width:    lv.Width,
+		}
+		for _, d := range quota.Dimensions {
+			table.Rows = append(table.Rows, []string{d.Resource, d.Used, d.Hard, quotaGauge(d.Ratio)})
+		}
+		sb.WriteString(table.Render())
+	}
+
+	sb.WriteString(renderHelp(lv.Keys, "  {dashboard} dashboard • {refresh} refresh • esc back • {quit} quit"))
+
+	return sb.String()
+}
+
+// quotaGauge renders a usage ratio as a text bar followed by the percentage,
+// in the warning color above resources.QuotaPressure and the error color
+// once the limit is reached
+func quotaGauge(ratio float64) string {
+	filled := int(math.Round(min(ratio, 1) * gaugeWidth))
+	gauge := strings.Repeat("█", filled) + strings.Repeat("░", gaugeWidth-filled) + fmt.Sprintf(" %3.0f%%", ratio*100)
+
+	switch {
+	case ratio >= 1:
+		return ErrorStyle.Render(gauge)
+	case ratio >= resources.QuotaPressure:
+		return WarningStyle.Render(gauge)
+	}
+	return gauge
+}
+
+// quotasCard lists the quota dimensions used above resources.QuotaPressure
+func quotasCard(info resources.DashboardInfo) []string {
+	if info.QuotasErr != nil {
+		return []string{WarningStyle.Render("unavailable: " + resources.ShortError(info.QuotasErr))}
+	}
+	if len(info.Quotas) == 0 {
+		return []string{StatusStyle.Render("none")}
+	}
+
+	lines := []string{fmt.Sprintf("%d total", len(info.Quotas))}
+	pressured := resources.QuotasUnderPressure(info.Quotas)
+	if len(pressured) == 0 {
+		return append(lines, SuccessStyle.Render(fmt.Sprintf("all below %.0f%%", resources.QuotaPressure*100)))
+	}
+	for _, p := range pressured {
+		lines = append(lines, WarningStyle.Render(p))
+	}
+	return lines
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/zvelocity/k8s-cli/internal/resources"
+)
+
+// RenderRecentView renders the resources opened recently, most recent first
+func RenderRecentView(recent []resources.RecentResource, lv ListView) string {
+	var sb strings.Builder
+
+	sb.WriteString(renderListHeader("Recently opened", lv))
+
+	openedTitle := "OPENED"
+	if lv.AbsoluteTime {
+		openedTitle = "OPENED AT"
+	}
+	table := Table{
+		Columns: []Column{
+			{Title: "NAME", TruncateMiddle: true},
+			{Title: "KIND", Priority: 1},
+			{Title: "NAMESPACE", Priority: 2, MaxWidth: 30},
+			{Title: openedTitle, Priority: 3},
+		},
+		Selected: lv.Selected,
+		Width:    lv.Width,
+		Height:   lv.Height,
+	}
+	for _, r := range recent {
+		ago := resources.FormatDuration(time.Since(r.Opened).Round(time.Second)) + " ago"
+		table.Rows = append(table.Rows, []string{
+			lv.highlight(r.Name),
+			string(r.Kind),
+			orNone(r.Namespace),
+			FormatAge(ago, r.Opened, lv.AbsoluteTime),
+		})
+	}
+	if len(recent) == 0 {
+		sb.WriteString(emptyList("Nothing opened yet. The resources whose details you open are listed here, press esc to go back."))
+	} else {
+		sb.WriteString(table.Render())
+	}
+
+	sb.WriteString(renderHelp(lv.Keys, "  ↑/{up} up • ↓/{down} down • {search} search • enter open • esc back • {quit} quit"))
+
+	return sb.String()
+}
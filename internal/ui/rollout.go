@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zvelocity/k8s-cli/internal/resources"
+)
+
+// RenderRolloutHistoryView renders the revisions of a Deployment, newest
+// first, the current one marked. The rollback key is only advertised when
+// canRollback is true.
+func RenderRolloutHistoryView(deployment string, revisions []resources.RevisionInfo, lv ListView, canRollback bool) string {
+	var sb strings.Builder
+
+	sb.WriteString(renderListHeader(fmt.Sprintf("Rollout history of %s", deployment), lv))
+
+	createdTitle := "AGE"
+	if lv.AbsoluteTime {
+		createdTitle = "CREATED"
+	}
+	table := Table{
+		Columns: []Column{
+			{Title: "REVISION"},
+			{Title: "REPLICASET", Priority: 2, TruncateMiddle: true},
+			{Title: "IMAGES", Priority: 1, MaxWidth: 60},
+			{Title: "READY", Priority: 1},
+			{Title: createdTitle, Priority: 2},
+			{Title: "CHANGE-CAUSE", Priority: 3, MaxWidth: 50},
+		},
+		Selected: lv.Selected,
+		Width:    lv.Width,
+		Height:   lv.Height,
+	}
+	for _, r := range revisions {
+		revision := fmt.Sprintf("%d", r.Revision)
+		if r.Current {
+			revision = SuccessStyle.Render(revision + " (current)")
+		}
+		table.Rows = append(table.Rows, []string{
+			revision,
+			lv.highlight(r.ReplicaSet),
+			strings.Join(r.Images, ", "),
+			fmt.Sprintf("%d/%d", r.ReadyReplicas, r.Replicas),
+			FormatAge(r.Age, r.Created, lv.AbsoluteTime),
+			orNone(r.ChangeCause),
+		})
+	}
+	if len(revisions) == 0 {
+		sb.WriteString(emptyList(lv.Keys.expand(fmt.Sprintf("No revisions of %s found. Press {refresh} to refresh or esc to go back.", deployment))))
+	} else {
+		sb.WriteString(table.Render())
+	}
+
+	rollbackHelp := ""
+	if canRollback {
+		rollbackHelp = "{rollback} roll back • "
+	}
+	sb.WriteString(renderHelp(lv.Keys, "  ↑/{up} up • ↓/{down} down • {search} search • enter revision pods • "+rollbackHelp+"{refresh} refresh • esc back • {quit} quit"))
+
+	return sb.String()
+}
@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// rowStyleNames are the styles row rules can apply, by the names used in
+// the configuration file
+var rowStyleNames = map[string]*lipgloss.Style{
+	"error":   &ErrorStyle,
+	"warning": &WarningStyle,
+	"success": &SuccessStyle,
+	"info":    &InfoStyle,
+	"dim":     &StatusStyle,
+	"marked":  &MarkedStyle,
+}
+
+// rowRule styles the rows whose labels match selector
+type rowRule struct {
+	selector labels.Selector
+	style    *lipgloss.Style
+}
+
+// rowRules are the rules styling list rows, in the order they are tried
+var rowRules []rowRule
+
+// AddRowStyle adds a rule styling the pod and service rows whose labels
+// match a label selector, e.g. "tier=critical" with the style "error". The
+// first rule matching a row wins, in the order they are added.
+func AddRowStyle(selector, style string) error {
+	s, err := labels.Parse(selector)
+	if err != nil {
+		return fmt.Errorf("invalid selector %q: %v", selector, err)
+	}
+	if s.Empty() {
+		return fmt.Errorf("empty selector, it would match every row")
+	}
+	st, ok := rowStyleNames[style]
+	if !ok {
+		names := make([]string, 0, len(rowStyleNames))
+		for name := range rowStyleNames {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unknown style %q, expected one of %s", style, strings.Join(names, ", "))
+	}
+	rowRules = append(rowRules, rowRule{s, st})
+	return nil
+}
+
+// labelStyle returns the style of the first rule matching the labels of a
+// row, nil when none does
+func labelStyle(l map[string]string) *lipgloss.Style {
+	i := slices.IndexFunc(rowRules, func(r rowRule) bool {
+		return r.selector.Matches(labels.Set(l))
+	})
+	if i < 0 {
+		return nil
+	}
+	return rowRules[i].style
+}
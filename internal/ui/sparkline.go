@@ -0,0 +1,31 @@
+package ui
+
+import "strings"
+
+// sparkBlocks are the characters used to draw sparklines, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws values as a line of unicode block characters scaled
+// between the smallest and largest value
+func Sparkline(values []int64) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo = min(lo, v)
+		hi = max(hi, v)
+	}
+
+	var sb strings.Builder
+	for _, v := range values {
+		idx := 0
+		if hi > lo {
+			idx = int((v - lo) * int64(len(sparkBlocks)-1) / (hi - lo))
+		}
+		sb.WriteRune(sparkBlocks[idx])
+	}
+
+	return sb.String()
+}
@@ -1,7 +1,13 @@
 package ui
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/zvelocity/k8s-cli/internal/resources"
 )
 
 // Common styles used throughout the application
@@ -18,6 +24,12 @@ var (
 				PaddingLeft(2).
 				Foreground(lipgloss.Color("170"))
 
+	PaletteStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("62")).
+			Padding(0, 1).
+			Width(50)
+
 	StatusStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("240"))
 
@@ -42,18 +54,91 @@ var (
 			Bold(true).
 			Underline(true).
 			Foreground(lipgloss.Color("69"))
+
+	InfoStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("45"))
+
+	ProdBadgeStyle = lipgloss.NewStyle().
+			Bold(true).
+			Padding(0, 1).
+			Foreground(lipgloss.Color("15")).
+			Background(lipgloss.Color("9"))
+
+	ModalStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("62")).
+			Padding(1, 2)
+
+	CardStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("62")).
+			Padding(0, 1).
+			MarginRight(1)
+
+	MarkedStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("214"))
+
+	// ChangedStyle marks the cells changed by the last refresh
+	ChangedStyle = lipgloss.NewStyle().
+			Bold(true).
+			Reverse(true)
+
+	HighlightStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("0")).
+			Background(lipgloss.Color("220"))
 )
 
+// SetTheme selects the color theme: "default", or "monochrome" to render
+// without colors
+func SetTheme(name string) error {
+	switch name {
+	case "", "default":
+		return nil
+	case "monochrome":
+		DisableColor()
+		return nil
+	}
+	return fmt.Errorf("unknown theme %q, expected default or monochrome", name)
+}
+
+// DisableColor renders every style as plain text, without colors or any
+// other escape code, whatever the theme
+func DisableColor() {
+	lipgloss.SetColorProfile(termenv.Ascii)
+}
+
 // StylePodStatus returns a styled pod status string based on its status value
 func StylePodStatus(status string) string {
 	switch status {
 	case "Running":
 		return SuccessStyle.Render(status)
-	case "Pending":
+	case resources.PodStarting:
+		return InfoStyle.Render(status)
+	case "Pending", "Unschedulable", "SchedulingGated":
 		return WarningStyle.Render(status)
 	case "Failed", "Unknown", "Error":
 		return ErrorStyle.Render(status)
-	default:
-		return status
 	}
+
+	// Init progress like "Init:0/2" is a warning, any other init status
+	// (e.g. "Init:CrashLoopBackOff") means an init container failed
+	if reason, ok := strings.CutPrefix(status, "Init:"); ok {
+		if strings.Contains(reason, "/") {
+			return WarningStyle.Render(status)
+		}
+		return ErrorStyle.Render(status)
+	}
+
+	return status
+}
+
+// StyleQOSClass returns a styled QoS class. BestEffort pods are evicted first
+// under memory pressure, so they are called out.
+func StyleQOSClass(class string) string {
+	if class == "BestEffort" {
+		return WarningStyle.Render(class)
+	}
+	return class
 }
@@ -0,0 +1,233 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// ListHeaderHeight is the number of lines list views render above the first
+// table row, used to map mouse clicks to rows
+const ListHeaderHeight = 4
+
+const (
+	// tableIndent is the space reserved left of every row for the cursor
+	tableIndent = 4
+
+	// minNameWidth is the narrowest the first column is shrunk to before
+	// other columns are dropped
+	minNameWidth = 20
+)
+
+// Column describes a table column
+type Column struct {
+	Title string
+
+	// Priority decides which columns are dropped first when the terminal is
+	// too narrow. Higher values are dropped first and 0 is never dropped.
+	Priority int
+
+	// MaxWidth caps the column width, 0 means no cap
+	MaxWidth int
+
+	// TruncateMiddle shortens long cells in the middle instead of at the
+	// end, keeping the suffix that tells generated names apart
+	TruncateMiddle bool
+
+	// Wide columns are only shown in wide mode, like kubectl's -o wide
+	Wide bool
+}
+
+// Table renders rows as aligned columns fitting the terminal width. Cells may
+// contain styled text; styling does not count toward column widths.
+type Table struct {
+	Columns  []Column
+	Rows     [][]string
+	Selected int
+
+	// Width is the terminal width, 0 when unknown
+	Width int
+
+	// Wide shows the Wide columns
+	Wide bool
+
+	// Height is the number of rows shown at once, 0 to show all of them.
+	// Longer tables are shown a page at a time, the page holding the
+	// selected row, with an indicator of the rows shown.
+	Height int
+
+	// RowStyles styles the cells of the rows by index and marks them with a
+	// bar, nil entries and rows past the end are left as they are
+	RowStyles []*lipgloss.Style
+}
+
+// Render renders the header and rows of the table
+func (t Table) Render() string {
+	widths := t.columnWidths()
+
+	var sb strings.Builder
+
+	// Header
+	header := make([]string, 0, len(t.Columns))
+	for i, col := range t.Columns {
+		if widths[i] > 0 {
+			header = append(header, fitCell(col.Title, widths[i]))
+		}
+	}
+	sb.WriteString(strings.Repeat(" ", tableIndent))
+	sb.WriteString(TableHeaderStyle.Render(strings.Join(header, " ")))
+	sb.WriteString("\n")
+
+	// Rows of the current page
+	start, end := 0, len(t.Rows)
+	if t.Height > 0 && len(t.Rows) > t.Height {
+		start = PageStart(t.Selected, t.Height)
+		end = min(start+t.Height, len(t.Rows))
+	}
+	for r := start; r < end; r++ {
+		row := t.Rows[r]
+		var style *lipgloss.Style
+		if r < len(t.RowStyles) {
+			style = t.RowStyles[r]
+		}
+		cells := make([]string, 0, len(t.Columns))
+		for i := range t.Columns {
+			if widths[i] == 0 {
+				continue
+			}
+			cell := ""
+			if i < len(row) {
+				cell = row[i]
+			}
+			if t.Columns[i].TruncateMiddle {
+				cell = TruncateMiddle(cell, widths[i])
+			}
+			cell = fitCell(cell, widths[i])
+			if style != nil {
+				cell = style.Render(cell)
+			}
+			cells = append(cells, cell)
+		}
+
+		line := strings.Join(cells, " ")
+		switch {
+		case r == t.Selected:
+			sb.WriteString(SelectedItemStyle.Render("> " + line))
+		case style != nil:
+			sb.WriteString(strings.Repeat(" ", tableIndent-2) + style.Render("▌") + " " + line)
+		default:
+			sb.WriteString(ItemStyle.Render(line))
+		}
+		sb.WriteString("\n")
+	}
+
+	if start > 0 || end < len(t.Rows) {
+		sb.WriteString(strings.Repeat(" ", tableIndent))
+		sb.WriteString(StatusStyle.Render(fmt.Sprintf("%d-%d of %d", start+1, end, len(t.Rows))))
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// PageStart returns the index of the first row of the page holding the
+// selected row, for pages of height rows
+func PageStart(selected, height int) int {
+	if height <= 0 || selected < 0 {
+		return 0
+	}
+	return selected / height * height
+}
+
+// columnWidths computes the width of every column, 0 for dropped columns
+func (t Table) columnWidths() []int {
+	widths := make([]int, len(t.Columns))
+	for i, col := range t.Columns {
+		if col.Wide && !t.Wide {
+			continue
+		}
+		widths[i] = ansi.StringWidth(col.Title)
+		for _, row := range t.Rows {
+			if i < len(row) {
+				widths[i] = max(widths[i], ansi.StringWidth(row[i]))
+			}
+		}
+		if col.MaxWidth > 0 {
+			widths[i] = min(widths[i], col.MaxWidth)
+		}
+	}
+
+	if t.Width <= 0 {
+		return widths
+	}
+	available := t.Width - tableIndent
+
+	// Drop low priority columns until the table fits with the first column
+	// shrunk as far as allowed
+	for total(widths)-max(widths[0]-minNameWidth, 0) > available {
+		drop := -1
+		for i, col := range t.Columns {
+			if widths[i] == 0 || col.Priority == 0 {
+				continue
+			}
+			if drop == -1 || col.Priority >= t.Columns[drop].Priority {
+				drop = i
+			}
+		}
+		if drop == -1 {
+			break
+		}
+		widths[drop] = 0
+	}
+
+	// Shrink the first column if the essential columns still don't fit
+	if over := total(widths) - available; over > 0 {
+		widths[0] = max(widths[0]-over, minNameWidth)
+	}
+
+	return widths
+}
+
+// total returns the rendered width of the visible columns including separators
+func total(widths []int) int {
+	sum, visible := 0, 0
+	for _, w := range widths {
+		if w > 0 {
+			sum += w
+			visible++
+		}
+	}
+	if visible > 1 {
+		sum += visible - 1
+	}
+	return sum
+}
+
+// TruncateMiddle shortens s to width cells by replacing its middle with an
+// ellipsis, e.g. "my-deploy-7f9c…-abc12"
+func TruncateMiddle(s string, width int) string {
+	w := ansi.StringWidth(s)
+	if w <= width {
+		return s
+	}
+	if width < 3 {
+		return ansi.Truncate(s, width, "…")
+	}
+
+	// Favor the prefix by a cell when the budget is uneven
+	keep := width - 1
+	tail := keep / 2
+	head := keep - tail
+	return ansi.Truncate(s, head, "") + "…" + ansi.TruncateLeft(s, w-tail, "")
+}
+
+// fitCell truncates s with an ellipsis or pads it to exactly width cells
+func fitCell(s string, width int) string {
+	w := ansi.StringWidth(s)
+	if w > width {
+		return ansi.Truncate(s, width, "…")
+	}
+	return s + strings.Repeat(" ", width-w)
+}
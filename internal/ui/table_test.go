@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestTruncateMiddle(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		width int
+		want  string
+	}{
+		{"shorter", "web-0", 10, "web-0"},
+		{"exact", "web-0", 5, "web-0"},
+		{"longer, even budget", "my-deploy-7f9c8-abc12", 10, "my-de…bc12"},
+		{"longer, odd budget", "my-deploy-7f9c8-abc12", 11, "my-de…abc12"},
+		{"one over", "abcdef", 5, "ab…ef"},
+		{"width 3", "abcdef", 3, "a…f"},
+		{"width 2", "abcdef", 2, "a…"},
+		{"width 1", "abcdef", 1, "…"},
+		{"width 0", "abcdef", 0, ""},
+		{"wide characters", "名前空間のポッド", 7, "名…ッド"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TruncateMiddle(tt.s, tt.width)
+			if got != tt.want {
+				t.Errorf("TruncateMiddle(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+			}
+			if w := ansi.StringWidth(got); w > tt.width && tt.width >= 0 {
+				t.Errorf("TruncateMiddle(%q, %d) is %d cells wide", tt.s, tt.width, w)
+			}
+		})
+	}
+}
+
+func TestTruncateMiddleStyled(t *testing.T) {
+	styled := "\x1b[31mmy-deploy-7f9c8-abc12\x1b[0m"
+
+	if got := TruncateMiddle(styled, 21); got != styled {
+		t.Errorf("styled input at its width = %q, want it unchanged", got)
+	}
+
+	got := TruncateMiddle(styled, 10)
+	if w := ansi.StringWidth(got); w != 10 {
+		t.Errorf("styled input truncated to %d cells, want 10: %q", w, got)
+	}
+	if plain := ansi.Strip(got); plain != "my-de…bc12" {
+		t.Errorf("styled input truncated to %q, want my-de…bc12", plain)
+	}
+}
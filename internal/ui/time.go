@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/zvelocity/k8s-cli/internal/resources"
+)
+
+// rfc3339Pattern matches an RFC3339 timestamp
+var rfc3339Pattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})`)
+
+// detailTimePattern matches the timestamps of detail text with their label,
+// see resources.TimestampLabels
+var detailTimePattern = func() *regexp.Regexp {
+	labels := make([]string, len(resources.TimestampLabels))
+	for i, label := range resources.TimestampLabels {
+		labels[i] = regexp.QuoteMeta(label)
+	}
+	return regexp.MustCompile(`(?m)(?:^[ \t]*(?:- )?|\()(?:` + strings.Join(labels, "|") + `)` + rfc3339Pattern.String())
+}()
+
+// FormatAge returns the relative age, or the creation time in RFC3339 form
+// when absolute is true
+func FormatAge(age string, created time.Time, absolute bool) string {
+	if !absolute || created.IsZero() {
+		return age
+	}
+	return created.Local().Format(time.RFC3339)
+}
+
+// RelativeTimes rewrites the timestamps of detail text, the ones after
+// resources.TimestampLabels, as durations relative to now, e.g. "5d12h ago"
+// or "in 30d0h"
+func RelativeTimes(detail string) string {
+	return detailTimePattern.ReplaceAllStringFunc(detail, func(s string) string {
+		loc := rfc3339Pattern.FindStringIndex(s)
+		t, err := time.Parse(time.RFC3339, s[loc[0]:loc[1]])
+		if err != nil {
+			return s
+		}
+
+		d := time.Since(t).Round(time.Second)
+		if d < 0 {
+			return s[:loc[0]] + "in " + resources.FormatDuration(-d)
+		}
+		return s[:loc[0]] + resources.FormatDuration(d) + " ago"
+	})
+}
+
+// ageTitle returns the header of an age column
+func ageTitle(absolute bool) string {
+	if absolute {
+		return "CREATED"
+	}
+	return "AGE"
+}
+
+// lastSeenTitle returns the header of the event age column
+func lastSeenTitle(absolute bool) string {
+	if absolute {
+		return "LAST SEEN"
+	}
+	return "AGE"
+}
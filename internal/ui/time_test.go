@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRelativeTimes(t *testing.T) {
+	ago := time.Now().Add(-50 * time.Hour).UTC().Format(time.RFC3339)
+	ahead := time.Now().Add(50*time.Hour + time.Minute).UTC().Format(time.RFC3339)
+
+	detail := strings.Join([]string{
+		"Created: " + ago,
+		"      State: Running (started at " + ago + ")",
+		"        Finished: " + ago,
+		"  - Subject: CN=web",
+		"    Expires: " + ahead + " (2 days left)",
+		"Annotations:",
+		"  deployed-at: " + ago,
+		"  note: restarted at " + ago,
+	}, "\n")
+
+	want := strings.Join([]string{
+		"Created: 2d2h ago",
+		"      State: Running (started at 2d2h ago)",
+		"        Finished: 2d2h ago",
+		"  - Subject: CN=web",
+		"    Expires: in 2d2h (2 days left)",
+		"Annotations:",
+		"  deployed-at: " + ago,
+		"  note: restarted at " + ago,
+	}, "\n")
+
+	if got := RelativeTimes(detail); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
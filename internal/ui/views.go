@@ -0,0 +1,1256 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/zvelocity/k8s-cli/internal/resources"
+)
+
+// RenderLoadingView renders the loading screen with a spinner and status message
+func RenderLoadingView(spinner, message string) string {
+	return fmt.Sprintf("\n  %s %s\n", spinner, StatusStyle.Render(message))
+}
+
+// RenderErrorView renders an error message
+func RenderErrorView(err string, keys Keys) string {
+	var sb strings.Builder
+
+	sb.WriteString("\n")
+	sb.WriteString(TitleStyle.Render("Error"))
+	sb.WriteString("\n\n")
+	sb.WriteString("  " + ErrorStyle.Render(err))
+	sb.WriteString("\n")
+	sb.WriteString(renderHelp(keys, "  {quit}: quit"))
+
+	return sb.String()
+}
+
+// RenderReconnectingView renders the banner shown while the connection to
+// the API server is being re-established. retryIn is the time left before
+// the next attempt, not positive while one is running.
+func RenderReconnectingView(spinner string, attempt int, retryIn time.Duration, lastErr string, keys Keys) string {
+	var sb strings.Builder
+
+	sb.WriteString("\n")
+	sb.WriteString(TitleStyle.Render("Connection lost"))
+	sb.WriteString("\n\n")
+	status := "reconnecting..."
+	if retryIn > 0 {
+		status = fmt.Sprintf("reconnecting in %s...", retryIn.Round(time.Second))
+	}
+	if attempt > 0 {
+		status += fmt.Sprintf(" (%d attempts so far)", attempt)
+	}
+	sb.WriteString(fmt.Sprintf("  %s %s\n", spinner, WarningStyle.Render(status)))
+	sb.WriteString("  " + StatusStyle.Render(lastErr) + "\n\n")
+	sb.WriteString(renderHelp(keys, "  {refresh} retry now • esc give up • {quit} quit"))
+
+	return sb.String()
+}
+
+// ListView holds the state shared by the list renderers
+type ListView struct {
+	Namespace string
+	Context   string
+	Selected  int
+
+	// As is the impersonated user, shown next to the context
+	As string
+
+	// Width is the terminal width, 0 when unknown
+	Width int
+
+	// FilterBar is shown below the title when not empty
+	FilterBar string
+
+	// AbsoluteTime shows creation times instead of ages
+	AbsoluteTime bool
+
+	// Marked holds the names of the rows marked for a bulk action
+	Marked map[string]bool
+
+	// Wide shows the extra columns of the views that have them
+	Wide bool
+
+	// Height is the number of rows that fit on screen, 0 when unknown
+	Height int
+
+	// Search highlights the characters of the names it fuzzy matches
+	Search string
+
+	// OnlyUnhealthy is set when healthy items are left out of the list,
+	// HiddenHealthy of them
+	OnlyUnhealthy bool
+	HiddenHealthy int
+
+	// Changes are the rows changed by the last refresh, highlighted
+	// for a moment
+	Changes RowChanges
+
+	// Forbidden is set when the user may not list the resources of the
+	// view in the namespace
+	Forbidden bool
+
+	// Keys are the keys of the actions shown in the help line
+	Keys Keys
+}
+
+// highlight highlights the characters of name matched by the search
+func (lv ListView) highlight(name string) string {
+	if lv.Search == "" {
+		return name
+	}
+	return HighlightFuzzy(name, lv.Search)
+}
+
+// RenderBreadcrumb renders the trail of views leading to the current one,
+// the last crumb highlighted
+func RenderBreadcrumb(crumbs []string) string {
+	if len(crumbs) == 0 {
+		return ""
+	}
+	last := len(crumbs) - 1
+	trail := ""
+	if last > 0 {
+		trail = StatusStyle.Render(strings.Join(crumbs[:last], " > ") + " > ")
+	}
+	return "  " + trail + InfoStyle.Render(crumbs[last])
+}
+
+// renderListHeader renders the title line with the context and the filter bar
+func renderListHeader(title string, lv ListView) string {
+	var sb strings.Builder
+
+	sb.WriteString("\n")
+	sb.WriteString(TitleStyle.Render(title))
+	if lv.Context != "" {
+		sb.WriteString(" " + StatusStyle.Render(fmt.Sprintf("(context: %s)", lv.Context)))
+	}
+	if lv.As != "" {
+		sb.WriteString(" " + WarningStyle.Render("as: "+lv.As))
+	}
+	sb.WriteString("\n")
+	if lv.FilterBar != "" {
+		sb.WriteString("  " + lv.FilterBar)
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// RenderPodsView renders the list of pods with the configured columns, see
+// SetColumns. The delete key is only advertised
+// when canDelete is true. hiddenJobs is the number of completed job pods left
+// out of the list; shown ones are dimmed.
+func RenderPodsView(pods []resources.PodInfo, lv ListView, canDelete bool, hiddenJobs int) string {
+	var sb strings.Builder
+
+	sb.WriteString(renderListHeader(fmt.Sprintf("Pods in namespace: %s", lv.Namespace), lv))
+
+	table := Table{
+		Columns: []Column{
+			{Title: "NAME", TruncateMiddle: true},
+			{Title: "STATUS", Priority: 1},
+			{Title: "READY", Priority: 2},
+			{Title: "RESTARTS", Priority: 2},
+			{Title: ageTitle(lv.AbsoluteTime), Priority: 3},
+			{Title: "QOS", Priority: 4},
+			{Title: "IMAGE", Priority: 5, MaxWidth: 40, TruncateMiddle: true},
+			{Title: "IP", Priority: 6, Wide: true},
+			{Title: "NODE", Priority: 7, MaxWidth: 30, Wide: true},
+			{Title: "NOMINATED NODE", Priority: 8, MaxWidth: 30, Wide: true},
+			{Title: "READINESS GATES", Priority: 9, Wide: true},
+		},
+		Selected: lv.Selected,
+		Width:    lv.Width,
+		Height:   lv.Height,
+		Wide:     lv.Wide,
+	}
+
+	for _, pod := range pods {
+		// Count ready containers, init containers never report ready
+		ready, total, restarts := 0, 0, 0
+		image := ""
+		for _, c := range pod.Containers {
+			if c.IsInit {
+				continue
+			}
+			if total == 0 {
+				image = c.Image
+			}
+			total++
+			if c.Ready {
+				ready++
+			}
+			restarts += c.RestartCount
+		}
+
+		name := lv.Changes.added(pod.Namespace, pod.Name, lv.highlight(pod.Name))
+		status := lv.Changes.changed(pod.Namespace, pod.Name, pod.Status, StylePodStatus(pod.Status))
+		if pod.IsCompletedJob() {
+			name = StatusStyle.Render(name)
+			status = StatusStyle.Render("Completed")
+		}
+		if lv.Marked[pod.Name] {
+			name = MarkedStyle.Render("* " + lv.highlight(pod.Name))
+		}
+
+		table.RowStyles = append(table.RowStyles, labelStyle(pod.Labels))
+		table.Rows = append(table.Rows, []string{
+			name,
+			status,
+			fmt.Sprintf("%d/%d", ready, total),
+			fmt.Sprintf("%d", restarts),
+			FormatAge(pod.Age, pod.Created, lv.AbsoluteTime),
+			StyleQOSClass(pod.QOSClass),
+			image,
+			orNone(pod.IP),
+			orNone(pod.Node),
+			orNone(pod.NominatedNode),
+			orNone(pod.ReadinessGates),
+		})
+	}
+	table.Rows = append(table.Rows, lv.Changes.removedRows(len(table.Columns))...)
+	table.pickColumns("pods")
+	if len(pods) == 0 {
+		sb.WriteString(emptyNamespaceList("pods", lv))
+	} else {
+		sb.WriteString(table.Render())
+	}
+
+	// Explain why the selected pod is stuck
+	if lv.Selected >= 0 && lv.Selected < len(pods) && pods[lv.Selected].StatusMessage != "" {
+		sb.WriteString("  " + WarningStyle.Render(pods[lv.Selected].StatusMessage))
+		sb.WriteString("\n")
+	}
+
+	if hiddenJobs > 0 {
+		sb.WriteString("  " + StatusStyle.Render(lv.Keys.expand(fmt.Sprintf("%d completed job pods hidden ({completed-jobs} to show)", hiddenJobs))))
+		sb.WriteString("\n")
+	}
+	sb.WriteString(unhealthyNote("pods", lv))
+
+	// The rest of the keys are in the key bindings overlay
+	help := "  ↑/{up} up • ↓/{down} down • enter details • {logs} logs • {search} search"
+	if canDelete {
+		help += " • {delete} delete"
+	}
+	help += " • {palette} palette • {help} help • {quit} quit"
+	sb.WriteString(renderHelp(lv.Keys, help))
+
+	return sb.String()
+}
+
+// RenderServicesView renders the list of services with the configured
+// columns, see SetColumns
+func RenderServicesView(services []resources.ServiceInfo, lv ListView, byType bool) string {
+	var sb strings.Builder
+
+	sb.WriteString(renderListHeader(fmt.Sprintf("Services in namespace: %s", lv.Namespace), lv))
+
+	table := Table{
+		Columns: []Column{
+			{Title: "NAME", TruncateMiddle: true},
+			{Title: "TYPE", Priority: 1},
+			{Title: "CLUSTER-IP", Priority: 3},
+			{Title: "EXTERNAL-IP", Priority: 4, MaxWidth: 40},
+			{Title: "PORTS", Priority: 2, MaxWidth: 40},
+			{Title: "PODS", Priority: 2},
+			{Title: ageTitle(lv.AbsoluteTime), Priority: 5},
+			{Title: "SELECTOR", Priority: 6, MaxWidth: 50, Wide: true},
+			{Title: "TARGET PORTS", Priority: 7, MaxWidth: 40, Wide: true},
+			{Title: "ENDPOINTS", Priority: 7, Wide: true},
+		},
+		Selected: lv.Selected,
+		Width:    lv.Width,
+		Height:   lv.Height,
+		Wide:     lv.Wide,
+	}
+
+	for _, svc := range services {
+		// Headless services resolve to pod IPs, so call them out
+		clusterIP := svc.ClusterIP
+		if svc.IsHeadless() {
+			clusterIP = InfoStyle.Render("Headless")
+		}
+
+		name := lv.Changes.added(svc.Namespace, svc.Name, lv.highlight(svc.Name))
+		name = lv.Changes.changed(svc.Namespace, svc.Name, svc.Name, name)
+
+		table.RowStyles = append(table.RowStyles, labelStyle(svc.Labels))
+		table.Rows = append(table.Rows, []string{
+			name,
+			svc.Type,
+			clusterIP,
+			svc.ExternalIP,
+			svc.Ports,
+			styleServicePods(svc),
+			FormatAge(svc.Age, svc.Created, lv.AbsoluteTime),
+			orNone(resources.FormatSelector(svc.Selector)),
+			orNone(svc.Targets),
+			styleEndpoints(svc),
+		})
+	}
+	table.Rows = append(table.Rows, lv.Changes.removedRows(len(table.Columns))...)
+	table.pickColumns("services")
+	if len(services) == 0 {
+		sb.WriteString(emptyNamespaceList("services", lv))
+	} else {
+		sb.WriteString(table.Render())
+	}
+	sb.WriteString(unhealthyNote("services", lv))
+
+	sortHelp := "{sort} sort by type"
+	if byType {
+		sortHelp = "{sort} sort by name"
+	}
+	// The rest of the keys are in the key bindings overlay
+	sb.WriteString(renderHelp(lv.Keys, "  ↑/{up} up • ↓/{down} down • enter details • {events} events • {search} search • "+sortHelp+" • {palette} palette • {help} help • {quit} quit"))
+
+	return sb.String()
+}
+
+// styleServicePods formats the ready and total pods matching the selector
+// of a service, flagging a selector that matches none
+func styleServicePods(svc resources.ServiceInfo) string {
+	switch {
+	case len(svc.Selector) == 0:
+		return "<none>"
+	case svc.PodsTotal < 0:
+		return "?"
+	case svc.PodsTotal == 0:
+		return ErrorStyle.Render("0/0")
+	}
+	pods := fmt.Sprintf("%d/%d", svc.PodsReady, svc.PodsTotal)
+	if svc.PodsReady == 0 {
+		return WarningStyle.Render(pods)
+	}
+	return pods
+}
+
+// styleEndpoints formats the ready endpoints of a service, flagging a
+// service with none to send traffic to
+func styleEndpoints(svc resources.ServiceInfo) string {
+	switch {
+	case svc.IsExternalName():
+		return "-"
+	case svc.Endpoints < 0:
+		return "?"
+	case svc.Endpoints == 0:
+		return ErrorStyle.Render("0")
+	}
+	return fmt.Sprintf("%d", svc.Endpoints)
+}
+
+// RenderRoutesView renders the list of OpenShift routes
+func RenderRoutesView(routes []resources.RouteInfo, lv ListView) string {
+	var sb strings.Builder
+
+	sb.WriteString(renderListHeader(fmt.Sprintf("Routes in namespace: %s", lv.Namespace), lv))
+
+	table := Table{
+		Columns: []Column{
+			{Title: "NAME", TruncateMiddle: true},
+			{Title: "HOST", Priority: 1, MaxWidth: 50, TruncateMiddle: true},
+			{Title: "PATH", Priority: 3, MaxWidth: 30},
+			{Title: "SERVICE", Priority: 2, MaxWidth: 40},
+			{Title: "TLS", Priority: 4},
+			{Title: ageTitle(lv.AbsoluteTime), Priority: 5},
+		},
+		Selected: lv.Selected,
+		Width:    lv.Width,
+		Height:   lv.Height,
+	}
+
+	for _, route := range routes {
+		// Plain HTTP routes are worth noticing
+		tls := route.TLS
+		if tls == "" {
+			tls = WarningStyle.Render("none")
+		}
+
+		table.Rows = append(table.Rows, []string{
+			lv.highlight(route.Name),
+			route.Host,
+			route.Path,
+			route.Service,
+			tls,
+			FormatAge(route.Age, route.Created, lv.AbsoluteTime),
+		})
+	}
+	if len(routes) == 0 {
+		sb.WriteString(emptyNamespaceList("routes", lv))
+	} else {
+		sb.WriteString(table.Render())
+	}
+
+	sb.WriteString(renderHelp(lv.Keys, "  ↑/{up} up • ↓/{down} down • {search} search • enter details • {events} events • {copy} copy • {kubectl} kubectl cmd • {pods} pods • {services} services • {palette} palette • {refresh} refresh • esc back • {quit} quit"))
+
+	return sb.String()
+}
+
+// emptyList renders the message a list shows instead of a bare table header
+// when it has no rows, which should say how to get out of it
+func emptyList(message string) string {
+	return "  " + StatusStyle.Render(message) + "\n"
+}
+
+// emptyNamespaceList renders the empty state of a list of namespaced
+// resources, e.g. "No pods in namespace default."
+func emptyNamespaceList(kind string, lv ListView) string {
+	if lv.Forbidden {
+		return "  " + WarningStyle.Render(lv.Keys.expand(fmt.Sprintf("You don't have permission to list %s in %s. Press {namespaces} or {go-namespace} to switch namespace.", kind, lv.Namespace))) + "\n"
+	}
+	if lv.OnlyUnhealthy && lv.HiddenHealthy > 0 {
+		return emptyList(lv.Keys.expand(fmt.Sprintf("All %d %s in namespace %s are healthy. Press {unhealthy} to show them.", lv.HiddenHealthy, kind, lv.Namespace)))
+	}
+	return emptyList(lv.Keys.expand(fmt.Sprintf("No %s in namespace %s. Press {refresh} to refresh or {namespaces} to switch namespace.", kind, lv.Namespace)))
+}
+
+// unhealthyNote tells that only unhealthy items are listed and how many
+// healthy kind are hidden, empty when the filter is off
+func unhealthyNote(kind string, lv ListView) string {
+	if !lv.OnlyUnhealthy {
+		return ""
+	}
+	return "  " + WarningStyle.Render(lv.Keys.expand(fmt.Sprintf("only unhealthy: %d healthy %s hidden ({unhealthy} to show all)", lv.HiddenHealthy, kind))) + "\n"
+}
+
+// orNone returns value, or "<none>" like kubectl when it is empty
+func orNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
+
+// RenderSecretsView renders the list of secrets with the configured columns,
+// see SetColumns
+func RenderSecretsView(secrets []resources.SecretInfo, lv ListView) string {
+	var sb strings.Builder
+
+	sb.WriteString(renderListHeader(fmt.Sprintf("Secrets in namespace: %s", lv.Namespace), lv))
+
+	table := Table{
+		Columns: []Column{
+			{Title: "NAME", TruncateMiddle: true},
+			{Title: "TYPE", Priority: 1, MaxWidth: 40},
+			{Title: "KEYS", Priority: 2},
+			{Title: ageTitle(lv.AbsoluteTime), Priority: 3},
+		},
+		Selected: lv.Selected,
+		Width:    lv.Width,
+		Height:   lv.Height,
+	}
+
+	for _, secret := range secrets {
+		table.Rows = append(table.Rows, []string{
+			lv.highlight(secret.Name),
+			secret.Type,
+			fmt.Sprintf("%d", secret.Keys),
+			FormatAge(secret.Age, secret.Created, lv.AbsoluteTime),
+		})
+	}
+	table.pickColumns("secrets")
+	if len(secrets) == 0 {
+		sb.WriteString(emptyNamespaceList("secrets", lv))
+	} else {
+		sb.WriteString(table.Render())
+	}
+
+	sb.WriteString(renderHelp(lv.Keys, "  ↑/{up} up • ↓/{down} down • {search} search • enter details • {events} events • {certificates} tls certificates • {copy} copy • {kubectl} kubectl cmd • {pods} pods • {services} services • {namespaces} namespaces • {go-namespace} go to namespace • {go-to} go to pod/svc • {recent} recent • {top} top • {dashboard} dashboard • {event-stream} event stream • {service-accounts}/{roles}/{role-bindings} rbac • {custom-resources} custom resources • {palette} palette • {refresh} refresh • {quit} quit"))
+
+	return sb.String()
+}
+
+// rbacHelp is the help line of the RBAC views
+const rbacHelp = "  ↑/{up} up • ↓/{down} down • enter details • {copy} copy • {kubectl} kubectl cmd • {service-accounts} service accounts • {roles} roles • {role-bindings} bindings • {who-can} who can • {pods} pods • {palette} palette • {refresh} refresh • esc back • {quit} quit"
+
+// RenderServiceAccountsView renders the list of service accounts
+func RenderServiceAccountsView(accounts []resources.ServiceAccountInfo, lv ListView) string {
+	var sb strings.Builder
+
+	sb.WriteString(renderListHeader(fmt.Sprintf("Service accounts in namespace: %s", lv.Namespace), lv))
+
+	table := Table{
+		Columns: []Column{
+			{Title: "NAME", TruncateMiddle: true},
+			{Title: "SECRETS", Priority: 2},
+			{Title: ageTitle(lv.AbsoluteTime), Priority: 1},
+		},
+		Selected: lv.Selected,
+		Width:    lv.Width,
+		Height:   lv.Height,
+	}
+
+	for _, sa := range accounts {
+		table.Rows = append(table.Rows, []string{
+			lv.highlight(sa.Name),
+			fmt.Sprintf("%d", sa.Secrets),
+			FormatAge(sa.Age, sa.Created, lv.AbsoluteTime),
+		})
+	}
+	if len(accounts) == 0 {
+		sb.WriteString(emptyNamespaceList("service accounts", lv))
+	} else {
+		sb.WriteString(table.Render())
+	}
+
+	sb.WriteString(renderHelp(lv.Keys, rbacHelp))
+
+	return sb.String()
+}
+
+// RenderRolesView renders the roles of a namespace followed by the cluster roles
+func RenderRolesView(roles []resources.RoleInfo, lv ListView) string {
+	var sb strings.Builder
+
+	sb.WriteString(renderListHeader(fmt.Sprintf("Roles in namespace: %s and cluster roles", lv.Namespace), lv))
+
+	table := Table{
+		Columns: []Column{
+			{Title: "NAME", TruncateMiddle: true},
+			{Title: "KIND", Priority: 1},
+			{Title: "RULES", Priority: 3},
+			{Title: ageTitle(lv.AbsoluteTime), Priority: 2},
+		},
+		Selected: lv.Selected,
+		Width:    lv.Width,
+		Height:   lv.Height,
+	}
+
+	for _, role := range roles {
+		table.Rows = append(table.Rows, []string{
+			lv.highlight(role.Name),
+			string(role.Kind),
+			fmt.Sprintf("%d", role.Rules),
+			FormatAge(role.Age, role.Created, lv.AbsoluteTime),
+		})
+	}
+	if len(roles) == 0 {
+		sb.WriteString(emptyList(lv.Keys.expand(fmt.Sprintf("No roles in namespace %s and no cluster roles. Press {refresh} to refresh or {namespaces} to switch namespace.", lv.Namespace))))
+	} else {
+		sb.WriteString(table.Render())
+	}
+
+	sb.WriteString(renderHelp(lv.Keys, rbacHelp))
+
+	return sb.String()
+}
+
+// RenderRoleBindingsView renders the role bindings of a namespace followed by
+// the cluster role bindings
+func RenderRoleBindingsView(bindings []resources.RoleBindingInfo, lv ListView) string {
+	var sb strings.Builder
+
+	sb.WriteString(renderListHeader(fmt.Sprintf("Role bindings in namespace: %s and cluster role bindings", lv.Namespace), lv))
+
+	table := Table{
+		Columns: []Column{
+			{Title: "NAME", TruncateMiddle: true},
+			{Title: "KIND", Priority: 2},
+			{Title: "ROLE", Priority: 1, MaxWidth: 40},
+			{Title: "SUBJECTS", Priority: 3, MaxWidth: 50},
+			{Title: ageTitle(lv.AbsoluteTime), Priority: 4},
+		},
+		Selected: lv.Selected,
+		Width:    lv.Width,
+		Height:   lv.Height,
+	}
+
+	for _, b := range bindings {
+		table.Rows = append(table.Rows, []string{
+			lv.highlight(b.Name),
+			string(b.Kind),
+			b.Role,
+			strings.Join(b.Subjects, ", "),
+			FormatAge(b.Age, b.Created, lv.AbsoluteTime),
+		})
+	}
+	if len(bindings) == 0 {
+		sb.WriteString(emptyList(lv.Keys.expand(fmt.Sprintf("No role bindings in namespace %s and no cluster role bindings. Press {refresh} to refresh or {namespaces} to switch namespace.", lv.Namespace))))
+	} else {
+		sb.WriteString(table.Render())
+	}
+
+	sb.WriteString(renderHelp(lv.Keys, rbacHelp))
+
+	return sb.String()
+}
+
+// RenderWhoCanView renders the subjects allowed the action of query
+func RenderWhoCanView(query string, subjects []resources.Subject, lv ListView) string {
+	var sb strings.Builder
+
+	title := "Who can"
+	if query != "" {
+		title = fmt.Sprintf("Who can %s in namespace: %s", query, lv.Namespace)
+	}
+	sb.WriteString(renderListHeader(title, lv))
+
+	if query != "" && len(subjects) == 0 {
+		sb.WriteString(emptyList(lv.Keys.expand("No role binding grants this permission. Press {who-can} for a new query or {refresh} to refresh.")))
+	}
+
+	table := Table{
+		Columns: []Column{
+			{Title: "SUBJECT", TruncateMiddle: true},
+			{Title: "KIND", Priority: 1},
+			{Title: "BINDING", Priority: 2, MaxWidth: 50},
+			{Title: "ROLE", Priority: 3, MaxWidth: 40},
+		},
+		Selected: lv.Selected,
+		Width:    lv.Width,
+		Height:   lv.Height,
+	}
+
+	for _, s := range subjects {
+		name := s.Name
+		if s.Namespace != "" {
+			name = s.Namespace + "/" + s.Name
+		}
+		table.Rows = append(table.Rows, []string{lv.highlight(name), s.Kind, s.Binding, s.Role})
+	}
+	if len(subjects) > 0 {
+		sb.WriteString(table.Render())
+	}
+
+	sb.WriteString(renderHelp(lv.Keys, "  ↑/{up} up • ↓/{down} down • {who-can} new query • {copy} copy • {service-accounts} service accounts • {roles} roles • {role-bindings} bindings • {refresh} refresh • esc back • {quit} quit"))
+
+	return sb.String()
+}
+
+// RenderCustomTypesView renders the list of custom resource types
+func RenderCustomTypesView(types []resources.CustomResourceType, lv ListView) string {
+	var sb strings.Builder
+
+	sb.WriteString(renderListHeader("Custom resource types", lv))
+
+	table := Table{
+		Columns: []Column{
+			{Title: "NAME", TruncateMiddle: true},
+			{Title: "KIND", Priority: 1},
+			{Title: "VERSION", Priority: 2},
+			{Title: "SCOPE", Priority: 3},
+		},
+		Selected: lv.Selected,
+		Width:    lv.Width,
+		Height:   lv.Height,
+	}
+
+	for _, t := range types {
+		scope := "Cluster"
+		if t.Namespaced {
+			scope = "Namespaced"
+		}
+		table.Rows = append(table.Rows, []string{lv.highlight(t.Name), t.Kind, t.Version, scope})
+	}
+	if len(types) == 0 {
+		sb.WriteString(emptyList(lv.Keys.expand("No custom resource types found. Press {refresh} to refresh or esc to go back.")))
+	} else {
+		sb.WriteString(table.Render())
+	}
+
+	sb.WriteString(renderHelp(lv.Keys, "  ↑/{up} up • ↓/{down} down • enter list • esc back • {quit} quit"))
+
+	return sb.String()
+}
+
+// RenderCustomResourcesView renders the instances of a custom resource type
+func RenderCustomResourcesView(crType resources.CustomResourceType, items []resources.CustomResourceInfo, lv ListView) string {
+	var sb strings.Builder
+
+	title := fmt.Sprintf("%s in namespace: %s", crType.Kind, lv.Namespace)
+	if !crType.Namespaced {
+		title = fmt.Sprintf("%s (cluster-scoped)", crType.Kind)
+	}
+	sb.WriteString(renderListHeader(title, lv))
+
+	table := Table{
+		Columns:  []Column{{Title: "NAME", TruncateMiddle: true}},
+		Selected: lv.Selected,
+		Width:    lv.Width,
+		Height:   lv.Height,
+	}
+	for i, col := range crType.Columns {
+		table.Columns = append(table.Columns, Column{Title: strings.ToUpper(col.Name), Priority: i + 1, MaxWidth: 40})
+	}
+	table.Columns = append(table.Columns, Column{Title: ageTitle(lv.AbsoluteTime), Priority: len(crType.Columns) + 1})
+
+	for _, item := range items {
+		row := append([]string{lv.highlight(item.Name)}, item.Fields...)
+		row = append(row, FormatAge(item.Age, item.Created, lv.AbsoluteTime))
+		table.Rows = append(table.Rows, row)
+	}
+	switch {
+	case len(items) > 0:
+		sb.WriteString(table.Render())
+	case crType.Namespaced:
+		sb.WriteString(emptyList(lv.Keys.expand(fmt.Sprintf("No %s in namespace %s. Press {refresh} to refresh or esc to go back.", crType.Kind, lv.Namespace))))
+	default:
+		sb.WriteString(emptyList(lv.Keys.expand(fmt.Sprintf("No %s found. Press {refresh} to refresh or esc to go back.", crType.Kind))))
+	}
+
+	sb.WriteString(renderHelp(lv.Keys, "  ↑/{up} up • ↓/{down} down • enter details • {events} events • {workload} owned pods • {refresh} refresh • esc back • {quit} quit"))
+
+	return sb.String()
+}
+
+// RenderPodDetailView renders the detail view around its scrolled body. pod
+// is true for pod details, whose environment variables are shown as env
+// says. The edit key is only advertised when editable is true, and the keys
+// selecting fields replace the others while inspecting. With hideSecrets
+// the values from Secrets can't be revealed, which the help says.
+func RenderPodDetailView(body string, following, pod bool, env resources.EnvMode, editable, inspecting, hideSecrets bool, keys Keys) string {
+	var sb strings.Builder
+
+	sb.WriteString("\n")
+	sb.WriteString(TitleStyle.Render("Details"))
+	if following {
+		sb.WriteString(" " + InfoStyle.Render("[following]"))
+	}
+	if inspecting {
+		sb.WriteString(" " + InfoStyle.Render("[inspecting]"))
+	}
+	sb.WriteString("\n\n")
+	sb.WriteString(body)
+	sb.WriteString("\n")
+
+	followHelp := "{follow} follow"
+	if following {
+		followHelp = "{follow} stop following"
+	}
+	envHelp := ""
+	if pod {
+		switch env {
+		case resources.EnvSources:
+			envHelp = "{env} resolve env • "
+		case resources.EnvResolved:
+			envHelp = "{env} reveal secrets • "
+			if hideSecrets {
+				envHelp = "{env} env sources (secret values hidden) • "
+			}
+		default:
+			envHelp = "{env} hide env values • "
+		}
+		envHelp += "{env-list} env list • {workload} workload pods • {rollout-history} rollout history • {logs} logs • "
+	}
+	if inspecting {
+		logsHelp := ""
+		if pod {
+			logsHelp = "{logs} container logs • {env-list} container env • "
+		}
+		sb.WriteString(renderHelp(keys, "  ↑/{up} ↓/{down} select field • {copy} copy value • "+logsHelp+"esc/{inspect} stop inspecting • {quit} quit"))
+		return sb.String()
+	}
+
+	editHelp := ""
+	if editable {
+		editHelp = "{edit} edit • "
+	}
+	sb.WriteString(renderHelp(keys, "  ↑/{up} ↓/{down} scroll • {inspect} inspect fields • "+editHelp+"{events} events • "+envHelp+"{copy} copy • {kubectl} kubectl cmd • "+followHelp+" • {absolute-time} absolute/relative times • esc back • {quit} quit"))
+
+	return sb.String()
+}
+
+// RenderDetailBody renders the detail text of a resource wrapped to width,
+// calling out its flags. usage is shown above the detail when not empty.
+// Timestamps are shown relative to now unless absolute is true. The detail
+// line at index selected, if any, is highlighted and the row it starts on
+// returned.
+func RenderDetailBody(detail string, flags []resources.DetailFlag, usage string, width int, absolute bool, selected int) (string, int) {
+	var sb strings.Builder
+	row, selectedRow := 0, -1
+
+	if usage != "" {
+		for _, line := range strings.Split(usage, "\n") {
+			sb.WriteString("  " + line + "\n")
+			row++
+		}
+		sb.WriteString("\n")
+		row++
+	}
+
+	// Flags are styled first, their offsets are the ones of the text written
+	detail = StyleDetail(detail, flags)
+	if !absolute {
+		detail = RelativeTimes(detail)
+	}
+	// The indent is given up when it would leave no room for the text
+	indent, selectedStyle := detailIndent, SelectedItemStyle
+	if width > 0 && width <= detailIndent+1 {
+		indent, selectedStyle = 0, SelectedItemStyle.UnsetPaddingLeft()
+	}
+	for i, line := range strings.Split(detail, "\n") {
+		if i == selected {
+			line = ansi.Strip(line)
+			selectedRow = row
+		}
+		// Long values such as annotations are wrapped rather than cut off by
+		// the terminal. The width is unknown until the first resize message.
+		// Words too long for very narrow widths are broken anywhere.
+		if width > 0 {
+			line = ansi.Hardwrap(ansi.Wrap(line, width-indent, ""), width-indent, true)
+		}
+		for _, wrapped := range strings.Split(line, "\n") {
+			if i == selected {
+				// The style's padding takes the place of the indent
+				sb.WriteString(selectedStyle.Render(wrapped) + "\n")
+			} else {
+				sb.WriteString(strings.Repeat(" ", indent) + wrapped + "\n")
+			}
+			row++
+		}
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n"), selectedRow
+}
+
+// detailIndent is the left margin of the detail body
+const detailIndent = 2
+
+// RenderUsage renders CPU and memory sparklines with the latest values
+func RenderUsage(cpu, mem []int64) string {
+	if len(cpu) == 0 {
+		return ""
+	}
+
+	cpuLine := fmt.Sprintf("CPU    %s %dm", InfoStyle.Render(Sparkline(cpu)), cpu[len(cpu)-1])
+	memLine := fmt.Sprintf("Memory %s %dMi", InfoStyle.Render(Sparkline(mem)), mem[len(mem)-1]/(1024*1024))
+
+	return cpuLine + "\n" + memLine
+}
+
+// RenderTopView renders pods sorted by resource usage with the namespace
+// totals. unavailable is set when the metrics API is missing.
+func RenderTopView(metrics []resources.PodMetricsInfo, lv ListView, byMemory, unavailable bool) string {
+	var sb strings.Builder
+
+	sb.WriteString(renderListHeader(fmt.Sprintf("Resource usage in namespace: %s", lv.Namespace), lv))
+
+	sortHelp := "{sort} sort by memory"
+	if byMemory {
+		sortHelp = "{sort} sort by CPU"
+	}
+	help := "  ↑/{up} up • ↓/{down} down • " + sortHelp + " • {pods} pods • {refresh} refresh • {quit} quit"
+
+	if unavailable {
+		sb.WriteString("  " + WarningStyle.Render("The metrics API is not available, metrics-server is probably not installed."))
+		sb.WriteString("\n  " + StatusStyle.Render("Install it: "+resources.MetricsServerURL))
+		sb.WriteString("\n")
+		sb.WriteString(renderHelp(lv.Keys, help))
+		return sb.String()
+	}
+
+	var totalCPU, totalMem int64
+	for _, pm := range metrics {
+		totalCPU += pm.CPUMilli
+		totalMem += pm.MemoryBytes
+	}
+
+	table := Table{
+		Columns: []Column{
+			{Title: "NAME", TruncateMiddle: true},
+			{Title: "CPU", Priority: 1},
+			{Title: "MEMORY", Priority: 1},
+			{Title: "CPU %", Priority: 2},
+			{Title: "MEMORY %", Priority: 2},
+		},
+		Selected: lv.Selected,
+		Width:    lv.Width,
+		Height:   lv.Height,
+	}
+
+	for _, pm := range metrics {
+		table.Rows = append(table.Rows, []string{
+			lv.highlight(pm.Name),
+			fmt.Sprintf("%dm", pm.CPUMilli),
+			fmt.Sprintf("%dMi", pm.MemoryBytes/(1024*1024)),
+			share(pm.CPUMilli, totalCPU),
+			share(pm.MemoryBytes, totalMem),
+		})
+	}
+	if len(metrics) == 0 {
+		sb.WriteString(emptyList(lv.Keys.expand(fmt.Sprintf("No pod metrics in namespace %s. Press {refresh} to refresh or {namespaces} to switch namespace.", lv.Namespace))))
+		sb.WriteString(renderHelp(lv.Keys, help))
+		return sb.String()
+	}
+	sb.WriteString(table.Render())
+
+	sb.WriteString("  " + InfoStyle.Render(fmt.Sprintf("Total: %d pods, CPU %dm, memory %dMi", len(metrics), totalCPU, totalMem/(1024*1024))))
+	sb.WriteString("\n")
+	sb.WriteString(renderHelp(lv.Keys, help))
+
+	return sb.String()
+}
+
+// share formats part as a percentage of total
+func share(part, total int64) string {
+	if total == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", float64(part)*100/float64(total))
+}
+
+// RenderNamespacesView renders the namespace picker, listing OpenShift
+// projects when projects is true. Favorites are starred. The pod counts,
+// while known, flag the namespaces with pending or failed pods.
+func RenderNamespacesView(namespaces []resources.NamespaceInfo, lv ListView, projects bool, favorites []string, counts map[string]resources.PodCounts) string {
+	var sb strings.Builder
+
+	title := "Select namespace"
+	if projects {
+		title = "Select project"
+	}
+	sb.WriteString("\n")
+	sb.WriteString(TitleStyle.Render(title))
+	sb.WriteString("\n\n")
+
+	table := Table{
+		Columns: []Column{
+			{Title: "NAME", TruncateMiddle: true},
+			{Title: "STATUS", Priority: 1},
+			{Title: "PODS", Priority: 1},
+			{Title: ageTitle(lv.AbsoluteTime), Priority: 2},
+		},
+		Selected: lv.Selected,
+		Width:    lv.Width,
+		Height:   lv.Height,
+	}
+
+	for _, ns := range namespaces {
+		// Namespaces stuck in Terminating are a common headache
+		status := ns.Status
+		if status == "Terminating" {
+			status = WarningStyle.Render(status)
+		}
+
+		// Keep names aligned next to the stars
+		name := lv.highlight(ns.Name)
+		if slices.Contains(favorites, ns.Name) {
+			name = WarningStyle.Render("★") + " " + name
+		} else if len(favorites) > 0 {
+			name = "  " + name
+		}
+
+		pods := ""
+		if c, ok := counts[ns.Name]; ok {
+			pods = c.String()
+			if c.Troubled() {
+				pods = WarningStyle.Render(pods)
+			}
+		}
+
+		table.Rows = append(table.Rows, []string{name, status, pods, FormatAge(ns.Age, ns.Created, lv.AbsoluteTime)})
+	}
+	if len(namespaces) == 0 {
+		kind := "namespaces"
+		if projects {
+			kind = "projects"
+		}
+		sb.WriteString(emptyList(lv.Keys.expand(fmt.Sprintf("No %s you can access. Press {go-namespace} to type a name or esc to go back.", kind))))
+	} else {
+		sb.WriteString(table.Render())
+	}
+
+	sb.WriteString(renderHelp(lv.Keys, "  ↑/{up} up • ↓/{down} down • {search} search • enter select • {favorite} favorite • {go-namespace} type name • esc back • {quit} quit"))
+
+	return sb.String()
+}
+
+// RenderEventsView renders the events of a resource
+func RenderEventsView(events []resources.EventInfo, kind, name string, width int, absolute bool, keys Keys) string {
+	var sb strings.Builder
+
+	sb.WriteString("\n")
+	sb.WriteString(TitleStyle.Render(fmt.Sprintf("Events: %s/%s", strings.ToLower(kind), name)))
+	sb.WriteString("\n\n")
+
+	if len(events) == 0 {
+		sb.WriteString(StatusStyle.Render("  No events found"))
+		sb.WriteString("\n")
+	} else {
+		table := Table{
+			Columns: []Column{
+				{Title: "TYPE"},
+				{Title: "REASON", Priority: 2, MaxWidth: 24},
+				{Title: "COUNT", Priority: 4},
+				{Title: lastSeenTitle(absolute), Priority: 3},
+				{Title: "MESSAGE", Priority: 1, MaxWidth: 80},
+			},
+			Selected: -1,
+			Width:    width,
+		}
+
+		for _, event := range events {
+			eventType := event.Type
+			if eventType == "Warning" {
+				eventType = ErrorStyle.Render(eventType)
+			}
+
+			table.Rows = append(table.Rows, []string{
+				eventType,
+				event.Reason,
+				fmt.Sprintf("%d", event.Count),
+				FormatAge(event.Age, event.LastSeen, absolute),
+				event.Message,
+			})
+		}
+		sb.WriteString(table.Render())
+	}
+
+	sb.WriteString(renderHelp(keys, "  {kubectl} kubectl cmd • {refresh} refresh • esc back • {quit} quit"))
+
+	return sb.String()
+}
+
+// RenderDiagnosisView renders the likely causes of a pod being pending
+func RenderDiagnosisView(podName string, diagnoses []resources.Diagnosis, keys Keys) string {
+	var sb strings.Builder
+
+	sb.WriteString("\n")
+	sb.WriteString(TitleStyle.Render(fmt.Sprintf("Why is %s pending?", podName)))
+	sb.WriteString("\n\n")
+
+	if len(diagnoses) == 0 {
+		sb.WriteString(StatusStyle.Render("  No obvious cause found, check the events (v) and the node capacity"))
+		sb.WriteString("\n")
+	}
+
+	for i, d := range diagnoses {
+		sb.WriteString(fmt.Sprintf("  %d. %s\n", i+1, WarningStyle.Render(d.Cause)))
+		if d.Detail != "" {
+			sb.WriteString("     " + StatusStyle.Render(d.Detail) + "\n")
+		}
+	}
+
+	sb.WriteString(renderHelp(keys, "  {refresh} refresh • esc back • {quit} quit"))
+
+	return sb.String()
+}
+
+// RenderContainerPicker renders the list of containers of a pod to choose from
+func RenderContainerPicker(podName string, containers []string, selected int, keys Keys) string {
+	var sb strings.Builder
+
+	sb.WriteString("\n")
+	sb.WriteString(TitleStyle.Render(fmt.Sprintf("Select container in pod: %s", podName)))
+	sb.WriteString("\n\n")
+
+	for i, c := range containers {
+		if i == selected {
+			sb.WriteString(SelectedItemStyle.Render("> " + c))
+		} else {
+			sb.WriteString(ItemStyle.Render(c))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(renderHelp(keys, "  ↑/{up} up • ↓/{down} down • enter select • esc back • {quit} quit"))
+
+	return sb.String()
+}
+
+// RenderContextPicker renders the kubeconfig contexts to connect to, shown
+// when the kubeconfig has no current context
+func RenderContextPicker(contexts []string, selected, width int, keys Keys) string {
+	var sb strings.Builder
+
+	sb.WriteString("\n")
+	sb.WriteString(TitleStyle.Render("Select context"))
+	sb.WriteString("\n")
+	sb.WriteString("  " + StatusStyle.Render("The kubeconfig has no current context, choose one to connect to"))
+	sb.WriteString("\n\n")
+
+	for i, c := range contexts {
+		if width > 0 {
+			c = TruncateMiddle(c, max(width-tableIndent, minNameWidth))
+		}
+		if i == selected {
+			sb.WriteString(SelectedItemStyle.Render("> " + c))
+		} else {
+			sb.WriteString(ItemStyle.Render(c))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(renderHelp(keys, "  ↑/{up} up • ↓/{down} down • enter connect • {quit} quit"))
+
+	return sb.String()
+}
+
+// RenderLogView renders the log viewport for a container, or for all of them
+// when merged is true. filterBar is shown below the header when a filter is
+// being edited or is active, following marks logs streamed as they come.
+// logRange describes which of the lines were fetched.
+func RenderLogView(content, podName, container string, previous, merged bool, filterBar string, following bool, logRange string, keys Keys) string {
+	var sb strings.Builder
+
+	// Make it obvious which container instance the logs belong to
+	instance := "current"
+	if previous {
+		instance = "previous"
+	}
+
+	sb.WriteString("\n")
+	if merged {
+		sb.WriteString(TitleStyle.Render(fmt.Sprintf("Logs: %s, all containers", podName)))
+	} else {
+		sb.WriteString(TitleStyle.Render(fmt.Sprintf("Logs: %s/%s", podName, container)))
+	}
+	sb.WriteString(" ")
+	if previous {
+		sb.WriteString(WarningStyle.Render(fmt.Sprintf("[%s]", instance)))
+	} else {
+		sb.WriteString(StatusStyle.Render(fmt.Sprintf("[%s]", instance)))
+	}
+	if following {
+		sb.WriteString(" " + InfoStyle.Render("[following]"))
+	}
+	sb.WriteString(" " + StatusStyle.Render("("+logRange+")"))
+	sb.WriteString("\n")
+	if filterBar != "" {
+		sb.WriteString("  " + filterBar)
+	}
+	sb.WriteString("\n")
+	sb.WriteString(content)
+	sb.WriteString("\n")
+	if merged {
+		sb.WriteString(renderHelp(keys, "  ↑/{up} ↓/{down} scroll • {search} filter • {more-logs}/{fewer-logs} more/fewer lines • {log-window} time window • {merge-logs} single container • {save-logs} save to file • {kubectl} kubectl cmd • {refresh} refresh • esc back • {quit} quit"))
+	} else {
+		followHelp := "{follow} follow"
+		if following {
+			followHelp = "{follow} stop following"
+		}
+		sb.WriteString(renderHelp(keys, "  ↑/{up} ↓/{down} scroll • {search} filter • "+followHelp+" • {more-logs}/{fewer-logs} more/fewer lines • {log-window} time window • {previous-logs} toggle previous/current • {merge-logs} merge all containers • {save-logs} save to file • {kubectl} kubectl cmd • {refresh} refresh • esc back • {quit} quit"))
+	}
+
+	return sb.String()
+}
+
+// containerColors are the colors given to containers in merged logs
+var containerColors = []lipgloss.Color{"39", "170", "214", "45", "2", "69", "3", "9"}
+
+// RenderMergedLogs renders the merged logs of a pod's containers, each line
+// prefixed with its container name in a color of its own
+func RenderMergedLogs(lines []resources.LogLine) string {
+	// Colors follow the container names so they don't change on refresh
+	var names []string
+	seen := make(map[string]bool)
+	width := 0
+	for _, l := range lines {
+		if !seen[l.Container] {
+			seen[l.Container] = true
+			names = append(names, l.Container)
+			width = max(width, len(l.Container))
+		}
+	}
+	sort.Strings(names)
+	styles := make(map[string]lipgloss.Style, len(names))
+	for i, name := range names {
+		styles[name] = lipgloss.NewStyle().Foreground(containerColors[i%len(containerColors)])
+	}
+
+	out := make([]string, 0, len(lines))
+	for _, l := range lines {
+		out = append(out, styles[l.Container].Render(fmt.Sprintf("%-*s", width, l.Container))+" "+l.Text)
+	}
+	return strings.Join(out, "\n")
+}
+
+// RenderEventStreamView renders the live event stream of scope, a namespace
+// or all namespaces. typeFilter is the event type shown, empty for all.
+func RenderEventStreamView(content, scope, typeFilter, streamErr string, keys Keys) string {
+	var sb strings.Builder
+
+	sb.WriteString("\n")
+	sb.WriteString(TitleStyle.Render(fmt.Sprintf("Event stream: %s", scope)))
+	if typeFilter != "" {
+		sb.WriteString(" " + InfoStyle.Render(fmt.Sprintf("[%s only]", typeFilter)))
+	}
+	sb.WriteString("\n")
+	if streamErr != "" {
+		sb.WriteString("  " + ErrorStyle.Render(streamErr))
+	}
+	sb.WriteString("\n")
+	sb.WriteString(content)
+	sb.WriteString("\n")
+	sb.WriteString(renderHelp(keys, "  ↑/{up} ↓/{down} scroll • {event-type} filter type • {all-namespaces} all/current namespace • esc back • {quit} quit"))
+
+	return sb.String()
+}
+
+// RenderStreamEvent renders an event of the event stream on one line,
+// colored by type
+func RenderStreamEvent(event resources.EventInfo, allNamespaces bool) string {
+	eventType := fmt.Sprintf("%-7s", event.Type)
+	if event.Type == "Warning" {
+		eventType = ErrorStyle.Render(eventType)
+	} else {
+		eventType = SuccessStyle.Render(eventType)
+	}
+
+	object := event.Object
+	if allNamespaces {
+		object = event.Namespace + "/" + object
+	}
+
+	return fmt.Sprintf("  %s %s %s %s: %s",
+		StatusStyle.Render(event.LastSeen.Local().Format("15:04:05")),
+		eventType,
+		InfoStyle.Render(object),
+		event.Reason,
+		event.Message,
+	)
+}
+
+// maxPaletteItems is the number of matches listed in the command palette
+const maxPaletteItems = 10
+
+// RenderPalette renders the command palette box with its input and matches
+func RenderPalette(input string, items []string, selected int) string {
+	var sb strings.Builder
+
+	sb.WriteString(input)
+	sb.WriteString("\n\n")
+
+	if len(items) == 0 {
+		sb.WriteString(StatusStyle.Render("no matching resource types"))
+	}
+
+	// Keep the selection visible when there are more matches than rows
+	start := max(selected-maxPaletteItems+1, 0)
+	for i := start; i < len(items) && i < start+maxPaletteItems; i++ {
+		if i == selected {
+			sb.WriteString(SelectedItemStyle.Render("> " + items[i]))
+		} else {
+			sb.WriteString(ItemStyle.Render(items[i]))
+		}
+		if i < len(items)-1 && i < start+maxPaletteItems-1 {
+			sb.WriteString("\n")
+		}
+	}
+
+	return PaletteStyle.Render(sb.String())
+}
+
+// Overlay draws box over the top of base, starting on its second line
+func Overlay(base, box string) string {
+	lines := strings.Split(base, "\n")
+	for i, line := range strings.Split(box, "\n") {
+		row := i + 1
+		if row < len(lines) {
+			lines[row] = "  " + line
+		} else {
+			lines = append(lines, "  "+line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,36 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestRenderDetailBodyNarrow(t *testing.T) {
+	annotation := "    kubectl.kubernetes.io/last-applied-configuration: " +
+		`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"web-0","namespace":"shop"},` +
+		strings.Repeat(`"spec":{"containers":[{"image":"nginx:1.27","name":"web"}]},`, 20) + "}"
+	detail := "Pod: web-0\nNamespace: shop\nAnnotations:\n" + annotation + "\nStatus: Running"
+
+	for _, width := range []int{1, 2, 3, 10} {
+		for _, selected := range []int{-1, 3} {
+			t.Run(fmt.Sprintf("width %d selected %d", width, selected), func(t *testing.T) {
+				body, row := RenderDetailBody(detail, nil, "", width, true, selected)
+				for i, line := range strings.Split(body, "\n") {
+					if w := ansi.StringWidth(line); w > width {
+						t.Errorf("line %d is %d cells wide: %q", i, w, line)
+					}
+				}
+				// The lines above the selected one take a row each at least
+				if selected >= 0 && row < selected {
+					t.Errorf("selected row = %d, want %d or more", row, selected)
+				}
+				if got := strings.Join(strings.Fields(ansi.Strip(body)), ""); !strings.Contains(got, `"name":"web-0"`) {
+					t.Errorf("annotation lost when wrapped: %q", got)
+				}
+			})
+		}
+	}
+}
@@ -1,18 +1,224 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-isatty"
+	"github.com/zvelocity/k8s-cli/internal/client"
+	"github.com/zvelocity/k8s-cli/internal/config"
 	"github.com/zvelocity/k8s-cli/internal/model"
+	"github.com/zvelocity/k8s-cli/internal/ui"
 )
 
 func main() {
-	// Create and run the program with alt screen enabled
-	p := tea.NewProgram(model.New(), tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	var opts model.Options
+	flag.StringVar(&opts.Kubeconfig, "kubeconfig", "", "path to the kubeconfig file (overrides KUBECONFIG)")
+	flag.StringVar(&opts.Server, "server", "", "API server URL, bypasses kubeconfig when set")
+	flag.StringVar(&opts.Token, "token", "", "bearer token used with --server")
+	flag.StringVar(&opts.CACert, "certificate-authority", "", "path to a CA certificate used with --server")
+	flag.BoolVar(&opts.Insecure, "insecure-skip-tls-verify", false, "skip verification of the server certificate")
+	flag.StringVar(&opts.APIServer, "api-server", "", "API server URL replacing the kubeconfig one, keeping its credentials (e.g. http://localhost:8001 for kubectl proxy)")
+	flag.StringVar(&opts.As, "as", "", "user to impersonate, like kubectl --as")
+	flag.Var((*stringList)(&opts.AsGroups), "as-group", "group to impersonate, can be repeated, requires --as")
+	flag.DurationVar(&opts.LoadTimeout, "load-timeout", 10*time.Second, "how long a request may take before offering to cancel it")
+	flag.StringVar(&opts.Namespace, "namespace", "", "namespace to start in (overrides defaultNamespace in the config file)")
+	view := flag.String("view", "", "view to start on: dashboard, pods, services, secrets, namespaces, top or fleet (overrides defaultView)")
+	flag.DurationVar(&opts.RefreshInterval, "refresh-interval", 0, "how often follow mode and the dashboard refresh (overrides refreshInterval)")
+	flag.Int64Var(&opts.LogTailLines, "log-tail", 0, "how many log lines opening logs fetches, 1000 by default (overrides logTailLines)")
+	flag.DurationVar(&opts.LogSince, "log-since", 0, "only fetch the log lines written within this duration, e.g. 15m (overrides logSince)")
+	theme := flag.String("theme", "", "color theme: default or monochrome (overrides theme)")
+	noColor := flag.Bool("no-color", false, "render without colors or other escape codes, like setting NO_COLOR")
+	flag.BoolVar(&opts.ReadOnly, "read-only", false, "disable every action that changes the cluster (delete, edit), whatever RBAC allows")
+	flag.BoolVar(&opts.HideSecrets, "hide-secrets", false, "never show what secrets hold: no revealed env values, only key names and sizes")
+	flag.BoolVar(&opts.Inline, "no-alt-screen", false, "render in the terminal instead of the alternate screen, leaving the last view in the scrollback")
+	noTUI := flag.Bool("no-tui", false, "print to stdout instead of starting the interface, e.g. --no-tui pods --summary")
+	summary := flag.Bool("summary", false, "with --no-tui pods, print one line counting the pods by status, exiting with 1 when any is failing")
+	flag.Parse()
+
+	// The resource of --no-tui may come before more flags
+	resource := ""
+	if flag.NArg() > 0 {
+		resource = flag.Arg(0)
+		if err := flag.CommandLine.Parse(flag.Args()[1:]); err != nil {
+			os.Exit(2)
+		}
+		if flag.NArg() > 0 {
+			fmt.Fprintf(os.Stderr, "Error: unexpected argument %q\n", flag.Arg(0))
+			os.Exit(2)
+		}
+	}
+	if resource != "" && !*noTUI {
+		fmt.Fprintf(os.Stderr, "Error: unexpected argument %q, resources are only given with --no-tui\n", resource)
+		os.Exit(2)
+	}
+	if *noTUI && resource == "" {
+		fmt.Fprintln(os.Stderr, "Error: --no-tui requires a resource, e.g. --no-tui pods --summary")
+		os.Exit(2)
+	}
+
+	// An unreadable config file falls back to the defaults in read-only
+	// mode with secrets hidden, since it may have asked for them, flags
+	// still apply
+	cfg, warnings, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v, using defaults in read-only mode with secrets hidden\n", err)
+		opts.Warning = fmt.Sprintf("Config ignored, read-only with secrets hidden: %v", err)
+		opts.ReadOnly = true
+		opts.HideSecrets = true
+	} else {
+		// Invalid settings, unknown columns, invalid rules and conflicting
+		// keys are skipped, the rest of the config applies
+		warnings = append(warnings, applyConfig(&opts, cfg, *view == "", *theme == "")...)
+		warnings = append(warnings, applyListConfig(&opts, cfg)...)
+	}
+	if len(warnings) > 0 {
+		for _, w := range warnings {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+		}
+		opts.Warning = "Config: " + strings.Join(warnings, "; ")
+	}
+
+	if *view != "" {
+		if opts.StartView, err = model.ParseStartView(*view); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --view: %v\n", err)
+			os.Exit(2)
+		}
+	}
+	if *theme != "" {
+		if err := ui.SetTheme(*theme); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --theme: %v\n", err)
+			os.Exit(2)
+		}
+	}
+	// NO_COLOR (https://no-color.org) turns colors off whatever the theme,
+	// and so does --no-tui output going to a file or a pipe
+	if *noColor || os.Getenv("NO_COLOR") != "" || *noTUI && !isatty.IsTerminal(os.Stdout.Fd()) {
+		ui.DisableColor()
+	}
+
+	protected, err := model.ParseProtectedContexts(os.Getenv(model.ProtectedContextsEnv))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s: %v\n", model.ProtectedContextsEnv, err)
+		os.Exit(2)
+	}
+	opts.ProtectedContexts = protected
+
+	if opts.Server == "" && (opts.Token != "" || opts.CACert != "" || opts.Insecure) {
+		fmt.Fprintln(os.Stderr, "Error: --token, --certificate-authority and --insecure-skip-tls-verify require --server")
+		os.Exit(2)
+	}
+	if len(opts.AsGroups) > 0 && opts.As == "" {
+		fmt.Fprintln(os.Stderr, "Error: --as-group requires --as")
+		os.Exit(2)
+	}
+	if opts.APIServer != "" {
+		if opts.Server != "" {
+			fmt.Fprintln(os.Stderr, "Error: --api-server cannot be used with --server")
+			os.Exit(2)
+		}
+		if err := client.ValidateServerURL(opts.APIServer); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --api-server: %v\n", err)
+			os.Exit(2)
+		}
+	}
+
+	// The root context is cancelled on SIGINT, SIGTERM or SIGHUP (the
+	// terminal was closed) so watches and follows stop before exiting
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+
+	if *noTUI {
+		code := runNoTUI(ctx, opts, resource, *summary)
+		stop()
+		os.Exit(code)
+	}
+
+	// Create and run the program on the alt screen with mouse support, unless
+	// asked to stay in the terminal's scrollback. Cancelling ctx kills the
+	// program, which restores the terminal first.
+	programOpts := []tea.ProgramOption{tea.WithContext(ctx)}
+	if !opts.Inline {
+		programOpts = append(programOpts, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	}
+	p := tea.NewProgram(model.New(ctx, opts), programOpts...)
+	_, err = p.Run()
+	signalled := ctx.Err() != nil
+	stop()
+	if signalled && errors.Is(err, tea.ErrProgramKilled) {
+		os.Exit(1)
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error running program: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+// stringList is a flag that can be repeated, collecting its values
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// applyListConfig sets the columns and row styles of the lists and the key
+// bindings from the config file, returning warnings for the entries left out
+func applyListConfig(opts *model.Options, cfg config.Config) []string {
+	warnings := ui.SetColumns(cfg.Columns)
+	keys, keyWarnings := model.NewKeyBindings(cfg.Keys)
+	opts.Keys = keys
+	warnings = append(warnings, keyWarnings...)
+	for i, rule := range cfg.RowStyles {
+		if err := ui.AddRowStyle(rule.Selector, rule.Style); err != nil {
+			warnings = append(warnings, fmt.Sprintf("rowStyles[%d]: %v", i, err))
+		}
+	}
+	return warnings
+}
+
+// applyConfig fills the options not set by flags from the config file,
+// returning warnings for the view and theme left out as invalid
+func applyConfig(opts *model.Options, cfg config.Config, useView, useTheme bool) []string {
+	var warnings []string
+	if useView && cfg.DefaultView != "" {
+		if view, err := model.ParseStartView(cfg.DefaultView); err != nil {
+			warnings = append(warnings, fmt.Sprintf("defaultView: %v", err))
+		} else {
+			opts.StartView = view
+		}
+	}
+	if useTheme {
+		if err := ui.SetTheme(cfg.Theme); err != nil {
+			warnings = append(warnings, fmt.Sprintf("theme: %v", err))
+		}
+	}
+
+	if opts.Namespace == "" {
+		opts.Namespace = cfg.DefaultNamespace
+	}
+	if opts.RefreshInterval == 0 {
+		opts.RefreshInterval = cfg.Interval()
+	}
+	if opts.LogTailLines == 0 {
+		opts.LogTailLines = cfg.LogTailLines
+	}
+	if opts.LogSince == 0 {
+		opts.LogSince = cfg.Since()
+	}
+	opts.ReadOnly = opts.ReadOnly || cfg.ReadOnly
+	opts.HideSecrets = opts.HideSecrets || cfg.HideSecrets
+	opts.FavoriteNamespaces = cfg.FavoriteNamespaces
+	return warnings
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/zvelocity/k8s-cli/internal/client"
+	"github.com/zvelocity/k8s-cli/internal/model"
+	"github.com/zvelocity/k8s-cli/internal/resources"
+)
+
+// runNoTUI prints what --no-tui asks for instead of starting the interface
+// and returns the exit code: 1 when a pod is failing or the cluster can't be
+// reached, 2 for invalid arguments
+func runNoTUI(ctx context.Context, opts model.Options, resource string, summary bool) int {
+	if resource != "pods" {
+		fmt.Fprintf(os.Stderr, "Error: --no-tui: unsupported resource %q, expected pods\n", resource)
+		return 2
+	}
+	if !summary {
+		fmt.Fprintln(os.Stderr, "Error: --no-tui pods requires --summary")
+		return 2
+	}
+
+	as := client.Impersonation{User: opts.As, Groups: opts.AsGroups}
+	var c *client.K8sClient
+	var err error
+	if opts.Server != "" {
+		c, err = client.NewFromToken(opts.Server, opts.Token, opts.CACert, opts.Insecure, as)
+	} else {
+		c, err = client.NewWithContext(opts.Kubeconfig, opts.Context, opts.APIServer, as)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	namespace := opts.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+	ctx, cancel := context.WithTimeout(ctx, opts.LoadTimeout)
+	defer cancel()
+	pods, err := c.GetPods(ctx, namespace, "")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	s := resources.SummarizePods(pods)
+	fmt.Printf("%s: %s\n", namespace, s)
+	if s.Failing > 0 {
+		return 1
+	}
+	return 0
+}